@@ -28,6 +28,16 @@ type ADIIO struct {
 	LowerCase           bool // TODO consider a case enum: keep, upper, lower, or just get rid of this option
 	ASCIIOnly           bool
 	FieldSep, RecordSep Separator
+	// Lenient recovers from missing <EOR> tags and extra <EOH> tags while
+	// reading, writing a message to Warnings (if not nil) rather than failing.
+	// A missing <EOR> is detected when a field name repeats in a record.
+	Lenient  bool
+	Warnings io.Writer
+	// OmitEmpty skips record fields with an empty value while writing.
+	OmitEmpty bool
+	// AppendRecords skips the header while writing, so records can be added to
+	// the end of an existing ADI file.
+	AppendRecords bool
 }
 
 func NewADIIO() *ADIIO {
@@ -36,7 +46,9 @@ func NewADIIO() *ADIIO {
 
 func (_ *ADIIO) String() string { return "adi" }
 
-func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
+func (o *ADIIO) Read(in io.Reader) (*Logfile, error) { return readAll(o, in) }
+
+func (o *ADIIO) ReadRecords(in io.Reader, fn func(*Logfile, *Record) bool) (*Logfile, error) {
 	var comments []string
 	l := NewLogfile()
 	r := bufio.NewReader(in)
@@ -58,6 +70,8 @@ func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
 	// software though, so allow an <EOH> even if we didn't get a comment.
 	cur := NewRecord()
 	var sawHeader, sawRecord bool
+	// number of records passed to fn
+	var n int
 	for { // invariant: last byte read was '<'
 		s, err = r.ReadString('>')
 		if errors.Is(err, io.EOF) {
@@ -74,6 +88,17 @@ func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
 		case 1:
 			switch strings.ToUpper(tag[0]) {
 			case "EOH":
+				if o.Lenient && (sawHeader || sawRecord) {
+					o.warnf("ignoring extra <EOH> after %d records", n)
+					for _, f := range cur.Fields() {
+						if _, ok := l.Header.Get(f.Name); !ok {
+							l.Header.Set(f)
+						}
+					}
+					cur = NewRecord()
+					comments = nil
+					break
+				}
 				if sawHeader {
 					return nil, fmt.Errorf("invalid ADI file with two <EOH> headers")
 				}
@@ -88,7 +113,10 @@ func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
 			case "EOR":
 				sawRecord = true
 				cur.SetComment(strings.Join(comments, o.RecordSep.Val()))
-				l.AddRecord(cur)
+				n++
+				if !fn(l, cur) {
+					return l, nil
+				}
 				cur = NewRecord()
 				comments = nil
 			default:
@@ -136,6 +164,17 @@ func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
 						return nil, fmt.Errorf("%v from <%s", err, s)
 					}
 				}
+				if _, ok := cur.Get(f.Name); ok && o.Lenient {
+					o.warnf("missing <EOR> before repeated %s field, starting record %d", f.Name, n+2)
+					sawRecord = true
+					cur.SetComment(strings.Join(comments, o.RecordSep.Val()))
+					n++
+					if !fn(l, cur) {
+						return l, nil
+					}
+					cur = NewRecord()
+					comments = nil
+				}
 				cur.Set(f)
 			}
 		default:
@@ -149,7 +188,13 @@ func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
 		}
 		if errors.Is(err, io.EOF) {
 			if len(cur.fields) != 0 {
-				return nil, fmt.Errorf("final record missing <EOR>: %s", cur)
+				if !o.Lenient {
+					return nil, fmt.Errorf("final record missing <EOR>: %s", cur)
+				}
+				o.warnf("final record missing <EOR>: %s", cur)
+				if !fn(l, cur) {
+					return l, nil
+				}
 			}
 			if len(comments) > 0 {
 				l.Comment = strings.Join(comments, o.RecordSep.Val())
@@ -162,6 +207,12 @@ func (o *ADIIO) Read(in io.Reader) (*Logfile, error) {
 	}
 }
 
+func (o *ADIIO) warnf(format string, a ...any) {
+	if o.Warnings != nil {
+		fmt.Fprintf(o.Warnings, "Warning: ADI "+format+"\n", a...)
+	}
+}
+
 const defaultAdiComment = "ADI format, see https://adif.org.uk/"
 
 func (o *ADIIO) Write(l *Logfile, out io.Writer) error {
@@ -169,7 +220,7 @@ func (o *ADIIO) Write(l *Logfile, out io.Writer) error {
 		return err
 	}
 	b := bufio.NewWriter(out)
-	if !l.Header.Empty() {
+	if !o.AppendRecords && !l.Header.Empty() {
 		c := l.Header.GetComment()
 		if c == "" {
 			c = defaultAdiComment
@@ -188,7 +239,7 @@ func (o *ADIIO) Write(l *Logfile, out io.Writer) error {
 		if _, err := b.WriteString(fmt.Sprintf("<%s>%s", o.fixCase("EOH"), o.RecordSep.Val())); err != nil {
 			return fmt.Errorf("writing ADI header: %w", err)
 		}
-	} else if len(l.Userdef) > 0 { // add a header for the userdef fields
+	} else if !o.AppendRecords && len(l.Userdef) > 0 { // add a header for the userdef fields
 		if err := o.writeComment(b, defaultAdiComment, o.RecordSep.Val()); err != nil {
 			return fmt.Errorf("writing ADI header: %w", err)
 		}
@@ -209,13 +260,19 @@ func (o *ADIIO) Write(l *Logfile, out io.Writer) error {
 		seen := make(map[string]bool)
 		for _, n := range l.FieldOrder {
 			if f, ok := r.Get(n); ok {
+				seen[f.Name] = true
+				if o.OmitEmpty && f.Value == "" {
+					continue
+				}
 				if err := o.writeField(f, b); err != nil {
 					return fmt.Errorf("writing ADI record #%d: %w", i, err)
 				}
-				seen[f.Name] = true
 			}
 		}
 		for _, f := range r.Fields() {
+			if o.OmitEmpty && f.Value == "" {
+				continue
+			}
 			if !seen[f.Name] {
 				if err := o.writeField(f, b); err != nil {
 					return fmt.Errorf("writing ADI record #%d: %w", i, err)
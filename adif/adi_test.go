@@ -121,6 +121,51 @@ Inverted L antenna, 70' above ground
 	}
 }
 
+func TestReadADILenient(t *testing.T) {
+	input := `Header comment <PROGRAMID:4>test <EOH>
+<CALL:4>W1AW <BAND:3>40m <EOR>
+<CALL:3>K2B <BAND:3>20m
+<CALL:3>K3C <BAND:3>15m <EOR>
+Second file <PROGRAMID:5>other <ADIF_VER:5>3.1.5 <EOH>
+<CALL:3>K4D <BAND:3>10m
+`
+	wantFields := [][]Field{
+		{{Name: "CALL", Value: "W1AW"}, {Name: "BAND", Value: "40m"}},
+		{{Name: "CALL", Value: "K2B"}, {Name: "BAND", Value: "20m"}},
+		{{Name: "CALL", Value: "K3C"}, {Name: "BAND", Value: "15m"}},
+		{{Name: "CALL", Value: "K4D"}, {Name: "BAND", Value: "10m"}},
+	}
+	wantHeader := []Field{{Name: "PROGRAMID", Value: "test"}, {Name: "ADIF_VER", Value: "3.1.5"}}
+	adi := NewADIIO()
+	if _, err := adi.Read(strings.NewReader(input)); err == nil {
+		t.Errorf("Read(%q) without Lenient want error, got nil", input)
+	}
+	warnings := &strings.Builder{}
+	adi.Lenient = true
+	adi.Warnings = warnings
+	parsed, err := adi.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read(%q) with Lenient got error %v", input, err)
+	}
+	got := make([][]Field, len(parsed.Records))
+	for i, r := range parsed.Records {
+		got[i] = r.Fields()
+	}
+	if diff := cmp.Diff(wantFields, got); diff != "" {
+		t.Errorf("Read(%q) with Lenient unexpected records, diff:\n%s", input, diff)
+	}
+	if diff := cmp.Diff(wantHeader, parsed.Header.Fields()); diff != "" {
+		t.Errorf("Read(%q) with Lenient unexpected header, diff:\n%s", input, diff)
+	}
+	wantWarnings := `Warning: ADI missing <EOR> before repeated CALL field, starting record 3
+Warning: ADI ignoring extra <EOH> after 3 records
+Warning: ADI final record missing <EOR>: [CALL=K4D BAND=10m]
+`
+	if diff := cmp.Diff(wantWarnings, warnings.String()); diff != "" {
+		t.Errorf("Read(%q) with Lenient unexpected warnings, diff:\n%s", input, diff)
+	}
+}
+
 func TestWriteADI(t *testing.T) {
 	l := NewLogfile()
 	l.Comment = "The <last> word."
@@ -153,10 +198,12 @@ Inverted L antenna, 70' above ground
 		Field{Name: "SHOESIZE", Value: "12"},
 	))
 	l.Records[1].SetComment("Record comment")
-	l.Header.Set(Field{Name: "ADIF_VER", Value: "3.1.4"})
-	l.Header.Set(Field{Name: "PROGRAMID", Value: "adi_test"})
-	l.Header.Set(Field{Name: "PROGRAMVERSION", Value: "1.2.3"})
-	l.Header.Set(Field{Name: "CREATED_TIMESTAMP", Value: "20220102 153456"})
+	l.Header = NewRecord(
+		Field{Name: "ADIF_VER", Value: "3.1.4"},
+		Field{Name: "PROGRAMID", Value: "adi_test"},
+		Field{Name: "PROGRAMVERSION", Value: "1.2.3"},
+		Field{Name: "CREATED_TIMESTAMP", Value: "20220102 153456"},
+	)
 	l.Userdef = []UserdefField{
 		{Name: "MY FIELD", Type: TypeString},
 		{Name: "sweatersize", Type: TypeEnumeration, EnumValues: []string{"S", "M", "L"}},
@@ -189,6 +236,45 @@ The &lt;last&gt; word.
 	}
 }
 
+func TestWriteADIOmitEmpty(t *testing.T) {
+	l := NewLogfile()
+	l.FieldOrder = []string{"CALL", "NAME"}
+	l.AddRecord(NewRecord(
+		Field{Name: "CALL", Value: "W1AW"},
+		Field{Name: "NAME", Value: ""},
+		Field{Name: "BAND", Value: "40M"},
+		Field{Name: "QTH", Value: ""},
+	))
+	adi := NewADIIO()
+	adi.OmitEmpty = true
+	out := &strings.Builder{}
+	if err := adi.Write(l, out); err != nil {
+		t.Fatalf("Write(%v) got error %v", l, err)
+	}
+	want := "<CALL:4>W1AW <BAND:3>40M <EOR>\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Write(%v) with OmitEmpty had diff with expected:\n%s", l, diff)
+	}
+}
+
+func TestWriteADIAppendRecords(t *testing.T) {
+	l := NewLogfile()
+	l.Header.SetComment("ignored")
+	l.Header.Set(Field{Name: "ADIF_VER", Value: "3.1.4"})
+	l.AddUserdef(UserdefField{Name: "COLOR", Type: TypeString})
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "W1AW"}, Field{Name: "COLOR", Value: "blue"}))
+	adi := NewADIIO()
+	adi.AppendRecords = true
+	out := &strings.Builder{}
+	if err := adi.Write(l, out); err != nil {
+		t.Fatalf("Write(%v) got error %v", l, err)
+	}
+	want := "<CALL:4>W1AW <COLOR:4>blue <EOR>\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Write(%v) with AppendRecords had diff with expected:\n%s", l, diff)
+	}
+}
+
 func TestADIASCIIOnly(t *testing.T) {
 	adi := NewADIIO()
 	adi.ASCIIOnly = true
@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adif
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// zipMagic is the start of a ZIP file's first local file header.
+var zipMagic = []byte("PK\x03\x04")
+
+// ADIZIO reads and writes ZIP archives containing a single ADI or ADX file.
+// Reading and writing the contained file uses the options in ADI and ADX.
+type ADIZIO struct {
+	ADI *ADIIO
+	ADX *ADXIO
+	// EntryName is the name of the file in the archive when writing; the
+	// extension must be .adi or .adx.  Defaults to log.adi.
+	EntryName string
+}
+
+func NewADIZIO(adi *ADIIO, adx *ADXIO) *ADIZIO {
+	return &ADIZIO{ADI: adi, ADX: adx}
+}
+
+func (o *ADIZIO) String() string { return "adiz" }
+
+func (o *ADIZIO) Read(in io.Reader) (*Logfile, error) {
+	// zip needs random access to find the central directory at the end
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("could not read ADIZ archive: %w", err)
+	}
+	var entry *zip.File
+	for _, f := range z.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if _, err := o.entryIO(f.Name); err != nil {
+			continue
+		}
+		if entry != nil {
+			return nil, fmt.Errorf("ADIZ archive has more than one log file: %s and %s", entry.Name, f.Name)
+		}
+		entry = f
+	}
+	if entry == nil {
+		return nil, errors.New("ADIZ archive does not contain an .adi or .adx file")
+	}
+	r, _ := o.entryIO(entry.Name)
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s in ADIZ archive: %w", entry.Name, err)
+	}
+	defer rc.Close()
+	return r.Read(rc)
+}
+
+func (o *ADIZIO) Write(l *Logfile, out io.Writer) error {
+	name := o.EntryName
+	if name == "" {
+		name = "log.adi"
+	}
+	w, err := o.entryIO(name)
+	if err != nil {
+		return err
+	}
+	z := zip.NewWriter(out)
+	f, err := z.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err := w.Write(l, f); err != nil {
+		return err
+	}
+	return z.Close()
+}
+
+// entryIO returns the ADI or ADX ReadWriter for a file in the archive.
+func (o *ADIZIO) entryIO(name string) (ReadWriter, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".adi", ".adif":
+		return o.ADI, nil
+	case ".adx":
+		return o.ADX, nil
+	default:
+		return nil, fmt.Errorf("%s is not an .adi or .adx file", name)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adif
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func makeZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	z := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestADIZRoundTrip(t *testing.T) {
+	l := NewLogfile()
+	l.Header.Set(Field{Name: "PROGRAMID", Value: "adiz test"})
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "K1A"}, Field{Name: "BAND", Value: "20m"}))
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "K2B"}, Field{Name: "BAND", Value: "40m"}))
+	for _, name := range []string{"", "mylog.adi", "mylog.adx"} {
+		adiz := NewADIZIO(NewADIIO(), NewADXIO())
+		adiz.EntryName = name
+		buf := &bytes.Buffer{}
+		if err := adiz.Write(l, buf); err != nil {
+			t.Fatalf("Write with entry %q got error %v", name, err)
+		}
+		if f, err := GuessFormatFromContent(bufio.NewReader(bytes.NewReader(buf.Bytes()))); err != nil || f != FormatADIZ {
+			t.Errorf("GuessFormatFromContent on ADIZ with entry %q got %s, %v", name, f, err)
+		}
+		got, err := adiz.Read(buf)
+		if err != nil {
+			t.Fatalf("Read with entry %q got error %v", name, err)
+		}
+		if diff := cmp.Diff(l.Records, got.Records, cmp.Comparer(func(a, b *Record) bool { return a.Equal(b) })); diff != "" {
+			t.Errorf("Read(Write(l)) with entry %q unexpected records, diff:\n%s", name, diff)
+		}
+		if p, _ := got.Header.Get("PROGRAMID"); p.Value != "adiz test" {
+			t.Errorf("Read(Write(l)) with entry %q got header %v", name, got.Header)
+		}
+	}
+}
+
+func TestADIZRead(t *testing.T) {
+	adi := "<CALL:3>K1A <EOR>\n"
+	adx := "<ADX><RECORDS><RECORD><CALL>K2B</CALL></RECORD></RECORDS></ADX>"
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "adi", files: map[string]string{"log.adi": adi}, want: "K1A"},
+		{name: "adx", files: map[string]string{"log.ADX": adx}, want: "K2B"},
+		{name: "other files", files: map[string]string{"README.txt": "hello", "logs/contest.adif": adi}, want: "K1A"},
+		{name: "two logs", files: map[string]string{"a.adi": adi, "b.adx": adx}, wantErr: true},
+		{name: "no logs", files: map[string]string{"log.csv": "CALL\nK1A\n"}, wantErr: true},
+		{name: "empty", files: map[string]string{}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewADIZIO(NewADIIO(), NewADXIO()).Read(bytes.NewReader(makeZip(t, tc.files)))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Read(%v) got %v, want error", tc.files, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Read(%v) got error %v", tc.files, err)
+			}
+			if len(got.Records) != 1 {
+				t.Fatalf("Read(%v) got %d records, want 1", tc.files, len(got.Records))
+			}
+			if c, _ := got.Records[0].Get("CALL"); c.Value != tc.want {
+				t.Errorf("Read(%v) got CALL %q, want %q", tc.files, c.Value, tc.want)
+			}
+		})
+	}
+	if got, err := NewADIZIO(NewADIIO(), NewADXIO()).Read(strings.NewReader(adi)); err == nil {
+		t.Errorf("Read(%q) got %v, want error", adi, got)
+	}
+}
@@ -15,7 +15,9 @@
 package adif
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -38,11 +40,24 @@ func (f adxField) IsUserdef() bool { return f.XMLName.Local == "USERDEF" }
 
 func (f adxField) IsAppDefined() bool { return f.XMLName.Local == "APP" }
 
-func (f adxField) Field() Field {
+// Field converts f to a Field.  Elements in an extension namespace become
+// app-defined fields named with the namespace prefix, e.g. <ext:GRID> with
+// xmlns:ext declared becomes APP_EXT_GRID.  ns maps namespace URLs to prefixes;
+// a default namespace has an empty prefix and is treated as ADIF.
+func (f adxField) Field(ns map[string]string) Field {
 	dt, err := DataTypeFromIndicator(f.Type)
 	if err != nil {
 		dt = TypeUnspecified
 	}
+	if f.XMLName.Space != "" {
+		prefix, ok := ns[f.XMLName.Space]
+		if !ok {
+			prefix = f.XMLName.Space // undeclared prefix is not translated
+		}
+		if prefix != "" {
+			return Field{Name: fmt.Sprintf("APP_%s_%s", strings.ToUpper(prefix), f.XMLName.Local), Value: f.Value, Type: dt}
+		}
+	}
 	if f.IsUserdef() {
 		return Field{Name: f.FieldName, Value: f.Value, Type: dt}
 	}
@@ -128,7 +143,7 @@ type adxRecord struct {
 	Fields  []adxField `xml:",any"`
 }
 
-func (r adxRecord) Record(header bool) *Record {
+func (r adxRecord) Record(header bool, ns map[string]string) *Record {
 	res := NewRecord()
 	fcs := make([]string, 0, len(r.Fields))
 	if r.Comment != "" {
@@ -138,7 +153,7 @@ func (r adxRecord) Record(header bool) *Record {
 		if header && f.IsUserdef() {
 			continue
 		}
-		res.Set(f.Field())
+		res.Set(f.Field(ns))
 		if f.Comment != "" {
 			fcs = append(fcs, f.Comment)
 		}
@@ -191,6 +206,11 @@ type adxFile struct {
 
 type ADXIO struct {
 	Indent int
+	// OmitEmpty skips record fields with an empty value while writing.
+	OmitEmpty bool
+	// ValidateSchema checks that output is well-formed XML with the element
+	// structure of the ADX schema before writing anything.
+	ValidateSchema bool
 }
 
 func NewADXIO() *ADXIO {
@@ -202,12 +222,17 @@ func (_ *ADXIO) String() string { return "adx" }
 func (o *ADXIO) Read(in io.Reader) (*Logfile, error) {
 	l := NewLogfile()
 	f := adxFile{}
-	d := xml.NewDecoder(in)
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ADX file: %w", err)
+	}
+	d := xml.NewDecoder(bytes.NewReader(data))
 	if err := d.Decode(&f); err != nil {
 		return nil, fmt.Errorf("could not decode ADX file: %w", err)
 	}
+	ns := adxNamespaces(data)
 	l.Comment = f.Comment
-	l.Header = f.Header.Record(true)
+	l.Header = f.Header.Record(true, ns)
 	us, err := f.Header.UserdefFields()
 	if err != nil {
 		return nil, err
@@ -216,11 +241,33 @@ func (o *ADXIO) Read(in io.Reader) (*Logfile, error) {
 		l.AddUserdef(u)
 	}
 	for _, r := range f.Records {
-		l.AddRecord(r.Record(false))
+		l.AddRecord(r.Record(false, ns))
 	}
 	return l, nil
 }
 
+// adxNamespaces maps XML namespace URLs declared in data to their prefixes.
+// Default namespaces (xmlns="...") map to the empty string.
+func adxNamespaces(data []byte) map[string]string {
+	res := make(map[string]string)
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			return res
+		}
+		if se, ok := t.(xml.StartElement); ok {
+			for _, a := range se.Attr {
+				if a.Name.Space == "xmlns" {
+					res[a.Value] = a.Name.Local
+				} else if a.Name.Space == "" && a.Name.Local == "xmlns" {
+					res[a.Value] = ""
+				}
+			}
+		}
+	}
+}
+
 func (o *ADXIO) Write(l *Logfile, out io.Writer) error {
 	f := adxFile{}
 	f.Header = newAdxRecord(l.Header, l)
@@ -231,11 +278,26 @@ func (o *ADXIO) Write(l *Logfile, out io.Writer) error {
 		f.Header.Fields = append(f.Header.Fields, newAdxUserdef(u, i+1))
 	}
 	for _, r := range l.Records {
-		f.Records = append(f.Records, newAdxRecord(r, l))
+		x := newAdxRecord(r, l)
+		if o.OmitEmpty {
+			fs := x.Fields[:0]
+			for _, xf := range x.Fields {
+				if xf.Value != "" {
+					fs = append(fs, xf)
+				}
+			}
+			x.Fields = fs
+		}
+		f.Records = append(f.Records, x)
 	}
 	if l.Comment != "" {
 		f.Comment = l.Comment
 	}
+	dest := out
+	var buf bytes.Buffer
+	if o.ValidateSchema {
+		out = &buf
+	}
 	if n, err := out.Write([]byte(xml.Header)); err != nil {
 		return fmt.Errorf("could not write XML header to %s: %w", out, err)
 	} else if n != len(xml.Header) {
@@ -250,5 +312,106 @@ func (o *ADXIO) Write(l *Logfile, out io.Writer) error {
 	if _, err := out.Write([]byte("\n")); err != nil {
 		return fmt.Errorf("error writing ADX file %s: %w", l.Filename, err)
 	}
+	if o.ValidateSchema {
+		if err := validateADXStructure(buf.Bytes()); err != nil {
+			return fmt.Errorf("ADX output does not match schema: %w", err)
+		}
+		if _, err := dest.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("error writing ADX file %s: %w", l.Filename, err)
+		}
+	}
+	return nil
+}
+
+// validateADXStructure checks that data is well-formed XML following the
+// element structure of ADIF_Schema.xsd: an ADX root with an optional HEADER
+// before RECORDS, which only contains RECORD elements.  Fields in HEADER
+// and RECORD elements can't have child elements.  APP fields need PROGRAMID
+// and FIELDNAME attributes; USERDEF fields need FIELDID in the header and
+// FIELDNAME in records.  Field names and values are not checked.
+func validateADXStructure(data []byte) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var path []string
+	var header, records bool
+	for {
+		t, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			switch len(path) {
+			case 0:
+				if name != "ADX" {
+					return fmt.Errorf("root element is <%s>, want <ADX>", name)
+				}
+			case 1:
+				if name == "HEADER" && !header && !records {
+					header = true
+				} else if name == "RECORDS" && !records {
+					records = true
+				} else {
+					return fmt.Errorf("unexpected <%s> in <ADX>", name)
+				}
+			case 2:
+				if path[1] == "RECORDS" {
+					if name != "RECORD" {
+						return fmt.Errorf("unexpected <%s> in <RECORDS>", name)
+					}
+				} else if err := validateADXFieldAttrs(t, true); err != nil {
+					return err
+				}
+			case 3:
+				if path[1] == "HEADER" {
+					return fmt.Errorf("header field <%s> has child element <%s>", path[2], name)
+				}
+				if err := validateADXFieldAttrs(t, false); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("field <%s> has child element <%s>", path[len(path)-1], name)
+			}
+			path = append(path, name)
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		case xml.CharData:
+			inField := len(path) == 4 || (len(path) == 3 && path[1] == "HEADER")
+			if !inField && len(bytes.TrimSpace(t)) > 0 {
+				return fmt.Errorf("unexpected text %q outside a field", bytes.TrimSpace(t))
+			}
+		}
+	}
+	return nil
+}
+
+func validateADXFieldAttrs(e xml.StartElement, header bool) error {
+	has := func(name string) bool {
+		for _, a := range e.Attr {
+			if a.Name.Local == name && a.Value != "" {
+				return true
+			}
+		}
+		return false
+	}
+	var want []string
+	switch e.Name.Local {
+	case "APP":
+		want = []string{"PROGRAMID", "FIELDNAME"}
+	case "USERDEF":
+		if header {
+			want = []string{"FIELDID"}
+		} else {
+			want = []string{"FIELDNAME"}
+		}
+	}
+	for _, a := range want {
+		if !has(a) {
+			return fmt.Errorf("<%s> field missing %s attribute", e.Name.Local, a)
+		}
+	}
 	return nil
 }
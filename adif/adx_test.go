@@ -149,6 +149,36 @@ Inverted L antenna, 70' above ground
 	}
 }
 
+func TestReadADXNamespaces(t *testing.T) {
+	input := xml.Header + `<ADX xmlns="http://www.adif.org/adx" xmlns:log="http://example.com/mylog">
+<HEADER><ADIF_VER>3.1.5</ADIF_VER><log:VERSION>2.0</log:VERSION></HEADER>
+<RECORDS>
+<RECORD><CALL>W1AW</CALL><log:CALL>W1AW/P</log:CALL><log:RIG xmlns:log="http://example.com/mylog">IC-705</log:RIG></RECORD>
+<RECORD><CALL>K1A</CALL><rig:ANTENNA xmlns:rig="urn:rigs">Dipole</rig:ANTENNA><x:EXTRA>1</x:EXTRA></RECORD>
+</RECORDS>
+</ADX>`
+	adx := NewADXIO()
+	l, err := adx.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read(%q) got error %v", input, err)
+	}
+	if h, _ := l.Header.Get("APP_LOG_VERSION"); h.Value != "2.0" {
+		t.Errorf("Read(%q) header got APP_LOG_VERSION %q, want 2.0", input, h.Value)
+	}
+	wantFields := [][]Field{
+		{{Name: "CALL", Value: "W1AW"}, {Name: "APP_LOG_CALL", Value: "W1AW/P"}, {Name: "APP_LOG_RIG", Value: "IC-705"}},
+		{{Name: "CALL", Value: "K1A"}, {Name: "APP_RIG_ANTENNA", Value: "Dipole"}, {Name: "APP_X_EXTRA", Value: "1"}},
+	}
+	if len(l.Records) != len(wantFields) {
+		t.Fatalf("Read(%q) got %d records, want %d", input, len(l.Records), len(wantFields))
+	}
+	for i, r := range l.Records {
+		if diff := cmp.Diff(wantFields[i], r.Fields()); diff != "" {
+			t.Errorf("Read(%q) record %d unexpected fields, diff:\n%s", input, i+1, diff)
+		}
+	}
+}
+
 func TestWriteADX(t *testing.T) {
 	l := NewLogfile()
 	l.Comment = "The <last> word."
@@ -181,10 +211,12 @@ Inverted L antenna, 70' above ground
 		Field{Name: "SHOESIZE", Value: "12"},
 	))
 	l.Records[1].SetComment("Record comment")
-	l.Header.Set(Field{Name: "ADIF_VER", Value: "3.1.4"})
-	l.Header.Set(Field{Name: "PROGRAMID", Value: "adx_test"})
-	l.Header.Set(Field{Name: "PROGRAMVERSION", Value: "1.2.3"})
-	l.Header.Set(Field{Name: "CREATED_TIMESTAMP", Value: "20220102 153456"})
+	l.Header = NewRecord(
+		Field{Name: "ADIF_VER", Value: "3.1.4"},
+		Field{Name: "PROGRAMID", Value: "adx_test"},
+		Field{Name: "PROGRAMVERSION", Value: "1.2.3"},
+		Field{Name: "CREATED_TIMESTAMP", Value: "20220102 153456"},
+	)
 	l.Header.SetComment("Header comment")
 	l.Userdef = []UserdefField{
 		{Name: "MY FIELD", Type: TypeString},
@@ -237,13 +269,62 @@ Inverted L antenna, 70' above ground
 `
 	adx := NewADXIO()
 	adx.Indent = 2
-	out := &strings.Builder{}
-	if err := adx.Write(l, out); err != nil {
-		t.Errorf("Write(%v) got error %v", l, err)
-	} else {
-		got := out.String()
-		if diff := cmp.Diff(want, got); diff != "" {
-			t.Errorf("Write(%v) had diff with expected:\n%s", l, diff)
+	for _, validate := range []bool{false, true} {
+		adx.ValidateSchema = validate
+		out := &strings.Builder{}
+		if err := adx.Write(l, out); err != nil {
+			t.Errorf("Write(%v) with ValidateSchema=%v got error %v", l, validate, err)
+		} else {
+			got := out.String()
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Write(%v) with ValidateSchema=%v had diff with expected:\n%s", l, validate, diff)
+			}
 		}
 	}
 }
+
+func TestWriteADXValidateSchema(t *testing.T) {
+	l := NewLogfile()
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "W1AW"}, Field{Name: "MY FIELD", Value: "oops"}))
+	adx := NewADXIO()
+	adx.ValidateSchema = true
+	out := &strings.Builder{}
+	if err := adx.Write(l, out); err == nil {
+		t.Errorf("Write(%v) with ValidateSchema want error for invalid element name, got\n%s", l, out)
+	} else if out.Len() != 0 {
+		t.Errorf("Write(%v) with ValidateSchema wrote output despite error %v:\n%s", l, err, out)
+	}
+}
+
+func TestValidateADXStructure(t *testing.T) {
+	tests := []struct {
+		name, xml string
+		valid     bool
+	}{
+		{name: "empty records", xml: `<ADX><HEADER></HEADER><RECORDS></RECORDS></ADX>`, valid: true},
+		{name: "no header", xml: `<ADX><RECORDS><RECORD><CALL>W1AW</CALL></RECORD></RECORDS></ADX>`, valid: true},
+		{name: "app and userdef", valid: true, xml: `<ADX><HEADER><USERDEF FIELDID="1">FOO</USERDEF><APP PROGRAMID="X" FIELDNAME="Y">1</APP></HEADER>
+<RECORDS><RECORD><USERDEF FIELDNAME="FOO">bar</USERDEF><APP PROGRAMID="X" FIELDNAME="Y">2</APP></RECORD></RECORDS></ADX>`},
+		{name: "unclosed", xml: `<ADX><RECORDS><RECORD><CALL>W1AW</RECORD></RECORDS></ADX>`},
+		{name: "wrong root", xml: `<ADIF><RECORDS></RECORDS></ADIF>`},
+		{name: "header after records", xml: `<ADX><RECORDS></RECORDS><HEADER></HEADER></ADX>`},
+		{name: "two records elements", xml: `<ADX><RECORDS></RECORDS><RECORDS></RECORDS></ADX>`},
+		{name: "field in records", xml: `<ADX><RECORDS><CALL>W1AW</CALL></RECORDS></ADX>`},
+		{name: "nested field", xml: `<ADX><RECORDS><RECORD><CALL><X>1</X></CALL></RECORD></RECORDS></ADX>`},
+		{name: "nested header field", xml: `<ADX><HEADER><ADIF_VER><X>1</X></ADIF_VER></HEADER><RECORDS></RECORDS></ADX>`},
+		{name: "text in record", xml: `<ADX><RECORDS><RECORD>W1AW</RECORD></RECORDS></ADX>`},
+		{name: "app without programid", xml: `<ADX><RECORDS><RECORD><APP FIELDNAME="Y">1</APP></RECORD></RECORDS></ADX>`},
+		{name: "header userdef without fieldid", xml: `<ADX><HEADER><USERDEF>FOO</USERDEF></HEADER><RECORDS></RECORDS></ADX>`},
+		{name: "record userdef without fieldname", xml: `<ADX><RECORDS><RECORD><USERDEF>bar</USERDEF></RECORD></RECORDS></ADX>`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateADXStructure([]byte(tc.xml))
+			if tc.valid && err != nil {
+				t.Errorf("validateADXStructure(%s) got error %v", tc.xml, err)
+			} else if !tc.valid && err == nil {
+				t.Errorf("validateADXStructure(%s) want error", tc.xml)
+			}
+		})
+	}
+}
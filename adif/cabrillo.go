@@ -43,6 +43,32 @@ type CabrilloIO struct {
 	Categories                             map[string]string
 	MyExchange, TheirExchange, ExtraFields CabrilloFieldList
 	TabDelimiter                           bool
+	// Template, if not nil, provides MyExchange and TheirExchange if those
+	// lists are empty, so exchange flags override the template.
+	Template *CabrilloTemplate
+	// CheckExchange enables ExchangeValidator, which is off by default since
+	// exchange fields vary between contest years and categories.
+	CheckExchange bool
+	// ExchangeValidator, if CheckExchange is set, is called with Contest for
+	// each record before writing; an error stops the write.
+	ExchangeValidator func(contest string, r *Record) error
+	// ScorerFor, if not nil, returns scoring rules for a contest which are used
+	// to compute CLAIMED-SCORE if ClaimedScore is not set.
+	ScorerFor func(contest string) (CabrilloScorer, bool)
+}
+
+// CabrilloScorer computes the claimed score for a contest log: the sum of QSO
+// points for each contact which is not a duplicate, times the number of
+// multipliers.
+type CabrilloScorer interface {
+	// ScoreQSO returns the points for a contact.
+	ScoreQSO(r *Record) int
+	// DupeKey returns a key, e.g. call and band, which is the same for a
+	// contact and its duplicates.  Contacts with an empty key are never
+	// duplicates.
+	DupeKey(r *Record) string
+	// Multipliers returns the total number of multipliers in a log.
+	Multipliers(l *Logfile) int
 }
 
 func NewCabrilloIO() *CabrilloIO {
@@ -53,12 +79,17 @@ func NewCabrilloIO() *CabrilloIO {
 		MyExchange:    make(CabrilloFieldList, 0),
 		TheirExchange: make(CabrilloFieldList, 0),
 		ExtraFields:   make(CabrilloFieldList, 0),
+
+		ExchangeValidator: ValidateCabrilloExchange,
 	}
 }
 
 func (_ *CabrilloIO) String() string { return "cabrillo" }
 
 func (o *CabrilloIO) Read(in io.Reader) (*Logfile, error) {
+	if err := o.applyTemplate(); err != nil {
+		return nil, err
+	}
 	headers := make(map[string]string)
 	s := bufio.NewScanner(in)
 	readLine := func() (k, v string, err error) {
@@ -154,12 +185,26 @@ func (o *CabrilloIO) Read(in io.Reader) (*Logfile, error) {
 	slices.Sort(headorder)
 	for _, k := range headorder {
 		v := headers[k]
-		l.Header.Set(Field{Name: "APP_CABRILLO_" + strings.ReplaceAll(k, "-", "_"), Value: v, Type: TypeString})
+		t := TypeString
+		if strings.Contains(v, "\n") {
+			t = TypeMultilineString
+		}
+		l.Header.Set(Field{Name: "APP_CABRILLO_" + strings.ReplaceAll(k, "-", "_"), Value: v, Type: t})
 	}
 	return l, nil
 }
 
 func (o *CabrilloIO) Write(l *Logfile, out io.Writer) error {
+	if err := o.applyTemplate(); err != nil {
+		return err
+	}
+	if o.CheckExchange && o.Contest != "" && o.ExchangeValidator != nil {
+		for i, r := range l.Records {
+			if err := o.ExchangeValidator(o.Contest, r); err != nil {
+				return fmt.Errorf("record %d: %w", i+1, err)
+			}
+		}
+	}
 	var qlines [][2]string
 	var err error
 	qlines, err = o.toConfig().toLines(l)
@@ -237,10 +282,15 @@ func (o *CabrilloIO) Write(l *Logfile, out io.Writer) error {
 		headers["LOCATION"] = o.Location
 	}
 	if s, ok := headers["CLAIMED-SCORE"]; !ok || s == "" || s == "0" {
-		setHeader("CLAIMED-SCORE", fmt.Sprintf("%d", o.ClaimedScore))
+		score := o.ClaimedScore
+		if score == 0 && o.ScorerFor != nil {
+			if sc, ok := o.ScorerFor(headers["CONTEST"]); ok {
+				score = cabrilloScore(l, sc)
+			}
+		}
+		setHeader("CLAIMED-SCORE", fmt.Sprintf("%d", score))
 	}
-	// TODO compute offtimes
-	setHeader("OFFTIME", "")
+	setHeader("OFFTIME", cabrilloOfftimes(l, o.MinReportedOfftime))
 	cats := o.getCategories(l)
 	for k, v := range cats {
 		if strings.HasPrefix(k, "X-") {
@@ -297,6 +347,161 @@ func (o *CabrilloIO) Write(l *Logfile, out io.Writer) error {
 	return nil
 }
 
+// cabrilloOfftimes returns OFFTIME header lines for each gap between QSOs
+// which is at least min long.  Returns the empty string if min is not positive.
+// Records without a valid QSO_DATE and TIME_ON are ignored.
+func cabrilloOfftimes(l *Logfile, min time.Duration) string {
+	if min <= 0 {
+		return ""
+	}
+	times := make([]time.Time, 0, len(l.Records))
+	for _, r := range l.Records {
+		d, err := r.ParseDate("QSO_DATE")
+		if err != nil {
+			continue
+		}
+		t, err := r.ParseTime("TIME_ON")
+		if err != nil {
+			continue
+		}
+		times = append(times, time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	var offs []string
+	for i := 1; i < len(times); i++ {
+		if times[i].Sub(times[i-1]) >= min {
+			offs = append(offs, times[i-1].Format("2006-01-02 1504")+" "+times[i].Format("2006-01-02 1504"))
+		}
+	}
+	return strings.Join(offs, "\n")
+}
+
+// CabrilloContestRule describes the expected exchange in a contest.
+// Pattern is matched against upper-case STX_STRING and SRX_STRING values.
+type CabrilloContestRule struct {
+	Exchange string
+	Pattern  *regexp.Regexp
+}
+
+var (
+	cqWWRule     = CabrilloContestRule{Exchange: "CQ zone", Pattern: regexp.MustCompile(`^(0?[1-9]|[1-3][0-9]|40)$`)}
+	cqWWRTTYRule = CabrilloContestRule{Exchange: "CQ zone, plus state or province for W/VE",
+		Pattern: regexp.MustCompile(`^(0?[1-9]|[1-3][0-9]|40)( [A-Z]{2,3})?$`)}
+	arrlDXRule = CabrilloContestRule{Exchange: "state or province for W/VE, power for DX",
+		Pattern: regexp.MustCompile(`^([A-Z]{2,3}|[0-9ANOT]*[0-9][0-9ANOT]*W?|[0-9]*KW?)$`)}
+	arrlSSRule = CabrilloContestRule{Exchange: "serial number (optional), precedence, check, and section",
+		Pattern: regexp.MustCompile(`^([0-9]+ ?)?[QABUMS] ?[0-9]{2} ?[A-Z]{2,3}$`)}
+	naqpRule = CabrilloContestRule{Exchange: "name and location",
+		Pattern: regexp.MustCompile(`^[A-Z]+ [A-Z0-9]{1,4}$`)}
+
+	// CabrilloContestRules maps Contest_Id values to exchange rules for
+	// contests with a well-known exchange format.
+	CabrilloContestRules = map[string]CabrilloContestRule{
+		"ARRL-10": {Exchange: "state or province for W/VE, serial number for others",
+			Pattern: regexp.MustCompile(`^([A-Z]{2,3}|[0-9]+)$`)},
+		"ARRL-DX-CW":  arrlDXRule,
+		"ARRL-DX-SSB": arrlDXRule,
+		"ARRL-SS-CW":  arrlSSRule,
+		"ARRL-SS-SSB": arrlSSRule,
+		"CQ-WW-CW":    cqWWRule,
+		"CQ-WW-RTTY":  cqWWRTTYRule,
+		"CQ-WW-SSB":   cqWWRule,
+		"NAQP-CW":     naqpRule,
+		"NAQP-RTTY":   naqpRule,
+		"NAQP-SSB":    naqpRule,
+	}
+)
+
+// cabrilloScore returns the score of l using s.  A contact with the same
+// DupeKey as an earlier contact is a duplicate and scores no points.
+func cabrilloScore(l *Logfile, s CabrilloScorer) int {
+	var points int
+	seen := make(map[string]bool)
+	for _, r := range l.Records {
+		k := s.DupeKey(r)
+		if k != "" && seen[k] {
+			continue
+		}
+		seen[k] = true
+		points += s.ScoreQSO(r)
+	}
+	return points * s.Multipliers(l)
+}
+
+// ValidateCabrilloExchange returns an error if the STX_STRING or SRX_STRING
+// fields in r do not match the CabrilloContestRules entry for contest.
+// Returns nil for contests without a rule and for empty exchange fields.
+func ValidateCabrilloExchange(contest string, r *Record) error {
+	rule, ok := CabrilloContestRules[strings.ToUpper(contest)]
+	if !ok {
+		return nil
+	}
+	for _, n := range []string{"STX_STRING", "SRX_STRING"} {
+		f, _ := r.Get(n)
+		v := strings.ToUpper(strings.Join(strings.Fields(f.Value), " "))
+		if v != "" && !rule.Pattern.MatchString(v) {
+			return fmt.Errorf("%s %q does not match %s exchange: %s", n, f.Value, strings.ToUpper(contest), rule.Exchange)
+		}
+	}
+	return nil
+}
+
+// CabrilloTemplate is a predefined exchange configuration for a popular
+// contest.  Exchange values which are the same for every contact, like your
+// CQ zone, come from MY_ fields or STX_STRING; values from the other station
+// come from specific ADIF fields or SRX_STRING.  An empty MyExchange means the
+// exchange has no ADIF fields and must be configured by the user.
+type CabrilloTemplate struct {
+	Name, Description         string
+	MyExchange, TheirExchange CabrilloFieldList
+}
+
+// CabrilloTemplates maps template names to exchange configurations.
+var CabrilloTemplates = map[string]*CabrilloTemplate{
+	"ARRL-DX": {Name: "ARRL-DX", Description: "ARRL International DX Contest: RST and state/province or power",
+		MyExchange:    mustCabrilloFields("rst:RST_SENT exch:STX_STRING/MY_STATE"),
+		TheirExchange: mustCabrilloFields("rst:RST_RCVD exch:SRX_STRING/STATE")},
+	"ARRL-SS": {Name: "ARRL-SS", Description: "ARRL Sweepstakes: serial, precedence, check, and section",
+		TheirExchange: mustCabrilloFields("nr:SRX p:PRECEDENCE ck:CHECK sec:ARRL_SECT")},
+	"CQ-WPX": {Name: "CQ-WPX", Description: "CQ WPX Contest: RST and serial number",
+		MyExchange:    mustCabrilloFields("rst:RST_SENT nr:STX"),
+		TheirExchange: mustCabrilloFields("rst:RST_RCVD nr:SRX")},
+	"CQ-WW-DX": {Name: "CQ-WW-DX", Description: "CQ World Wide DX Contest: RST and CQ zone",
+		MyExchange:    mustCabrilloFields("rst:RST_SENT zone:STX_STRING/MY_CQ_ZONE"),
+		TheirExchange: mustCabrilloFields("rst:RST_RCVD zone:SRX_STRING/CQZ")},
+	"NAQP": {Name: "NAQP", Description: "North American QSO Party: name and location",
+		MyExchange:    mustCabrilloFields("name:MY_NAME exch:STX_STRING/MY_STATE"),
+		TheirExchange: mustCabrilloFields("name:NAME exch:SRX_STRING/STATE")},
+	"STATE-QSO-PARTY": {Name: "STATE-QSO-PARTY", Description: "State QSO parties: RST and county, state, or province",
+		MyExchange:    mustCabrilloFields("rst:RST_SENT exch:STX_STRING/MY_STATE"),
+		TheirExchange: mustCabrilloFields("rst:RST_RCVD exch:SRX_STRING/STATE")},
+}
+
+func mustCabrilloFields(v string) CabrilloFieldList {
+	var l CabrilloFieldList
+	if err := l.Set(v); err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func (o *CabrilloIO) applyTemplate() error {
+	t := o.Template
+	if t == nil {
+		return nil
+	}
+	if len(o.MyExchange) == 0 {
+		if len(t.MyExchange) == 0 {
+			return fmt.Errorf("Cabrillo template %s needs my exchange fields to be configured (%s)", t.Name, t.Description)
+		}
+		o.MyExchange = append(o.MyExchange, t.MyExchange...)
+	}
+	if len(o.TheirExchange) == 0 {
+		o.TheirExchange = append(o.TheirExchange, t.TheirExchange...)
+	}
+	return nil
+}
+
 func (o *CabrilloIO) getCategories(l *Logfile) map[string]string {
 	cats := make(map[string]string)
 	for k, v := range o.Categories {
@@ -394,6 +599,28 @@ func (o *CabrilloIO) toConfig() cabrilloConfig {
 	return c
 }
 
+// SupportedFields returns the ADIF fields used for QSO lines with the current
+// exchange configuration and fields used to infer header values.
+func (o *CabrilloIO) SupportedFields() []string {
+	o.applyTemplate() // error reported by Read or Write
+	seen := map[string]bool{"APP_CABRILLO_XQSO": true}
+	res := []string{"APP_CABRILLO_XQSO"}
+	add := func(names ...string) {
+		for _, n := range names {
+			n = strings.ToUpper(n)
+			if !seen[n] {
+				seen[n] = true
+				res = append(res, n)
+			}
+		}
+	}
+	for _, f := range o.toConfig().fields {
+		add(f.TryFields...)
+	}
+	add("CONTEST_ID", "OPERATOR", "MY_NAME", "MY_GRIDSQUARE", "MY_ARRL_SECT", "TX_PWR")
+	return res
+}
+
 func mhzToKhz(mhz string) string {
 	pieces := strings.Split(mhz, ".")
 	if len(pieces) == 1 {
@@ -18,9 +18,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/exp/slices"
 )
 
 func TestEmptyCabrillo(t *testing.T) {
@@ -258,14 +260,16 @@ func TestWriteCabrillo(t *testing.T) {
 		Field{Name: "ARRL_SECT", Value: "NC", Type: TypeString},
 		Field{Name: "APP_CABRILLO_TRANSMITTER_ID", Value: "0", Type: TypeNumber},
 	))
-	l.Header.Set(Field{Name: "PROGRAMID", Value: "My Logger"})
-	l.Header.Set(Field{Name: "PROGRAMVERSION", Value: "1.2.3"})
-	l.Header.Set(Field{Name: "APP_CABRILLO_CLAIMED_SCORE", Value: "42"})
-	l.Header.Set(Field{Name: "APP_CABRILLO_CLUB", Value: "Amateur Radio Relay League"})
-	l.Header.Set(Field{Name: "APP_CABRILLO_ADDRESS", Value: "225 Main Street\nNewington, CT 06111"})
-	l.Header.Set(Field{Name: "APP_CABRILLO_CALLSIGN", Value: "N9N"})
-	l.Header.Set(Field{Name: "APP_CABRILLO_CATEGORY_BAND", Value: "14000"})
-	l.Header.Set(Field{Name: "APP_CABRILLO_CATEGORY_OVERLAY", Value: "CLASSIC"})
+	l.Header = NewRecord(
+		Field{Name: "PROGRAMID", Value: "My Logger"},
+		Field{Name: "PROGRAMVERSION", Value: "1.2.3"},
+		Field{Name: "APP_CABRILLO_CLAIMED_SCORE", Value: "42"},
+		Field{Name: "APP_CABRILLO_CLUB", Value: "Amateur Radio Relay League"},
+		Field{Name: "APP_CABRILLO_ADDRESS", Value: "225 Main Street\nNewington, CT 06111"},
+		Field{Name: "APP_CABRILLO_CALLSIGN", Value: "N9N"},
+		Field{Name: "APP_CABRILLO_CATEGORY_BAND", Value: "14000"},
+		Field{Name: "APP_CABRILLO_CATEGORY_OVERLAY", Value: "CLASSIC"},
+	)
 	want := `START-OF-LOG: 3.0
 X-INSTRUCTIONS: Fill out headers following contest instructions
 X-INSTRUCTIONS: Delete any unnecessary headers
@@ -336,6 +340,335 @@ END-OF-LOG:
 	}
 }
 
+type bandScorer struct{}
+
+func (bandScorer) ScoreQSO(r *Record) int { return 2 }
+
+func (bandScorer) DupeKey(r *Record) string {
+	call, _ := r.Get("CALL")
+	band, _ := r.Get("BAND")
+	return strings.ToUpper(call.Value + " " + band.Value)
+}
+
+func (bandScorer) Multipliers(l *Logfile) int { return len(fieldValues(l, "BAND")) }
+
+func TestCabrilloClaimedScore(t *testing.T) {
+	l := NewLogfile()
+	for _, c := range [][2]string{{"K1A", "20m"}, {"K2B", "20m"}, {"k1a", "20M"}, {"K1A", "40m"}} {
+		l.AddRecord(NewRecord(
+			Field{Name: "QSO_DATE", Value: "20231031"}, Field{Name: "TIME_ON", Value: "1234"},
+			Field{Name: "BAND", Value: c[1]}, Field{Name: "MODE", Value: "CW"},
+			Field{Name: "STATION_CALLSIGN", Value: "W1AW"}, Field{Name: "CALL", Value: c[0]}))
+	}
+	tests := []struct {
+		name         string
+		contest      string
+		claimedScore int
+		want         string
+	}{
+		{name: "computed", contest: "BANDS", want: "CLAIMED-SCORE: 18\n"}, // 3 non-dupes * 2 points * 3 band values
+		{name: "flag", contest: "BANDS", claimedScore: 7, want: "CLAIMED-SCORE: 7\n"},
+		{name: "unknown contest", contest: "OTHER", want: "CLAIMED-SCORE: 0\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cab := NewCabrilloIO()
+			cab.Contest = tc.contest
+			cab.ClaimedScore = tc.claimedScore
+			cab.ScorerFor = func(contest string) (CabrilloScorer, bool) {
+				if contest == "BANDS" {
+					return bandScorer{}, true
+				}
+				return nil, false
+			}
+			out := &strings.Builder{}
+			if err := cab.Write(l, out); err != nil {
+				t.Fatalf("Write(%v) got error %v", l, err)
+			}
+			if !strings.Contains(out.String(), tc.want) {
+				t.Errorf("Write(%v) want %q, got\n%s", l, tc.want, out)
+			}
+		})
+	}
+}
+
+func TestCabrilloSoapbox(t *testing.T) {
+	l := NewLogfile()
+	l.AddRecord(NewRecord(
+		Field{Name: "QSO_DATE", Value: "20231031"}, Field{Name: "TIME_ON", Value: "1234"},
+		Field{Name: "BAND", Value: "20m"}, Field{Name: "MODE", Value: "CW"},
+		Field{Name: "STATION_CALLSIGN", Value: "W1AW"}, Field{Name: "CALL", Value: "K1A"}))
+	cab := NewCabrilloIO()
+	cab.Soapbox = "Great conditions\nNew antenna worked well"
+	out := &strings.Builder{}
+	if err := cab.Write(l, out); err != nil {
+		t.Fatalf("Write(%v) got error %v", l, err)
+	}
+	want := "SOAPBOX: Great conditions\nSOAPBOX: New antenna worked well\n"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("Write(%v) want %q, got\n%s", l, want, out)
+	}
+	got, err := cab.Read(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatalf("Read(%q) got error %v", out, err)
+	}
+	wantField := Field{Name: "APP_CABRILLO_SOAPBOX", Value: cab.Soapbox, Type: TypeMultilineString}
+	if f, _ := got.Header.Get("APP_CABRILLO_SOAPBOX"); f != wantField {
+		t.Errorf("Read(%q) got header %v, want %v", out, f, wantField)
+	}
+}
+
+func TestCabrilloSupportedFields(t *testing.T) {
+	cab := NewCabrilloIO()
+	if err := cab.TheirExchange.Set("exch:srx_string/state"); err != nil {
+		t.Fatal(err)
+	}
+	got := cab.SupportedFields()
+	for _, want := range []string{"FREQ", "BAND", "MODE", "QSO_DATE", "TIME_ON", "STATION_CALLSIGN", "CALL", "SRX_STRING", "STATE", "CONTEST_ID"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("SupportedFields() = %v, missing %s", got, want)
+		}
+	}
+	for _, notWant := range []string{"NAME", "COMMENT", "SRX"} {
+		if slices.Contains(got, notWant) {
+			t.Errorf("SupportedFields() = %v, should not include %s", got, notWant)
+		}
+	}
+}
+
+func TestCabrilloRoundTrip(t *testing.T) {
+	tests := []struct {
+		name             string
+		contest          string
+		my, their, extra string
+		tabs             bool
+		qsos             string
+		want             []map[string]string
+	}{
+		{
+			name:    "CQ WW multi-op with X-QSO",
+			contest: "CQ-WW-CW",
+			my:      "rst:rst_sent=599 exch:stx_string",
+			their:   "rst:rst_rcvd exch:srx_string",
+			extra:   "t:app_cabrillo_transmitter_id=0",
+			qsos: `QSO:  3525 CW 2023-11-25 0001 K1ABC 599 5 DL1XYZ 599 14 0
+QSO: 14025 CW 2023-11-25 0002 K1ABC 599 5 JA1ZZZ 599 25 1
+X-QSO: 28010 CW 2023-11-25 0003 K1ABC 599 5 VK2AAA 599 30 1
+`,
+			want: []map[string]string{
+				{"FREQ": "3.525", "BAND": "80m", "MODE": "CW", "QSO_DATE": "20231125", "TIME_ON": "0001", "STATION_CALLSIGN": "K1ABC", "RST_SENT": "599", "STX_STRING": "5", "CALL": "DL1XYZ", "RST_RCVD": "599", "SRX_STRING": "14", "APP_CABRILLO_TRANSMITTER_ID": "0", "CONTEST_ID": "CQ-WW-CW"},
+				{"FREQ": "14.025", "BAND": "20m", "CALL": "JA1ZZZ", "SRX_STRING": "25", "APP_CABRILLO_TRANSMITTER_ID": "1"},
+				{"FREQ": "28.01", "BAND": "10m", "CALL": "VK2AAA", "SRX_STRING": "30", "APP_CABRILLO_XQSO": "Y"},
+			},
+		},
+		{
+			name:    "ARRL DX phone",
+			contest: "ARRL-DX-SSB",
+			my:      "rst:rst_sent=59 exch:stx_string",
+			their:   "rst:rst_rcvd exch:srx_string",
+			qsos: `QSO: 21250 PH 2024-03-02 1402 W1AW 59 CT JA1ABC 59 100
+QSO:  7150 PH 2024-03-03 0517 W1AW 59 CT EA8XX 57 KW
+`,
+			want: []map[string]string{
+				{"FREQ": "21.25", "BAND": "15m", "MODE": "SSB", "QSO_DATE": "20240302", "TIME_ON": "1402", "STX_STRING": "CT", "CALL": "JA1ABC", "SRX_STRING": "100"},
+				{"FREQ": "7.15", "BAND": "40m", "RST_RCVD": "57", "CALL": "EA8XX", "SRX_STRING": "KW"},
+			},
+		},
+		{
+			name:    "NAQP name and location",
+			contest: "NAQP-CW",
+			my:      "name:=HIRAM loc:my_state",
+			their:   "name:name loc:state",
+			qsos: `QSO:  7030 CW 2024-01-06 1800 W1AW HIRAM CT N0AX WARD WA
+QSO: 14041.5 CW 2024-01-06 1812 W1AW HIRAM CT VE3XYZ BOB ON
+`,
+			want: []map[string]string{
+				{"FREQ": "7.03", "BAND": "40m", "MY_STATE": "CT", "NAME": "WARD", "STATE": "WA", "CALL": "N0AX"},
+				{"FREQ": "14.0415", "BAND": "20m", "NAME": "BOB", "STATE": "ON", "CALL": "VE3XYZ"},
+			},
+		},
+		{
+			name:    "Field Day tab-delimited band names",
+			contest: "ARRL-FD",
+			my:      "class:=2A sect:my_arrl_sect",
+			their:   "class:class sect:arrl_sect",
+			tabs:    true,
+			qsos: "QSO:\t50\tPH\t2024-06-22\t1900\tW1AW\t2A\tCT\tK0XYZ\t1D\tCO\n" +
+				"QSO:\t144\tFM\t2024-06-22\t1915\tW1AW\t2A\tCT\tN1ABC\t3A\tWMA\n" +
+				"QSO:\t7040\tDG\t2024-06-23\t0230\tW1AW\t2A\tCT\tKL7AA\t1B\tAK\n",
+			want: []map[string]string{
+				{"BAND": "6m", "MODE": "SSB", "MY_ARRL_SECT": "CT", "CALL": "K0XYZ", "CLASS": "1D", "ARRL_SECT": "CO"},
+				{"BAND": "2m", "MODE": "FM", "CALL": "N1ABC", "CLASS": "3A", "ARRL_SECT": "WMA"},
+				{"FREQ": "7.04", "BAND": "40m", "MODE": "DIGITAL", "CALL": "KL7AA", "CLASS": "1B", "ARRL_SECT": "AK"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cab := NewCabrilloIO()
+			cab.Contest = tc.contest
+			cab.TabDelimiter = tc.tabs
+			for _, x := range []struct {
+				l *CabrilloFieldList
+				s string
+			}{{&cab.MyExchange, tc.my}, {&cab.TheirExchange, tc.their}, {&cab.ExtraFields, tc.extra}} {
+				if err := x.l.Set(x.s); err != nil {
+					t.Fatalf("invalid Cabrillo fields %q: %v", x.s, err)
+				}
+			}
+			input := fmt.Sprintf("START-OF-LOG: 3.0\nCONTEST: %s\nCALLSIGN: W1AW\n%sEND-OF-LOG:\n", tc.contest, tc.qsos)
+			parsed, err := cab.Read(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("Read(%q) got error %v", input, err)
+			}
+			if len(parsed.Records) != len(tc.want) {
+				t.Fatalf("Read(%q) got %d records, want %d", input, len(parsed.Records), len(tc.want))
+			}
+			for i, want := range tc.want {
+				for k, v := range want {
+					if f, _ := parsed.Records[i].Get(k); f.Value != v {
+						t.Errorf("Read(%q) record %d got %s=%q, want %q", input, i+1, k, f.Value, v)
+					}
+				}
+			}
+			out := &strings.Builder{}
+			if err := cab.Write(parsed, out); err != nil {
+				t.Fatalf("Write(%v) got error %v", parsed, err)
+			}
+			if tc.tabs && !strings.Contains(out.String(), "\nQSO: 50\tPH\t") {
+				t.Errorf("Write(%v) with TabDelimiter did not use tabs:\n%s", parsed, out)
+			}
+			reparsed, err := cab.Read(strings.NewReader(out.String()))
+			if err != nil {
+				t.Fatalf("Read(%q) of written log got error %v", out, err)
+			}
+			if len(reparsed.Records) != len(parsed.Records) {
+				t.Fatalf("round trip got %d records, want %d:\n%s", len(reparsed.Records), len(parsed.Records), out)
+			}
+			for i, r := range parsed.Records {
+				if diff := cmp.Diff(r.Fields(), reparsed.Records[i].Fields()); diff != "" {
+					t.Errorf("round trip record %d did not match, diff:\n%s\nwritten as:\n%s", i+1, diff, out)
+				}
+			}
+		})
+	}
+}
+
+func TestCabrilloOfftimes(t *testing.T) {
+	l := NewLogfile()
+	for _, dt := range [][2]string{
+		{"20231101", "0130"},
+		{"20231031", "2345"},
+		{"20231101", "0010"},
+		{"20231101", "0600"},
+		{"20231101", "0559"}, // out of order
+		{"20231102", "1200"},
+		{"", "1300"},         // ignored
+		{"20231101", "tbd"},  // ignored
+		{"20231103", "1200"}, // last QSO
+	} {
+		l.AddRecord(NewRecord(Field{Name: "QSO_DATE", Value: dt[0]}, Field{Name: "TIME_ON", Value: dt[1]}))
+	}
+	tests := []struct {
+		min  time.Duration
+		want string
+	}{
+		{min: 0, want: ""},
+		{min: -time.Hour, want: ""},
+		{min: 48 * time.Hour, want: ""},
+		{min: 30 * time.Hour, want: "2023-11-01 0600 2023-11-02 1200"},
+		{min: 24 * time.Hour, want: "2023-11-01 0600 2023-11-02 1200\n2023-11-02 1200 2023-11-03 1200"},
+		{min: 2 * time.Hour, want: "2023-11-01 0130 2023-11-01 0559\n2023-11-01 0600 2023-11-02 1200\n2023-11-02 1200 2023-11-03 1200"},
+		{min: 25 * time.Minute, want: "2023-10-31 2345 2023-11-01 0010\n2023-11-01 0010 2023-11-01 0130\n2023-11-01 0130 2023-11-01 0559\n2023-11-01 0600 2023-11-02 1200\n2023-11-02 1200 2023-11-03 1200"},
+	}
+	for _, tc := range tests {
+		if got := cabrilloOfftimes(l, tc.min); got != tc.want {
+			t.Errorf("cabrilloOfftimes(%v) got %q, want %q", tc.min, got, tc.want)
+		}
+	}
+}
+
+func TestValidateCabrilloExchange(t *testing.T) {
+	tests := []struct {
+		contest, stx, srx string
+		wantErr           bool
+	}{
+		{contest: "CQ-WW-CW", stx: "5", srx: "14"},
+		{contest: "cq-ww-ssb", stx: "05", srx: "40"},
+		{contest: "CQ-WW-CW", stx: "5", srx: "41", wantErr: true},
+		{contest: "CQ-WW-CW", stx: "CO", srx: "14", wantErr: true},
+		{contest: "CQ-WW-RTTY", stx: "4 co", srx: "14"},
+		{contest: "ARRL-DX-CW", stx: "CO", srx: "100"},
+		{contest: "ARRL-DX-SSB", stx: "on", srx: "KW"},
+		{contest: "ARRL-DX-CW", stx: "CO", srx: "599 100", wantErr: true},
+		{contest: "ARRL-SS-CW", stx: "123 A 73 CO", srx: "B 99 ENY"},
+		{contest: "ARRL-SS-SSB", stx: "123 A 73 CO", srx: "X 99 ENY", wantErr: true},
+		{contest: "NAQP-CW", stx: "BOB CO", srx: "Alice  ON"},
+		{contest: "NAQP-CW", stx: "BOB", srx: "ALICE ON", wantErr: true},
+		{contest: "CQ-WW-CW", stx: "", srx: ""},
+		{contest: "TEST-CONTEST-ID", stx: "anything", srx: "goes"},
+	}
+	for _, tc := range tests {
+		r := NewRecord(Field{Name: "STX_STRING", Value: tc.stx}, Field{Name: "SRX_STRING", Value: tc.srx})
+		err := ValidateCabrilloExchange(tc.contest, r)
+		if tc.wantErr && err == nil {
+			t.Errorf("ValidateCabrilloExchange(%q, %v) want error, got nil", tc.contest, r)
+		} else if !tc.wantErr && err != nil {
+			t.Errorf("ValidateCabrilloExchange(%q, %v) got error %v", tc.contest, r, err)
+		}
+	}
+	cab := NewCabrilloIO()
+	cab.Contest = "CQ-WW-CW"
+	l := NewLogfile()
+	l.AddRecord(NewRecord(
+		Field{Name: "QSO_DATE", Value: "20231031"}, Field{Name: "TIME_ON", Value: "1234"},
+		Field{Name: "BAND", Value: "20m"}, Field{Name: "MODE", Value: "CW"},
+		Field{Name: "STATION_CALLSIGN", Value: "W1AW"}, Field{Name: "CALL", Value: "K1A"},
+		Field{Name: "SRX_STRING", Value: "99"}))
+	if err := cab.Write(l, &strings.Builder{}); err != nil {
+		t.Errorf("Write with invalid %s exchange without CheckExchange got error %v", cab.Contest, err)
+	}
+	cab.CheckExchange = true
+	if err := cab.Write(l, &strings.Builder{}); err == nil {
+		t.Errorf("Write with invalid %s exchange want error, got nil", cab.Contest)
+	}
+}
+
+func TestCabrilloTemplate(t *testing.T) {
+	cab := NewCabrilloIO()
+	cab.Template = CabrilloTemplates["CQ-WW-DX"]
+	if err := cab.TheirExchange.Set("rst:RST_RCVD zone:CQZ"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cab.applyTemplate(); err != nil {
+		t.Fatalf("applyTemplate() got error %v", err)
+	}
+	if got, want := cab.MyExchange.String(), "rst:RST_SENT zone:STX_STRING/MY_CQ_ZONE"; got != want {
+		t.Errorf("template MyExchange got %q, want %q", got, want)
+	}
+	if got, want := cab.TheirExchange.String(), "rst:RST_RCVD zone:CQZ"; got != want {
+		t.Errorf("overridden TheirExchange got %q, want %q", got, want)
+	}
+	if err := cab.applyTemplate(); err != nil || len(cab.MyExchange) != 2 {
+		t.Errorf("applying template twice got error %v and MyExchange %v", err, cab.MyExchange)
+	}
+
+	ss := NewCabrilloIO()
+	ss.Template = CabrilloTemplates["ARRL-SS"]
+	if err := ss.Write(NewLogfile(), &strings.Builder{}); err == nil {
+		t.Errorf("Write with %s template and no my exchange want error, got nil", ss.Template.Name)
+	}
+	if err := ss.MyExchange.Set("nr:STX p:=A ck:=99 sec:MY_ARRL_SECT"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.applyTemplate(); err != nil {
+		t.Errorf("applyTemplate() with my exchange got error %v", err)
+	}
+	if got, want := ss.TheirExchange.String(), "nr:SRX p:PRECEDENCE ck:CHECK sec:ARRL_SECT"; got != want {
+		t.Errorf("template TheirExchange got %q, want %q", got, want)
+	}
+}
+
 func TestInferrCabrilloCategories(t *testing.T) {
 	tests := []struct {
 		name                          string
@@ -12,9 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+//go:generate go run github.com/abice/go-enum -f=$GOFILE --nocase --flag --names
 package adif
 
 import (
+	"bufio"
 	"encoding/csv"
 	"errors"
 	"fmt"
@@ -22,6 +24,13 @@ import (
 	"strings"
 )
 
+// CSVQuote controls when CSV output values are surrounded by double quotes.
+// Minimal quotes values with a comma, quote, line break, or leading space;
+// always quotes every value, and never quotes no values, returning an error
+// if a value can't be represented without quotes.
+// ENUM(minimal, always, never)
+type CSVQuote int
+
 type CSVIO struct {
 	Comma             rune
 	Comment           rune
@@ -30,15 +39,21 @@ type CSVIO struct {
 	RequireFullRecord bool
 	TrimLeadingSpace  bool
 	OmitHeader        bool
+	Quote             CSVQuote
+	// FieldMap renames input columns to ADIF field names, e.g. Date to QSO_DATE.
+	// Keys are upper case.
+	FieldMap map[string]string
 }
 
 func NewCSVIO() *CSVIO {
-	return &CSVIO{Comma: ','}
+	return &CSVIO{Comma: ',', FieldMap: make(map[string]string)}
 }
 
 func (o *CSVIO) String() string { return "csv" }
 
-func (o *CSVIO) Read(in io.Reader) (*Logfile, error) {
+func (o *CSVIO) Read(in io.Reader) (*Logfile, error) { return readAll(o, in) }
+
+func (o *CSVIO) ReadRecords(in io.Reader, fn func(*Logfile, *Record) bool) (*Logfile, error) {
 	l := NewLogfile()
 	c := csv.NewReader(in)
 	c.ReuseRecord = true
@@ -63,7 +78,10 @@ func (o *CSVIO) Read(in io.Reader) (*Logfile, error) {
 	copy(h, header)
 	l.FieldOrder = make([]string, len(h))
 	for i, n := range h {
-		l.FieldOrder[i] = strings.ToUpper(n)
+		if m, ok := o.FieldMap[strings.ToUpper(strings.TrimSpace(n))]; ok {
+			h[i] = m
+		}
+		l.FieldOrder[i] = strings.ToUpper(h[i])
 	}
 	// TODO if there are any USERDEF fields, add them to l.Header
 	for line, err := c.Read(); err != io.EOF; line, err = c.Read() {
@@ -86,7 +104,9 @@ func (o *CSVIO) Read(in io.Reader) (*Logfile, error) {
 				return nil, fmt.Errorf("could not set field %s to empty: %w", h[i], err)
 			}
 		}
-		l.AddRecord(r)
+		if !fn(l, r) {
+			return l, nil
+		}
 	}
 	return l, nil
 }
@@ -111,9 +131,15 @@ func (o *CSVIO) Write(l *Logfile, out io.Writer) error {
 	if len(order) == 0 {
 		return nil
 	}
-	c := csv.NewWriter(out)
-	c.Comma = o.Comma
-	c.UseCRLF = o.CRLF
+	var c csvRowWriter
+	if o.Quote == CSVQuoteMinimal {
+		w := csv.NewWriter(out)
+		w.Comma = o.Comma
+		w.UseCRLF = o.CRLF
+		c = w
+	} else {
+		c = &quotingCSVWriter{w: bufio.NewWriter(out), comma: o.Comma, crlf: o.CRLF, always: o.Quote == CSVQuoteAlways}
+	}
 	// CSV header row
 	if !o.OmitHeader {
 		if err := c.Write(order); err != nil {
@@ -136,3 +162,55 @@ func (o *CSVIO) Write(l *Logfile, out io.Writer) error {
 	c.Flush()
 	return c.Error()
 }
+
+type csvRowWriter interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+// quotingCSVWriter writes CSV with every value quoted or no values quoted,
+// which encoding/csv does not support.
+type quotingCSVWriter struct {
+	w      *bufio.Writer
+	comma  rune
+	crlf   bool
+	always bool
+	err    error
+}
+
+func (c *quotingCSVWriter) Write(record []string) error {
+	if c.err != nil {
+		return c.err
+	}
+	for i, v := range record {
+		if i > 0 {
+			c.w.WriteRune(c.comma)
+		}
+		if c.always {
+			c.w.WriteByte('"')
+			c.w.WriteString(strings.ReplaceAll(v, `"`, `""`))
+			c.w.WriteByte('"')
+		} else {
+			if strings.ContainsRune(v, c.comma) || strings.ContainsAny(v, "\"\r\n") {
+				c.err = fmt.Errorf("value %q needs quotes in CSV", v)
+				return c.err
+			}
+			c.w.WriteString(v)
+		}
+	}
+	if c.crlf {
+		_, c.err = c.w.WriteString("\r\n")
+	} else {
+		c.err = c.w.WriteByte('\n')
+	}
+	return c.err
+}
+
+func (c *quotingCSVWriter) Flush() {
+	if err := c.w.Flush(); c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *quotingCSVWriter) Error() error { return c.err }
@@ -166,6 +166,47 @@ Switzerland",",comma notes,,,"
 	}
 }
 
+func TestCSVQuoteStyle(t *testing.T) {
+	l := NewLogfile()
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "W1AW"}, Field{Name: "NAME", Value: `Hiram "HP" Maxim`}))
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "K1A"}, Field{Name: "NAME", Value: ""}))
+	tests := []struct {
+		quote CSVQuote
+		crlf  bool
+		want  string
+	}{
+		{quote: CSVQuoteMinimal, want: "CALL,NAME\nW1AW,\"Hiram \"\"HP\"\" Maxim\"\nK1A,\n"},
+		{quote: CSVQuoteAlways, want: "\"CALL\",\"NAME\"\n\"W1AW\",\"Hiram \"\"HP\"\" Maxim\"\n\"K1A\",\"\"\n"},
+		{quote: CSVQuoteAlways, crlf: true, want: "\"CALL\",\"NAME\"\r\n\"W1AW\",\"Hiram \"\"HP\"\" Maxim\"\r\n\"K1A\",\"\"\r\n"},
+	}
+	for _, tc := range tests {
+		csv := NewCSVIO()
+		csv.Quote = tc.quote
+		csv.CRLF = tc.crlf
+		out := &strings.Builder{}
+		if err := csv.Write(l, out); err != nil {
+			t.Errorf("Write with quote %s got error %v", tc.quote, err)
+		} else if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+			t.Errorf("Write with quote %s crlf %v got diff:\n%s", tc.quote, tc.crlf, diff)
+		}
+	}
+
+	csv := NewCSVIO()
+	csv.Quote = CSVQuoteNever
+	out := &strings.Builder{}
+	if err := csv.Write(l, out); err == nil {
+		t.Errorf("Write with quote never want error for value with quotes, got\n%s", out)
+	}
+	l.Records[0].Set(Field{Name: "NAME", Value: "Hiram Percy Maxim"})
+	out.Reset()
+	want := "CALL,NAME\nW1AW,Hiram Percy Maxim\nK1A,\n"
+	if err := csv.Write(l, out); err != nil {
+		t.Errorf("Write with quote never got error %v", err)
+	} else if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Write with quote never got diff:\n%s", diff)
+	}
+}
+
 func TestCSVOmitHeader(t *testing.T) {
 	l := NewLogfile()
 	l.Comment = "CSV ignores comments"
@@ -200,3 +241,34 @@ func TestCSVOmitHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestCSVFieldMap(t *testing.T) {
+	input := `Date,Time,Callsign,Band,NAME
+20240101,1234,K1A,20m,Al
+`
+	csv := NewCSVIO()
+	csv.FieldMap["DATE"] = "QSO_DATE"
+	csv.FieldMap["TIME"] = "TIME_ON"
+	csv.FieldMap["CALLSIGN"] = "CALL"
+	parsed, err := csv.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read(%q) got error %v", input, err)
+	}
+	wantOrder := []string{"QSO_DATE", "TIME_ON", "CALL", "BAND", "NAME"}
+	if diff := cmp.Diff(wantOrder, parsed.FieldOrder); diff != "" {
+		t.Errorf("Read(%q) with FieldMap %v unexpected field order, diff:\n%s", input, csv.FieldMap, diff)
+	}
+	want := []Field{
+		{Name: "QSO_DATE", Value: "20240101"},
+		{Name: "TIME_ON", Value: "1234"},
+		{Name: "CALL", Value: "K1A"},
+		{Name: "BAND", Value: "20m"},
+		{Name: "NAME", Value: "Al"},
+	}
+	if len(parsed.Records) != 1 {
+		t.Fatalf("Read(%q) got %d records, want 1", input, len(parsed.Records))
+	}
+	if diff := cmp.Diff(want, parsed.Records[0].Fields()); diff != "" {
+		t.Errorf("Read(%q) with FieldMap %v unexpected fields, diff:\n%s", input, csv.FieldMap, diff)
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adif
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+)
+
+// EDIIO configures conversion to and from the EDI (REG1TEST) format, which is
+// used to submit VHF, UHF, and microwave contest logs in IARU Region 1.  Most
+// fields configure the value of a header: Callsign is PCall, Contest is TName,
+// Locator is PWWLo, Exchange is PExch, Section is PSect, and Club is PClub.
+// If not set, header values are inferred from STATION_CALLSIGN,
+// MY_GRIDSQUARE, and STX_STRING if all records have the same value.  An EDI
+// file only covers one band; Write returns an error if records have more than
+// one BAND value.  See https://www.ok2kkw.com/ediformat.htm for details about
+// the format.
+type EDIIO struct {
+	Callsign, Contest, Locator, Exchange, Section, Club string
+}
+
+func NewEDIIO() *EDIIO {
+	return &EDIIO{}
+}
+
+func (_ *EDIIO) String() string { return "edi" }
+
+const ediFileStart = "[REG1TEST;1]"
+
+var (
+	ediModes = map[string]string{
+		"1": "SSB",
+		"2": "CW",
+		"3": "SSB", // SSB transmit, CW receive
+		"4": "CW",  // CW transmit, SSB receive
+		"5": "AM",
+		"6": "FM",
+		"7": "RTTY",
+		"8": "SSTV",
+		"9": "ATV",
+	}
+	ediModesRev = map[string]string{
+		"SSB":  "1",
+		"CW":   "2",
+		"AM":   "5",
+		"FM":   "6",
+		"RTTY": "7",
+		"SSTV": "8",
+		"ATV":  "9",
+	}
+	ediBands = map[string]string{
+		"10m":    "28 MHz",
+		"6m":     "50 MHz",
+		"4m":     "70 MHz",
+		"2m":     "144 MHz",
+		"1.25m":  "222 MHz",
+		"70cm":   "432 MHz",
+		"33cm":   "902 MHz",
+		"23cm":   "1,3 GHz",
+		"13cm":   "2,3 GHz",
+		"9cm":    "3,4 GHz",
+		"6cm":    "5,7 GHz",
+		"3cm":    "10 GHz",
+		"1.25cm": "24 GHz",
+		"6mm":    "47 GHz",
+		"4mm":    "76 GHz",
+		"2.5mm":  "122 GHz",
+		"2mm":    "134 GHz",
+		"1mm":    "241 GHz",
+	}
+	ediBandsRev = map[string]string{}
+)
+
+func init() {
+	for k, v := range ediBands {
+		ediBandsRev[ediBandKey(v)] = k
+	}
+}
+
+func ediBandKey(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), ",", "."))
+}
+
+// ediBand returns the ADIF band for a PBand header value like "144 MHz",
+// "1,3 GHz", or "1296 MHz".
+func ediBand(s string) string {
+	k := ediBandKey(s)
+	if b := ediBandsRev[k]; b != "" {
+		return b
+	}
+	mult := 1.0
+	v, ok := cutSuffix(k, "MHZ")
+	if !ok {
+		v, ok = cutSuffix(k, "GHZ")
+		mult = 1000.0
+	}
+	if ok {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			if b, ok := findBandFreq(f * mult); ok {
+				return b.adifName
+			}
+		}
+	}
+	return ""
+}
+
+func (o *EDIIO) Read(in io.Reader) (*Logfile, error) {
+	l := NewLogfile()
+	headers := make(map[string]string)
+	var remarks []string
+	section := ""
+	sawStart, sawEnd := false, false
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if sawEnd {
+			return nil, fmt.Errorf("got data after EDI [END;] line %q", line)
+		}
+		if !sawStart {
+			if !strings.EqualFold(strings.TrimSpace(line), ediFileStart) {
+				return nil, fmt.Errorf("EDI file does not start with %s: %q", ediFileStart, line)
+			}
+			sawStart = true
+			section = "REG1TEST"
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			name, _, _ := strings.Cut(strings.Trim(line, "[]"), ";")
+			section = strings.ToUpper(name)
+			if section == "END" {
+				sawEnd = true
+			}
+			continue
+		}
+		switch section {
+		case "REG1TEST":
+			k, v, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid EDI header line %q", line)
+			}
+			if v = strings.TrimSpace(v); v != "" {
+				headers[strings.TrimSpace(k)] = v
+			}
+		case "REMARKS":
+			remarks = append(remarks, line)
+		case "QSORECORDS":
+			r, err := o.toADIF(line, headers)
+			if err != nil {
+				return nil, err
+			}
+			l.AddRecord(r)
+		default:
+			// ignore unknown sections
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if !sawStart {
+		return nil, errors.New("empty EDI file")
+	}
+	if !sawEnd {
+		return nil, errors.New("got EOF before EDI [END;] line")
+	}
+	fromHeaders := make([]Field, 0)
+	if v := headers["PCall"]; v != "" {
+		fromHeaders = append(fromHeaders, Field{Name: "STATION_CALLSIGN", Value: v})
+	}
+	if v := headers["PWWLo"]; v != "" {
+		fromHeaders = append(fromHeaders, Field{Name: "MY_GRIDSQUARE", Value: v})
+	}
+	if v := headers["PExch"]; v != "" {
+		fromHeaders = append(fromHeaders, Field{Name: "STX_STRING", Value: v})
+	}
+	if v := headers["PBand"]; v != "" {
+		if b := ediBand(v); b != "" {
+			fromHeaders = append(fromHeaders, Field{Name: "BAND", Value: b, Type: TypeEnumeration})
+		}
+	}
+	for _, f := range fromHeaders {
+		for _, r := range l.Records {
+			if v, ok := r.Get(f.Name); !ok || v.Value == "" {
+				r.Set(f)
+			}
+		}
+	}
+	if len(remarks) > 0 {
+		headers["Remarks"] = strings.Join(remarks, "\n")
+	}
+	keys := maps.Keys(headers)
+	sort.Strings(keys)
+	for _, k := range keys {
+		l.Header.Set(Field{Name: "APP_EDI_" + strings.ToUpper(k), Value: headers[k]})
+	}
+	return l, nil
+}
+
+func (o *EDIIO) toADIF(line string, headers map[string]string) (*Record, error) {
+	vals := strings.Split(line, ";")
+	if len(vals) < 10 {
+		return nil, fmt.Errorf("EDI QSO record has %d fields, expected at least 10: %q", len(vals), line)
+	}
+	for i, v := range vals {
+		vals[i] = strings.TrimSpace(v)
+	}
+	for len(vals) < 15 {
+		vals = append(vals, "")
+	}
+	date := vals[0]
+	if len(date) == 6 {
+		century := "20"
+		if t := headers["TDate"]; len(t) >= 8 && isAllDigits(t[0:2]) {
+			century = t[0:2]
+		}
+		date = century + date
+	}
+	r := NewRecord()
+	set := func(name, val string, t DataType) {
+		if val != "" {
+			r.Set(Field{Name: name, Value: val, Type: t})
+		}
+	}
+	set("QSO_DATE", date, TypeDate)
+	set("TIME_ON", vals[1], TypeTime)
+	set("CALL", vals[2], TypeUnspecified)
+	set("MODE", ediModes[vals[3]], TypeEnumeration)
+	set("RST_SENT", vals[4], TypeUnspecified)
+	set("STX", strings.TrimLeft(vals[5], "0"), TypeNumber)
+	set("RST_RCVD", vals[6], TypeUnspecified)
+	set("SRX", strings.TrimLeft(vals[7], "0"), TypeNumber)
+	set("SRX_STRING", vals[8], TypeUnspecified)
+	set("GRIDSQUARE", vals[9], TypeUnspecified)
+	set("APP_EDI_QSO_POINTS", vals[10], TypeNumber)
+	if strings.EqualFold(vals[14], "D") {
+		set("APP_EDI_DUPLICATE", "Y", TypeBoolean)
+	}
+	return r, nil
+}
+
+func (o *EDIIO) Write(l *Logfile, out io.Writer) error {
+	bands := fieldValues(l, "BAND")
+	delete(bands, "")
+	if len(bands) > 1 {
+		names := maps.Keys(bands)
+		sort.Strings(names)
+		return fmt.Errorf("EDI files can only have one band, got %s", strings.Join(names, ", "))
+	}
+	headers := make(map[string]string)
+	for _, f := range l.Header.Fields() {
+		if h, ok := cutPrefix(f.Name, "APP_EDI_"); ok {
+			headers[strings.ToUpper(h)] = f.Value
+		}
+	}
+	setHeader := func(hname, val string) {
+		if val != "" {
+			headers[strings.ToUpper(hname)] = val
+		}
+	}
+	setSummaryField := func(hname, fname, priority string) {
+		val := priority
+		if val == "" {
+			if m := fieldValues(l, fname); len(m) == 1 {
+				val = maps.Keys(m)[0]
+			}
+		}
+		setHeader(hname, val)
+	}
+	dates := maps.Keys(fieldValues(l, "QSO_DATE"))
+	if len(dates) > 0 {
+		sort.Strings(dates)
+		setHeader("TDate", dates[0]+";"+dates[len(dates)-1])
+	}
+	setSummaryField("TName", "CONTEST_ID", o.Contest)
+	setSummaryField("PCall", "STATION_CALLSIGN", o.Callsign)
+	setSummaryField("PWWLo", "MY_GRIDSQUARE", o.Locator)
+	setSummaryField("PExch", "STX_STRING", o.Exchange)
+	setHeader("PSect", o.Section)
+	for b := range bands {
+		if v := ediBands[strings.ToLower(b)]; v != "" {
+			setHeader("PBand", v)
+		} else {
+			setHeader("PBand", b)
+		}
+	}
+	setHeader("PClub", o.Club)
+	setHeader("CQSOs", fmt.Sprintf("%d;1", len(l.Records)))
+	if g, ok := headers["PWWLO"]; ok && len(g) > 6 {
+		headers["PWWLO"] = g[0:6]
+	}
+	w := bufio.NewWriter(out)
+	lines := []string{ediFileStart}
+	headerOrder := []string{"TName", "TDate", "PCall", "PWWLo", "PExch", "PAdr1", "PAdr2", "PSect", "PBand", "PClub",
+		"RName", "RCall", "RAdr1", "RAdr2", "RPoCo", "RCity", "RCoun", "RPhon", "RHBBS", "MOpe1", "MOpe2",
+		"STXEq", "SPowe", "SRXEq", "SAnte", "SAntH", "CQSOs", "CQSOP", "CWWLs", "CWWLB", "CExcs", "CExcB",
+		"CDXCs", "CDXCB", "CToSc", "CODXC"}
+	for _, h := range headerOrder {
+		lines = append(lines, h+"="+headers[strings.ToUpper(h)])
+	}
+	lines = append(lines, "[Remarks]")
+	if v := headers["REMARKS"]; v != "" {
+		lines = append(lines, splitLines.Split(v, -1)...)
+	}
+	lines = append(lines, fmt.Sprintf("[QSORecords;%d]", len(l.Records)))
+	for _, r := range l.Records {
+		q, err := o.toLine(r)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, q)
+	}
+	lines = append(lines, "[END;]")
+	for _, line := range lines {
+		if _, err := w.WriteString(line); err != nil {
+			return err
+		}
+		if _, err := w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (o *EDIIO) toLine(r *Record) (string, error) {
+	get := func(name string) string {
+		f, _ := r.Get(name)
+		return strings.TrimSpace(f.Value)
+	}
+	date := get("QSO_DATE")
+	if len(date) != 8 || !isAllDigits(date) {
+		return "", fmt.Errorf("invalid QSO_DATE %q for EDI in record %s", date, r)
+	}
+	time := get("TIME_ON")
+	if len(time) < 4 || !isAllDigits(time) {
+		return "", fmt.Errorf("invalid TIME_ON %q for EDI in record %s", time, r)
+	}
+	mode := "0" // none of the below
+	if m := ediModesRev[strings.ToUpper(get("MODE"))]; m != "" {
+		mode = m
+	}
+	serial := func(name string) string {
+		s := get(name)
+		if isAllDigits(s) && len(s) > 0 && len(s) < 3 {
+			s = strings.Repeat("0", 3-len(s)) + s
+		}
+		return s
+	}
+	grid := get("GRIDSQUARE")
+	if len(grid) > 6 {
+		grid = grid[0:6]
+	}
+	dupe := ""
+	if strings.EqualFold(get("APP_EDI_DUPLICATE"), "Y") {
+		dupe = "D"
+	}
+	vals := []string{
+		date[2:8],
+		time[0:4],
+		get("CALL"),
+		mode,
+		get("RST_SENT"),
+		serial("STX"),
+		get("RST_RCVD"),
+		serial("SRX"),
+		get("SRX_STRING"),
+		grid,
+		get("APP_EDI_QSO_POINTS"),
+		"", "", "", // new exchange, new locator, new DXCC are computed by the contest robot
+		dupe,
+	}
+	return strings.Join(vals, ";"), nil
+}
+
+// SupportedFields returns the ADIF fields used for QSO lines and fields used to
+// infer header values.
+func (o *EDIIO) SupportedFields() []string {
+	return []string{"QSO_DATE", "TIME_ON", "CALL", "MODE", "RST_SENT", "STX", "RST_RCVD", "SRX", "SRX_STRING",
+		"GRIDSQUARE", "APP_EDI_QSO_POINTS", "APP_EDI_DUPLICATE",
+		"BAND", "CONTEST_ID", "STATION_CALLSIGN", "MY_GRIDSQUARE", "STX_STRING"}
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adif
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestReadEDI(t *testing.T) {
+	input := strings.ReplaceAll(`[REG1TEST;1]
+TName=IARU Region 1 VHF Contest
+TDate=20240907;20240908
+PCall=OK1ABC
+PWWLo=JN79FX
+PExch=
+PSect=SINGLE
+PBand=144 MHz
+[Remarks]
+Portable on a hilltop
+Strong wind
+[QSORecords;3]
+240907;1403;DL1XYZ;1;59;001;57;012;;JO60LJ;123;N;N;;
+240907;1410;OE3ABC;2;599;002;599;045;;JN88EF;140;;;;
+240908;0102;DL1XYZ;1;59;003;59;013;;JO60LJ;0;;;;D
+[END;]
+`, "\n", "\r\n")
+	edi := NewEDIIO()
+	l, err := edi.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read(%q) got error %v", input, err)
+	}
+	wantHeaders := []Field{
+		{Name: "APP_EDI_PBAND", Value: "144 MHz"},
+		{Name: "APP_EDI_PCALL", Value: "OK1ABC"},
+		{Name: "APP_EDI_PSECT", Value: "SINGLE"},
+		{Name: "APP_EDI_PWWLO", Value: "JN79FX"},
+		{Name: "APP_EDI_REMARKS", Value: "Portable on a hilltop\nStrong wind"},
+		{Name: "APP_EDI_TDATE", Value: "20240907;20240908"},
+		{Name: "APP_EDI_TNAME", Value: "IARU Region 1 VHF Contest"},
+	}
+	if diff := cmp.Diff(wantHeaders, l.Header.Fields()); diff != "" {
+		t.Errorf("Read(%q) header mismatch, diff:\n%s", input, diff)
+	}
+	common := []Field{
+		{Name: "STATION_CALLSIGN", Value: "OK1ABC"},
+		{Name: "MY_GRIDSQUARE", Value: "JN79FX"},
+		{Name: "BAND", Value: "2m", Type: TypeEnumeration},
+	}
+	wantFields := [][]Field{
+		append([]Field{
+			{Name: "QSO_DATE", Value: "20240907", Type: TypeDate},
+			{Name: "TIME_ON", Value: "1403", Type: TypeTime},
+			{Name: "CALL", Value: "DL1XYZ"},
+			{Name: "MODE", Value: "SSB", Type: TypeEnumeration},
+			{Name: "RST_SENT", Value: "59"},
+			{Name: "STX", Value: "1", Type: TypeNumber},
+			{Name: "RST_RCVD", Value: "57"},
+			{Name: "SRX", Value: "12", Type: TypeNumber},
+			{Name: "GRIDSQUARE", Value: "JO60LJ"},
+			{Name: "APP_EDI_QSO_POINTS", Value: "123", Type: TypeNumber},
+		}, common...),
+		append([]Field{
+			{Name: "QSO_DATE", Value: "20240907", Type: TypeDate},
+			{Name: "TIME_ON", Value: "1410", Type: TypeTime},
+			{Name: "CALL", Value: "OE3ABC"},
+			{Name: "MODE", Value: "CW", Type: TypeEnumeration},
+			{Name: "RST_SENT", Value: "599"},
+			{Name: "STX", Value: "2", Type: TypeNumber},
+			{Name: "RST_RCVD", Value: "599"},
+			{Name: "SRX", Value: "45", Type: TypeNumber},
+			{Name: "GRIDSQUARE", Value: "JN88EF"},
+			{Name: "APP_EDI_QSO_POINTS", Value: "140", Type: TypeNumber},
+		}, common...),
+		append([]Field{
+			{Name: "QSO_DATE", Value: "20240908", Type: TypeDate},
+			{Name: "TIME_ON", Value: "0102", Type: TypeTime},
+			{Name: "CALL", Value: "DL1XYZ"},
+			{Name: "MODE", Value: "SSB", Type: TypeEnumeration},
+			{Name: "RST_SENT", Value: "59"},
+			{Name: "STX", Value: "3", Type: TypeNumber},
+			{Name: "RST_RCVD", Value: "59"},
+			{Name: "SRX", Value: "13", Type: TypeNumber},
+			{Name: "GRIDSQUARE", Value: "JO60LJ"},
+			{Name: "APP_EDI_QSO_POINTS", Value: "0", Type: TypeNumber},
+			{Name: "APP_EDI_DUPLICATE", Value: "Y", Type: TypeBoolean},
+		}, common...),
+	}
+	if len(l.Records) != len(wantFields) {
+		t.Fatalf("Read(%q) got %d records, want %d", input, len(l.Records), len(wantFields))
+	}
+	for i, r := range l.Records {
+		if diff := cmp.Diff(wantFields[i], r.Fields()); diff != "" {
+			t.Errorf("Read(%q) record %d mismatch, diff:\n%s", input, i+1, diff)
+		}
+	}
+}
+
+func TestReadEDIErrors(t *testing.T) {
+	tests := []struct{ name, input string }{
+		{name: "empty", input: ""},
+		{name: "not EDI", input: "START-OF-LOG: 3.0\nEND-OF-LOG:\n"},
+		{name: "no end", input: "[REG1TEST;1]\nPCall=OK1ABC\n[QSORecords;0]\n"},
+		{name: "data after end", input: "[REG1TEST;1]\n[QSORecords;0]\n[END;]\nPCall=OK1ABC\n"},
+		{name: "bad header", input: "[REG1TEST;1]\nPCall OK1ABC\n[END;]\n"},
+		{name: "short QSO", input: "[REG1TEST;1]\n[QSORecords;1]\n240907;1403;DL1XYZ;1;59\n[END;]\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if l, err := NewEDIIO().Read(strings.NewReader(tc.input)); err == nil {
+				t.Errorf("Read(%q) want error, got %v", tc.input, l)
+			}
+		})
+	}
+}
+
+func TestWriteEDI(t *testing.T) {
+	l := NewLogfile()
+	l.Header = NewRecord(
+		Field{Name: "APP_EDI_RCALL", Value: "OK1XYZ"},
+		Field{Name: "APP_EDI_REMARKS", Value: "Line one\nLine two"},
+	)
+	l.AddRecord(NewRecord(
+		Field{Name: "QSO_DATE", Value: "20240907"},
+		Field{Name: "TIME_ON", Value: "140312"},
+		Field{Name: "CALL", Value: "DL1XYZ"},
+		Field{Name: "MODE", Value: "SSB"},
+		Field{Name: "BAND", Value: "70cm"},
+		Field{Name: "RST_SENT", Value: "59"},
+		Field{Name: "STX", Value: "1"},
+		Field{Name: "RST_RCVD", Value: "57"},
+		Field{Name: "SRX", Value: "1234"},
+		Field{Name: "GRIDSQUARE", Value: "JO60LJ12"},
+		Field{Name: "STATION_CALLSIGN", Value: "OK1ABC"},
+		Field{Name: "MY_GRIDSQUARE", Value: "JN79FX"},
+	))
+	l.AddRecord(NewRecord(
+		Field{Name: "QSO_DATE", Value: "20240908"},
+		Field{Name: "TIME_ON", Value: "0102"},
+		Field{Name: "CALL", Value: "OE3ABC"},
+		Field{Name: "MODE", Value: "FT8"},
+		Field{Name: "BAND", Value: "70cm"},
+		Field{Name: "STX", Value: "2"},
+		Field{Name: "SRX_STRING", Value: "OK"},
+		Field{Name: "STATION_CALLSIGN", Value: "OK1ABC"},
+		Field{Name: "MY_GRIDSQUARE", Value: "JN79FX"},
+		Field{Name: "APP_EDI_DUPLICATE", Value: "Y"},
+	))
+	edi := NewEDIIO()
+	edi.Contest = "Test Contest"
+	edi.Section = "SINGLE"
+	out := &strings.Builder{}
+	if err := edi.Write(l, out); err != nil {
+		t.Fatalf("Write(%v) got error %v", l, err)
+	}
+	want := strings.ReplaceAll(`[REG1TEST;1]
+TName=Test Contest
+TDate=20240907;20240908
+PCall=OK1ABC
+PWWLo=JN79FX
+PExch=
+PAdr1=
+PAdr2=
+PSect=SINGLE
+PBand=432 MHz
+PClub=
+RName=
+RCall=OK1XYZ
+RAdr1=
+RAdr2=
+RPoCo=
+RCity=
+RCoun=
+RPhon=
+RHBBS=
+MOpe1=
+MOpe2=
+STXEq=
+SPowe=
+SRXEq=
+SAnte=
+SAntH=
+CQSOs=2;1
+CQSOP=
+CWWLs=
+CWWLB=
+CExcs=
+CExcB=
+CDXCs=
+CDXCB=
+CToSc=
+CODXC=
+[Remarks]
+Line one
+Line two
+[QSORecords;2]
+240907;1403;DL1XYZ;1;59;001;57;1234;;JO60LJ;;;;;
+240908;0102;OE3ABC;0;;002;;;OK;;;;;;D
+[END;]
+`, "\n", "\r\n")
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Write(%v) unexpected output, diff:\n%s", l, diff)
+	}
+	// round trip
+	got, err := edi.Read(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatalf("Read(%q) got error %v", out, err)
+	}
+	if len(got.Records) != 2 {
+		t.Errorf("Read(%q) got %d records, want 2", out, len(got.Records))
+	}
+}
+
+func TestWriteEDIMultipleBands(t *testing.T) {
+	l := NewLogfile()
+	l.AddRecord(NewRecord(Field{Name: "QSO_DATE", Value: "20240907"}, Field{Name: "TIME_ON", Value: "1234"}, Field{Name: "BAND", Value: "2m"}))
+	l.AddRecord(NewRecord(Field{Name: "QSO_DATE", Value: "20240907"}, Field{Name: "TIME_ON", Value: "1235"}, Field{Name: "BAND", Value: "70cm"}))
+	if err := NewEDIIO().Write(l, &strings.Builder{}); err == nil {
+		t.Errorf("Write(%v) want error for multiple bands", l)
+	}
+}
+
+func TestEDISupportedFields(t *testing.T) {
+	got := NewEDIIO().SupportedFields()
+	for _, want := range []string{"QSO_DATE", "TIME_ON", "CALL", "MODE", "RST_SENT", "SRX", "GRIDSQUARE", "BAND", "STATION_CALLSIGN"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("SupportedFields() = %v, missing %s", got, want)
+		}
+	}
+	for _, notWant := range []string{"NAME", "COMMENT", "FREQ"} {
+		if slices.Contains(got, notWant) {
+			t.Errorf("SupportedFields() = %v, should not include %s", got, notWant)
+		}
+	}
+}
+
+func TestEDIBand(t *testing.T) {
+	tests := []struct{ pband, want string }{
+		{pband: "144 MHz", want: "2m"},
+		{pband: "145 MHz", want: "2m"},
+		{pband: "435 mhz", want: "70cm"},
+		{pband: "1,3 GHz", want: "23cm"},
+		{pband: "1.3 GHz", want: "23cm"},
+		{pband: "1296 MHz", want: "23cm"},
+		{pband: "10 GHz", want: "3cm"},
+		{pband: "10368 MHz", want: "3cm"},
+		{pband: "5 GHz", want: ""},
+		{pband: "two meters", want: ""},
+	}
+	for _, tc := range tests {
+		if got := ediBand(tc.pband); got != tc.want {
+			t.Errorf("ediBand(%q) got %q, want %q", tc.pband, got, tc.want)
+		}
+	}
+}
@@ -27,13 +27,17 @@ import (
 	"unicode"
 )
 
-// ENUM(ADI, ADX, Cabrillo, CSV, JSON, TSV)
+// ENUM(ADI, ADIZ, ADX, Cabrillo, CSV, EDI, JSON, Markdown, TSV)
 type Format string
 
 // GuessFormatFromName guesses a file's Format based on its extension.
+// A trailing .gz extension is ignored, so "log.adi.gz" is FormatADI.
 // If filename doesn't match any known format, Format("") and an error are
 // returned.
 func GuessFormatFromName(filename string) (Format, error) {
+	if strings.EqualFold(filepath.Ext(filename), ".gz") {
+		filename = filename[:len(filename)-len(".gz")]
+	}
 	ext := strings.TrimPrefix(filepath.Ext(filename), ".")
 	if ext == "" {
 		return Format(""), fmt.Errorf("no file extension in %q", filename)
@@ -43,6 +47,8 @@ func GuessFormatFromName(filename string) (Format, error) {
 		switch strings.ToLower(ext) {
 		case "cbr", "log":
 			f, err = FormatCabrillo, nil
+		case "md":
+			f, err = FormatMarkdown, nil
 		}
 	}
 	return f, err
@@ -70,12 +76,18 @@ func GuessFormatFromContent(r *bufio.Reader) (Format, error) {
 	if len(start) == 0 {
 		return Format(""), fmt.Errorf("could not determine data format, input is empty")
 	}
+	if bytes.HasPrefix(buf, zipMagic) {
+		return FormatADIZ, nil
+	}
 	if bytes.HasPrefix(start, []byte("<?xml")) || bytes.HasPrefix(start, []byte("<ADX>")) {
 		return FormatADX, nil
 	}
 	if bytes.HasPrefix(start, []byte("START-OF-LOG:")) {
 		return FormatCabrillo, nil
 	}
+	if bytes.HasPrefix(bytes.ToUpper(start), []byte(ediFileStart)) {
+		return FormatEDI, nil
+	}
 	if firstADITagPat.Find(start) != nil {
 		return FormatADI, nil
 	}
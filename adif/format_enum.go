@@ -14,14 +14,20 @@ import (
 const (
 	// FormatADI is a Format of type ADI.
 	FormatADI Format = "ADI"
+	// FormatADIZ is a Format of type ADIZ.
+	FormatADIZ Format = "ADIZ"
 	// FormatADX is a Format of type ADX.
 	FormatADX Format = "ADX"
 	// FormatCabrillo is a Format of type Cabrillo.
 	FormatCabrillo Format = "Cabrillo"
 	// FormatCSV is a Format of type CSV.
 	FormatCSV Format = "CSV"
+	// FormatEDI is a Format of type EDI.
+	FormatEDI Format = "EDI"
 	// FormatJSON is a Format of type JSON.
 	FormatJSON Format = "JSON"
+	// FormatMarkdown is a Format of type Markdown.
+	FormatMarkdown Format = "Markdown"
 	// FormatTSV is a Format of type TSV.
 	FormatTSV Format = "TSV"
 )
@@ -30,10 +36,13 @@ var ErrInvalidFormat = fmt.Errorf("not a valid Format, try [%s]", strings.Join(_
 
 var _FormatNames = []string{
 	string(FormatADI),
+	string(FormatADIZ),
 	string(FormatADX),
 	string(FormatCabrillo),
 	string(FormatCSV),
+	string(FormatEDI),
 	string(FormatJSON),
+	string(FormatMarkdown),
 	string(FormatTSV),
 }
 
@@ -58,14 +67,20 @@ func (x Format) IsValid() bool {
 var _FormatValue = map[string]Format{
 	"ADI":      FormatADI,
 	"adi":      FormatADI,
+	"ADIZ":     FormatADIZ,
+	"adiz":     FormatADIZ,
 	"ADX":      FormatADX,
 	"adx":      FormatADX,
 	"Cabrillo": FormatCabrillo,
 	"cabrillo": FormatCabrillo,
 	"CSV":      FormatCSV,
 	"csv":      FormatCSV,
+	"EDI":      FormatEDI,
+	"edi":      FormatEDI,
 	"JSON":     FormatJSON,
 	"json":     FormatJSON,
+	"Markdown": FormatMarkdown,
+	"markdown": FormatMarkdown,
 	"TSV":      FormatTSV,
 	"tsv":      FormatTSV,
 }
@@ -28,11 +28,16 @@ func TestGuessFormatFromName(t *testing.T) {
 		wantErr bool
 	}{
 		{name: "foo.adi", want: FormatADI},
+		{name: "foo.adiz", want: FormatADIZ},
 		{name: "foo.adx", want: FormatADX},
 		{name: "foo.csv", want: FormatCSV},
+		{name: "foo.edi", want: FormatEDI},
 		{name: "foo.json", want: FormatJSON},
+		{name: "foo.md", want: FormatMarkdown},
+		{name: "foo.markdown", want: FormatMarkdown},
 		{name: "foo.tsv", want: FormatTSV},
 		{name: "bar.ADI", want: FormatADI},
+		{name: "bar.ADIZ", want: FormatADIZ},
 		{name: "bar.ADX", want: FormatADX},
 		{name: "bar.CSV", want: FormatCSV},
 		{name: "bar.JSON", want: FormatJSON},
@@ -42,7 +47,10 @@ func TestGuessFormatFromName(t *testing.T) {
 		{name: "nodotcsv", wantErr: true},
 		{name: "log.txt", wantErr: true},
 		{name: "file.tsv.data", wantErr: true},
-		{name: "compressed.adx.gz", wantErr: true},
+		{name: "compressed.adx.gz", want: FormatADX},
+		{name: "compressed.adi.gz", want: FormatADI},
+		{name: "COMPRESSED.CSV.GZ", want: FormatCSV},
+		{name: "compressed.gz", wantErr: true},
 		{name: "/path/to/files.adi/noext", wantErr: true},
 	}
 	for _, tc := range tests {
@@ -155,6 +163,12 @@ func TestGuessFormatFromContent(t *testing.T) {
 			records: 1,
 			text:    shortSpace + "CALL,MODE\nW1AW,CW\n",
 		},
+		{
+			name:    "EDI basic",
+			want:    FormatEDI,
+			records: 1,
+			text:    "[REG1TEST;1]\r\nPCall=W1AW\r\n[QSORecords;1]\r\n240907;1234;K1A;2;599;001;599;002;;FN31PR;100;;;;\r\n[END;]\r\n",
+		},
 		{
 			name:    "JSON basic",
 			want:    FormatJSON,
@@ -283,6 +297,8 @@ func TestGuessFormatFromContent(t *testing.T) {
 					fr = NewADXIO()
 				case FormatCSV:
 					fr = NewCSVIO()
+				case FormatEDI:
+					fr = NewEDIIO()
 				case FormatJSON:
 					fr = NewJSONIO()
 				case FormatTSV:
@@ -27,6 +27,32 @@ type Writer interface {
 	Write(*Logfile, io.Writer) error
 }
 
+// FieldLimiter is implemented by a Writer which can only represent some fields,
+// e.g. Cabrillo QSO lines have a fixed set of columns.
+type FieldLimiter interface {
+	// SupportedFields returns the names of fields which can appear in output,
+	// either directly or by contributing to a header.
+	SupportedFields() []string
+}
+
+// RecordReader is implemented by a Reader which can handle one record at a
+// time, so a caller can stop early or avoid holding a whole log in memory.
+type RecordReader interface {
+	// ReadRecords reads a logfile like Read, but passes each record to fn
+	// rather than adding it to the logfile's Records.  l has the header, field
+	// order, and userdef fields read so far.  If fn returns false, reading stops
+	// and ReadRecords returns without an error.
+	ReadRecords(in io.Reader, fn func(l *Logfile, r *Record) bool) (*Logfile, error)
+}
+
+// readAll implements Reader.Read for a RecordReader.
+func readAll(r RecordReader, in io.Reader) (*Logfile, error) {
+	return r.ReadRecords(in, func(l *Logfile, rec *Record) bool {
+		l.AddRecord(rec)
+		return true
+	})
+}
+
 type ReadWriter interface {
 	Reader
 	Writer
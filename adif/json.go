@@ -15,11 +15,14 @@
 package adif
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"strconv"
+	"regexp"
 	"strings"
+
+	"github.com/flwyd/adif-multitool/adif/spec"
 )
 
 type jsonRecord map[string]any
@@ -28,25 +31,7 @@ func newJsonRecord(r *Record, typed bool) jsonRecord {
 	j := make(jsonRecord)
 	for _, f := range r.Fields() {
 		if typed {
-			switch f.Type {
-			default:
-				// TODO look up field type in spec
-				j[f.Name] = f.Value
-			case TypeBoolean:
-				if f.Value == "Y" || f.Value == "y" {
-					j[f.Name] = true
-				} else if f.Value == "N" || f.Value == "n" {
-					j[f.Name] = false
-				} else {
-					j[f.Name] = f.Value
-				}
-			case TypeNumber:
-				if v, err := strconv.ParseFloat(f.Value, 64); err != nil {
-					j[f.Name] = f.Value
-				} else {
-					j[f.Name] = v
-				}
-			}
+			j[f.Name] = jsonValue(f, jsonFieldType(f))
 		} else {
 			j[f.Name] = f.Value
 		}
@@ -54,30 +39,149 @@ func newJsonRecord(r *Record, typed bool) jsonRecord {
 	return j
 }
 
+// jsonNumberPat matches the JSON number syntax which is also an ADIF Number,
+// i.e. no exponent and no leading zeroes.
+var jsonNumberPat = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// jsonValue returns a JSON bool or number for Boolean and Number fields with
+// valid values, otherwise the value as a string.  Numbers keep their original
+// digits, e.g. 14.07400 is not shortened.
+func jsonValue(f Field, t DataType) any {
+	switch t {
+	case TypeBoolean:
+		if f.Value == "Y" || f.Value == "y" {
+			return true
+		} else if f.Value == "N" || f.Value == "n" {
+			return false
+		}
+	case TypeNumber:
+		if jsonNumberPat.MatchString(f.Value) {
+			return json.Number(f.Value)
+		}
+	}
+	return f.Value
+}
+
 func (j jsonRecord) toRecord() (*Record, error) {
 	r := NewRecord()
 	for k, v := range j {
-		switch vv := v.(type) {
-		default:
-			// TODO handle USERDEF fields
-			return nil, fmt.Errorf("unsupported JSON field type %q: %v", k, v)
-		case string:
-			r.Set(Field{Name: k, Value: vv})
-		case bool:
-			if vv {
-				r.Set(Field{Name: k, Value: "Y", Type: TypeBoolean})
-			} else {
-				r.Set(Field{Name: k, Value: "N", Type: TypeBoolean})
-			}
-		case json.Number:
-			r.Set(Field{Name: k, Value: vv.String(), Type: TypeNumber})
-		case nil:
-			r.Set(Field{Name: k, Value: ""})
+		f, err := jsonField(k, v)
+		if err != nil {
+			return nil, err
 		}
+		r.Set(f)
 	}
 	return r, nil
 }
 
+// jsonField converts a JSON value decoded with json.Decoder.UseNumber to a
+// field.
+func jsonField(name string, v any) (Field, error) {
+	switch vv := v.(type) {
+	default:
+		// TODO handle USERDEF fields
+		return Field{}, fmt.Errorf("unsupported JSON field type %q: %v", name, v)
+	case string:
+		return Field{Name: name, Value: vv}, nil
+	case bool:
+		if vv {
+			return Field{Name: name, Value: "Y", Type: TypeBoolean}, nil
+		}
+		return Field{Name: name, Value: "N", Type: TypeBoolean}, nil
+	case json.Number:
+		return Field{Name: name, Value: vv.String(), Type: TypeNumber}, nil
+	case nil:
+		return Field{Name: name, Value: ""}, nil
+	}
+}
+
+// MarshalJSON encodes r as a JSON object with field names as keys, in field
+// order.  Fields with a Number type, either set on the field or from the ADIF
+// specification (including Integer and PositiveInteger), are encoded as JSON numbers if they have decimal syntax like
+// 14.074 (not 014 or 1e3); Boolean fields are encoded as JSON booleans.  Other
+// values are strings.
+func (r *Record) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range r.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(jsonValue(f, jsonFieldType(f)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the fields in r with the keys and values of a JSON
+// object, in order, as if by calling Set.  Values may be strings, numbers,
+// booleans (converted to Y or N), or null (an empty field).
+func (r *Record) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if t, err := d.Token(); err != nil {
+		return err
+	} else if t != json.Delim('{') {
+		return fmt.Errorf("JSON record must be an object, got %v", t)
+	}
+	res := NewRecord()
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		k, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("JSON field name must be a string, got %v", t)
+		}
+		var v any
+		if err := d.Decode(&v); err != nil {
+			return err
+		}
+		f, err := jsonField(k, v)
+		if err != nil {
+			return err
+		}
+		if err := res.Set(f); err != nil {
+			return err
+		}
+	}
+	if _, err := d.Token(); err != nil {
+		return err
+	}
+	res.comment = r.comment
+	*r = *res
+	return nil
+}
+
+// jsonFieldType returns the type of f, or the type of the field with the same
+// name in the ADIF specification if f doesn't have a type.
+func jsonFieldType(f Field) DataType {
+	if f.Type != TypeUnspecified {
+		return f.Type
+	}
+	if sf, ok := spec.FieldNamed(f.Name); ok {
+		switch sf.Type.Name {
+		case spec.NumberDataType.Name, spec.IntegerDataType.Name, spec.PositiveIntegerDataType.Name:
+			return TypeNumber
+		}
+	}
+	return TypeUnspecified
+}
+
 type jsonFile struct {
 	Header  jsonRecord   `json:"HEADER"`
 	Records []jsonRecord `json:"RECORDS"`
@@ -15,6 +15,7 @@
 package adif
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -133,10 +134,12 @@ Inverted L antenna, < 70' above ground
 		Field{Name: "NAME", Value: `"C.G." Tuska`, Type: TypeString},
 	))
 	l.Records[1].SetComment("Record comment")
-	l.Header.Set(Field{Name: "adif_ver", Value: "3.1.4"})
-	l.Header.Set(Field{Name: "PROGRAMID", Value: "adx_test"})
-	l.Header.Set(Field{Name: "PROGRAMVERSION", Value: "1.2.3"})
-	l.Header.Set(Field{Name: "CREATED_TIMESTAMP", Value: "20220102 153456"})
+	l.Header = NewRecord(
+		Field{Name: "adif_ver", Value: "3.1.4"},
+		Field{Name: "PROGRAMID", Value: "adx_test"},
+		Field{Name: "PROGRAMVERSION", Value: "1.2.3"},
+		Field{Name: "CREATED_TIMESTAMP", Value: "20220102 153456"},
+	)
 	want := `{
  "HEADER": {
   "ADIF_VER": "3.1.4",
@@ -185,3 +188,62 @@ Inverted L antenna, < 70' above ground
 		}
 	}
 }
+
+func TestRecordMarshalJSON(t *testing.T) {
+	r := NewRecord(
+		Field{Name: "CALL", Value: "W1AW"},
+		Field{Name: "BAND", Value: "20m"},
+		Field{Name: "FREQ", Value: "14.07400"},
+		Field{Name: "CQZ", Value: "05"},
+		Field{Name: "TX_PWR", Value: "about 5"},
+		Field{Name: "QSO_DATE", Value: "20240102"},
+		Field{Name: "SWL", Value: "N", Type: TypeBoolean},
+		Field{Name: "APP_LOG_SCORE", Value: "42", Type: TypeNumber},
+		Field{Name: "APP_LOG_GAIN", Value: "NaN", Type: TypeNumber},
+		Field{Name: "APP_LOG_LOSS", Value: "-Inf", Type: TypeNumber},
+		Field{Name: "APP_LOG_EXP", Value: "1e3", Type: TypeNumber},
+		Field{Name: "NAME_INTL", Value: "Pedro Peña"},
+	)
+	want := `{"CALL":"W1AW","BAND":"20m","FREQ":14.07400,"CQZ":"05","TX_PWR":"about 5","QSO_DATE":"20240102","SWL":false,"APP_LOG_SCORE":42,"APP_LOG_GAIN":"NaN","APP_LOG_LOSS":"-Inf","APP_LOG_EXP":"1e3","NAME_INTL":"Pedro Peña"}`
+	got, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) got error %v", r, err)
+	}
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("json.Marshal(%v) unexpected output, diff:\n%s", r, diff)
+	}
+	if got, err := json.Marshal(NewRecord()); err != nil || string(got) != "{}" {
+		t.Errorf("json.Marshal(empty record) got %s, %v, want {}", got, err)
+	}
+}
+
+func TestRecordUnmarshalJSON(t *testing.T) {
+	in := `{"call": "W1AW", "BAND": "20m", "FREQ": 14.074, "SWL": true, "NOTES": null}`
+	want := NewRecord(
+		Field{Name: "CALL", Value: "W1AW"},
+		Field{Name: "BAND", Value: "20m"},
+		Field{Name: "FREQ", Value: "14.074", Type: TypeNumber},
+		Field{Name: "SWL", Value: "Y", Type: TypeBoolean},
+		Field{Name: "NOTES", Value: ""},
+	)
+	var got Record
+	if err := json.Unmarshal([]byte(in), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) got error %v", in, err)
+	}
+	if diff := cmp.Diff(want.Fields(), got.Fields()); diff != "" {
+		t.Errorf("json.Unmarshal(%s) unexpected fields, diff:\n%s", in, diff)
+	}
+	var recs []*Record
+	if err := json.Unmarshal([]byte(`[{"CALL": "K1AB"}, {"CALL": "K2CD", "MODE": "CW"}]`), &recs); err != nil {
+		t.Fatalf("json.Unmarshal into []*Record got error %v", err)
+	}
+	if len(recs) != 2 || !recs[1].Equal(NewRecord(Field{Name: "CALL", Value: "K2CD"}, Field{Name: "MODE", Value: "CW"})) {
+		t.Errorf("json.Unmarshal into []*Record got %v", recs)
+	}
+	for _, s := range []string{`["CALL", "W1AW"]`, `{"CALL": {"VALUE": "W1AW"}}`, `{"": "W1AW"}`} {
+		var r Record
+		if err := json.Unmarshal([]byte(s), &r); err == nil {
+			t.Errorf("json.Unmarshal(%s) want error, got %v", s, &r)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adif
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// MarkdownWriter writes a logfile as a Markdown table with one column per
+// field, suitable for sharing in forums and documentation.  Markdown is an
+// output-only format.
+type MarkdownWriter struct {
+	// MaxWidth truncates values longer than this many characters, adding an
+	// ellipsis.  Zero means no limit.
+	MaxWidth int
+}
+
+func NewMarkdownWriter() *MarkdownWriter { return &MarkdownWriter{} }
+
+func (_ *MarkdownWriter) String() string { return "markdown" }
+
+func (o *MarkdownWriter) Write(l *Logfile, w io.Writer) error {
+	order := make([]string, 0, len(l.FieldOrder))
+	seen := make(map[string]bool)
+	for _, n := range l.FieldOrder {
+		n = strings.ToUpper(n)
+		if !seen[n] {
+			order = append(order, n)
+			seen[n] = true
+		}
+	}
+	for _, r := range l.Records {
+		for _, f := range r.Fields() {
+			n := strings.ToUpper(f.Name)
+			if !seen[n] {
+				order = append(order, n)
+				seen[n] = true
+			}
+		}
+	}
+	if len(order) == 0 {
+		return nil
+	}
+	rows := make([][]string, len(l.Records)+1)
+	widths := make([]int, len(order))
+	rows[0] = make([]string, len(order))
+	for i, n := range order {
+		rows[0][i] = o.escape(n)
+		widths[i] = utf8.RuneCountInString(rows[0][i])
+		if widths[i] < 3 {
+			widths[i] = 3 // minimum delimiter row is ---
+		}
+	}
+	for i, r := range l.Records {
+		row := make([]string, len(order))
+		for j, n := range order {
+			f, _ := r.Get(n)
+			row[j] = o.escape(o.truncate(f.Value))
+			if c := utf8.RuneCountInString(row[j]); c > widths[j] {
+				widths[j] = c
+			}
+		}
+		rows[i+1] = row
+	}
+	out := bufio.NewWriter(w)
+	writeRow := func(row []string) error {
+		for i, v := range row {
+			if _, err := fmt.Fprintf(out, "| %s%s ", v, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v))); err != nil {
+				return err
+			}
+		}
+		_, err := out.WriteString("|\n")
+		return err
+	}
+	if err := writeRow(rows[0]); err != nil {
+		return fmt.Errorf("writing Markdown header: %w", err)
+	}
+	delim := make([]string, len(order))
+	for i := range delim {
+		delim[i] = strings.Repeat("-", widths[i])
+	}
+	if err := writeRow(delim); err != nil {
+		return fmt.Errorf("writing Markdown header: %w", err)
+	}
+	for _, row := range rows[1:] {
+		if err := writeRow(row); err != nil {
+			return fmt.Errorf("writing Markdown record: %w", err)
+		}
+	}
+	return out.Flush()
+}
+
+func (o *MarkdownWriter) truncate(s string) string {
+	if o.MaxWidth <= 0 || utf8.RuneCountInString(s) <= o.MaxWidth {
+		return s
+	}
+	if o.MaxWidth <= 3 {
+		return strings.Repeat(".", o.MaxWidth)
+	}
+	r := []rune(s)
+	return strings.TrimRight(string(r[:o.MaxWidth-3]), " ") + "..."
+}
+
+// escape makes s safe for a table cell: pipes are escaped and line breaks
+// become spaces, since Markdown table rows are a single line.
+func (o *MarkdownWriter) escape(s string) string {
+	if !strings.ContainsAny(s, "|\\\r\n") {
+		return s
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adif
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	l := NewLogfile()
+	l.FieldOrder = []string{"CALL", "BAND"}
+	l.AddRecord(NewRecord(Field{Name: "CALL", Value: "W1AW"}, Field{Name: "BAND", Value: "40m"}, Field{Name: "COMMENT", Value: "Hiram Percy Maxim | ARRL founder"}))
+	l.AddRecord(NewRecord(Field{Name: "call", Value: "N0P"}, Field{Name: "NOTES", Value: "Line one\nline two"}))
+	tests := []struct {
+		name     string
+		maxWidth int
+		want     string
+	}{
+		{
+			name: "no limit",
+			want: `| CALL | BAND | COMMENT                           | NOTES             |
+| ---- | ---- | --------------------------------- | ----------------- |
+| W1AW | 40m  | Hiram Percy Maxim \| ARRL founder |                   |
+| N0P  |      |                                   | Line one line two |
+`,
+		},
+		{
+			name:     "max width",
+			maxWidth: 12,
+			want: `| CALL | BAND | COMMENT      | NOTES        |
+| ---- | ---- | ------------ | ------------ |
+| W1AW | 40m  | Hiram Per... |              |
+| N0P  |      |              | Line one ... |
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			md := NewMarkdownWriter()
+			md.MaxWidth = tc.maxWidth
+			out := &strings.Builder{}
+			if err := md.Write(l, out); err != nil {
+				t.Fatalf("Write(%v) got error %v", l, err)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("Write(%v) unexpected output, diff:\n%s", l, diff)
+			}
+		})
+	}
+}
@@ -32,6 +32,9 @@ type Record struct {
 	comment string
 }
 
+// NewRecord returns a record with the given fields, in order, as if by calling
+// Set for each one.  Fields with an empty name are ignored and a repeated name
+// replaces the earlier value.
 func NewRecord(fs ...Field) *Record {
 	r := &Record{fields: make([]Field, 0, len(fs)), named: make(map[string]int)}
 	for _, f := range fs {
@@ -17,6 +17,7 @@
 package spec_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/flwyd/adif-multitool/adif"
@@ -36,3 +37,19 @@ func TestDataTypeIndicators(t *testing.T) {
 		}
 	}
 }
+
+func TestAllDataTypeIndicatorsKnown(t *testing.T) {
+	for _, dt := range spec.DataTypes {
+		if dt.Indicator == "" {
+			continue
+		}
+		for _, id := range []string{dt.Indicator, strings.ToLower(dt.Indicator)} {
+			got, err := adif.DataTypeFromIndicator(id)
+			if err != nil {
+				t.Errorf("DataTypeFromIndicator(%q) for %s got error %v", id, dt.Name, err)
+			} else if got.Indicator() != dt.Indicator {
+				t.Errorf("DataTypeFromIndicator(%q) got %s, want %s", id, got.Indicator(), dt.Indicator)
+			}
+		}
+	}
+}
@@ -0,0 +1,456 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "strings"
+
+// DXCCFromCallsign returns the DXCC entities which may be indicated by the
+// prefix of a callsign.  Returns an empty slice if the prefix is not known or
+// the station is maritime or aeronautical mobile.  If the callsign has a
+// portable designator, like W6/G0ABC or G0ABC/W6, the shorter part is used as
+// the prefix.  Suffixes like /P, /M, /QRP, and call area digits are ignored.
+//
+// The result is a guess: licenses in many countries (including the United
+// States) can be kept after moving to a different DXCC entity, special event
+// callsigns may use unusual prefixes, and some prefixes (like VP8 and 3D2)
+// are shared by several entities.  Prefixes are based on the ITU allocation
+// table and the ARRL DXCC list; deleted entities are not included.
+func DXCCFromCallsign(call string) []CountryEnum {
+	var parts []string
+	for _, p := range strings.Split(strings.ToUpper(strings.TrimSpace(call)), "/") {
+		switch {
+		case p == "MM", p == "AM":
+			return []CountryEnum{}
+		case p == "", p == "P", p == "M", p == "A", p == "QRP", p == "LH":
+			continue
+		case len(p) == 1 && p[0] >= '0' && p[0] <= '9':
+			continue
+		}
+		parts = append(parts, p)
+	}
+	if len(parts) == 0 {
+		return []CountryEnum{}
+	}
+	pfx := parts[0]
+	for _, p := range parts[1:] {
+		if len(p) < len(pfx) {
+			pfx = p
+		}
+	}
+	for n := len(pfx); n > 0; n-- {
+		if c, ok := callsignPrefixes[pfx[:n]]; ok {
+			return c
+		}
+	}
+	return []CountryEnum{}
+}
+
+var callsignPrefixes = make(map[string][]CountryEnum)
+
+func addCallsignPrefixes(c CountryEnum, prefixes ...string) {
+	for _, p := range prefixes {
+		callsignPrefixes[p] = append(callsignPrefixes[p], c)
+	}
+}
+
+func init() {
+	for _, p := range callsignPrefixList {
+		addCallsignPrefixes(p.country, strings.Fields(p.prefixes)...)
+	}
+	// United States: K, N, W, AA-AK, with territories indicated by the second
+	// letter and digit in AH, KH, NH, WH, KP, NP, and WP.  AL, KL, NL, and WL
+	// are Alaska.
+	us := []string{"K", "N", "W"}
+	for c := 'A'; c <= 'K'; c++ {
+		if c != 'H' {
+			addCallsignPrefixes(CountryUnitedStatesOfAmerica, "A"+string(c))
+		}
+	}
+	addCallsignPrefixes(CountryUnitedStatesOfAmerica, us...)
+	for _, l := range append(us, "A") {
+		addCallsignPrefixes(CountryAlaska, l+"L")
+		for d, c := range map[string][]CountryEnum{
+			"H0": {CountryMarianaIslands}, "H1": {CountryBakerHowlandIslands},
+			"H2": {CountryGuam}, "H3": {CountryJohnstonIsland},
+			"H4": {CountryMidwayIsland}, "H5": {CountryPalmyraJarvisIslands},
+			"H6": {CountryHawaii}, "H7": {CountryHawaii}, "H7K": {CountryKureIsland, CountryHawaii},
+			"H8": {CountryAmericanSamoa, CountrySwainsIsland}, "H9": {CountryWakeIsland},
+		} {
+			for _, x := range c {
+				addCallsignPrefixes(x, l+d)
+			}
+		}
+		if l != "A" {
+			for d, c := range map[string]CountryEnum{
+				"P1": CountryNavassaIsland, "P2": CountryVirginIslands,
+				"P3": CountryPuertoRico, "P4": CountryPuertoRico, "P5": CountryDesecheoIsland,
+			} {
+				addCallsignPrefixes(c, l+d)
+			}
+		}
+	}
+	// 2x3 callsigns in the US 4 call area overlap with Guantanamo Bay and
+	// Antarctic stations
+	addCallsignPrefixes(CountryUnitedStatesOfAmerica, "KC4", "KG4")
+	// Russia: digits 0, 8, and 9 are Asiatic Russia, 2F and 2K are Kaliningrad
+	ru := []string{"R", "UA", "UB", "UC", "UD", "UE", "UF", "UG", "UH", "UI"}
+	for c := 'A'; c <= 'Z'; c++ {
+		ru = append(ru, "R"+string(c))
+	}
+	for _, p := range ru {
+		addCallsignPrefixes(CountryEuropeanRussia, p)
+		addCallsignPrefixes(CountryAsiaticRussia, p+"0", p+"8", p+"9")
+		addCallsignPrefixes(CountryKaliningrad, p+"2F", p+"2K")
+	}
+	// Brazilian islands: PY0F Fernando de Noronha, PY0S St Peter and St Paul
+	// Rocks, PY0T Trindade and Martim Vaz
+	for _, p := range strings.Fields("PP PQ PR PS PT PU PV PW PX PY ZV ZW ZX ZY ZZ") {
+		addCallsignPrefixes(CountryFernandoDeNoronha, p+"0F")
+		addCallsignPrefixes(CountryStPeterStPaulRocks, p+"0S")
+		addCallsignPrefixes(CountryTrindadeMartimVazIslands, p+"0T")
+	}
+	// French Southern and Antarctic Lands use FT, a digit, and a letter
+	for d := '0'; d <= '9'; d++ {
+		for l, c := range map[string]CountryEnum{
+			"E": CountryJuanDeNovaEuropa, "G": CountryGloriosoIslands,
+			"J": CountryJuanDeNovaEuropa, "T": CountryTromelinIsland,
+			"W": CountryCrozetIsland, "X": CountryKerguelenIslands,
+			"Z": CountryAmsterdamStPaulIslands,
+		} {
+			addCallsignPrefixes(c, "FT"+string(d)+l)
+		}
+	}
+}
+
+var callsignPrefixList = []struct {
+	country  CountryEnum
+	prefixes string
+}{
+	{CountryAfghanistan, "T6 YA"},
+	{CountryAgalegaStBrandonIslands, "3B6 3B7"},
+	{CountryAlandIslands, "OH0"},
+	{CountryAlbania, "ZA"},
+	{CountryAlgeria, "7X 7R 7T 7U 7V 7W 7Y"},
+	{CountryAndamanNicobarIslands, "VU4"},
+	{CountryAndorra, "C3"},
+	{CountryAngola, "D2 D3"},
+	{CountryAnguilla, "VP2E"},
+	{CountryAnnobonIsland, "3C0"},
+	{CountryAntarctica, "KC4 CE9 RI1AN VK0 ZL5 ZS7 8J1"},
+	{CountryAntiguaBarbuda, "V2"},
+	{CountryArgentina, "LU LO LP LQ LR LS LT LV LW AY AZ L2 L3 L4 L5 L6 L7 L8 L9"},
+	{CountryArmenia, "EK"},
+	{CountryAruba, "P4"},
+	{CountryAscensionIsland, "ZD8"},
+	{CountryAustralIsland, "FO"},
+	{CountryAustralia, "VK AX VH VI VJ VL VM VN VZ"},
+	{CountryAustria, "OE"},
+	{CountryAvesIsland, "YV0"},
+	{CountryAzerbaijan, "4J 4K"},
+	{CountryAzores, "CU CQ8 CR8 CS8 CT8"},
+	{CountryBahamas, "C6"},
+	{CountryBahrain, "A9"},
+	{CountryBalearicIslands, "EA6 EB6 EC6 ED6 EE6 EF6 EG6 EH6"},
+	{CountryBanabaIslandOceanIsland, "T33"},
+	{CountryBangladesh, "S2 S3"},
+	{CountryBarbados, "8P"},
+	{CountryBelarus, "EU EV EW"},
+	{CountryBelgium, "ON OO OP OQ OR OS OT"},
+	{CountryBelize, "V3"},
+	{CountryBenin, "TY"},
+	{CountryBermuda, "VP9"},
+	{CountryBhutan, "A5"},
+	{CountryBolivia, "CP"},
+	{CountryBonaire, "PJ4"},
+	{CountryBosniaHerzegovina, "E7"},
+	{CountryBotswana, "A2 8O"},
+	{CountryBouvet, "3Y"},
+	{CountryBrazil, "PP PQ PR PS PT PU PV PW PX PY ZV ZW ZX ZY ZZ"},
+	{CountryBritishVirginIslands, "VP2V"},
+	{CountryBruneiDarussalam, "V8"},
+	{CountryBulgaria, "LZ"},
+	{CountryBurkinaFaso, "XT"},
+	{CountryBurundi, "9U"},
+	{CountryCKiribatiBritishPhoenixIslands, "T31"},
+	{CountryCambodia, "XU"},
+	{CountryCameroon, "TJ"},
+	{CountryCanada, "VA VB VC VD VE VF VG VO VX VY CF CG CH CI CJ CK CY CZ XJ XK XL XM XN XO"},
+	{CountryCanaryIslands, "EA8 EB8 EC8 ED8 EE8 EF8 EG8 EH8"},
+	{CountryCapeVerde, "D4"},
+	{CountryCaymanIslands, "ZF"},
+	{CountryCentralAfrica, "TL"},
+	{CountryCeutaMelilla, "EA9 EB9 EC9 ED9 EE9 EF9 EG9 EH9"},
+	{CountryChad, "TT"},
+	{CountryChagosIslands, "VQ9"},
+	{CountryChathamIslands, "ZL7"},
+	{CountryChesterfieldIslands, "FK"},
+	{CountryChile, "CE CA CB CC CD XQ XR 3G"},
+	{CountryChina, "B BA BD BG BH BI BJ BL BY BZ 3H 3I 3J 3K 3L 3M 3N 3O 3P 3Q 3R 3S 3T 3U XS"},
+	{CountryChristmasIsland, "VK9X"},
+	{CountryClippertonIsland, "FO"},
+	{CountryCocosIsland, "TI9"},
+	{CountryCocosKeelingIslands, "VK9C"},
+	{CountryColombia, "HK HJ 5J 5K"},
+	{CountryComoros, "D6"},
+	{CountryConwayReef, "3D2"},
+	{CountryCorsica, "TK"},
+	{CountryCostaRica, "TI TE"},
+	{CountryCoteDIvoire, "TU"},
+	{CountryCrete, "SV9 SW9 SX9 SY9 SZ9 J49"},
+	{CountryCroatia, "9A"},
+	{CountryCrozetIsland, "FT5W"},
+	{CountryCuba, "CL CM CO T4"},
+	{CountryCuracao, "PJ2"},
+	{CountryCyprus, "5B C4 H2 P3"},
+	{CountryCzechRepublic, "OK OL"},
+	{CountryDemocraticPeoplesRepOfKorea, "P5 P6 P7 P8 P9 HM"},
+	{CountryDemocraticRepublicOfTheCongo, "9Q 9R 9S 9T"},
+	{CountryDenmark, "OU OV OW OZ 5P 5Q"},
+	{CountryDesecheoIsland, "KP5"},
+	{CountryDjibouti, "J2"},
+	{CountryDodecanese, "SV5 SW5 SX5 SY5 SZ5 J45"},
+	{CountryDominica, "J7"},
+	{CountryDominicanRepublic, "HI"},
+	{CountryDucieIsland, "VP6"},
+	{CountryEKiribatiLineIslands, "T32"},
+	{CountryEastMalaysia, "9M6 9M8 9W6 9W8"},
+	{CountryEasterIsland, "CE0Y XQ0Y XR0Y"},
+	{CountryEcuador, "HC HD"},
+	{CountryEgypt, "SU SS 6A 6B"},
+	{CountryElSalvador, "YS HU"},
+	{CountryEngland, "G GX M MX 2E GB"},
+	{CountryEquatorialGuinea, "3C"},
+	{CountryEritrea, "E3"},
+	{CountryEstonia, "ES"},
+	{CountryEthiopia, "ET E9 9E 9F"},
+	{CountryFalklandIslands, "VP8"},
+	{CountryFaroeIslands, "OY"},
+	{CountryFederalRepublicOfGermany, "DA DB DC DD DE DF DG DH DI DJ DK DL DM DN DO DP DQ DR Y2 Y3 Y4 Y5 Y6 Y7 Y8 Y9"},
+	{CountryFiji, "3D2"},
+	{CountryFinland, "OH OF OG OI"},
+	{CountryFrance, "F TM"},
+	{CountryFranzJosefLand, "R1FJ"},
+	{CountryFrenchGuiana, "FY"},
+	{CountryFrenchPolynesia, "FO"},
+	{CountryGabon, "TR"},
+	{CountryGalapagosIslands, "HC8 HD8"},
+	{CountryGeorgia, "4L"},
+	{CountryGhana, "9G"},
+	{CountryGibraltar, "ZB ZG"},
+	{CountryGreece, "SV SW SX SY SZ J4"},
+	{CountryGreenland, "OX XP"},
+	{CountryGrenada, "J3"},
+	{CountryGuadeloupe, "FG"},
+	{CountryGuantanamoBay, "KG4"},
+	{CountryGuatemala, "TG TD"},
+	{CountryGuernsey, "GU GP MU MP 2U GB"},
+	{CountryGuinea, "3X"},
+	{CountryGuineaBissau, "J5"},
+	{CountryGuyana, "8R"},
+	{CountryHaiti, "HH 4V"},
+	{CountryHeardIsland, "VK0"},
+	{CountryHonduras, "HR HQ"},
+	{CountryHongKong, "VR"},
+	{CountryHungary, "HA HG"},
+	{CountryIceland, "TF"},
+	{CountryIndia, "VU AT AU AV AW 8T 8U 8V 8W 8X 8Y"},
+	{CountryIndonesia, "YB YC YD YE YF YG YH 7A 7B 7C 7D 7E 7F 7G 7H 7I 8A 8B 8C 8D 8E 8F 8G 8H 8I PK PL PM PN PO"},
+	{CountryIran, "EP EQ 9B 9C 9D"},
+	{CountryIraq, "YI HN"},
+	{CountryIreland, "EI EJ"},
+	{CountryIsleOfMan, "GD GT MD MT 2D GB"},
+	{CountryIsrael, "4X 4Z"},
+	{CountryItaly, "I"},
+	{CountryItuHq, "4U1I"},
+	{CountryJamaica, "6Y"},
+	{CountryJanMayen, "JX"},
+	{CountryJapan, "JA JE JF JG JH JI JJ JK JL JM JN JO JP JQ JR JS 7J 7K 7L 7M 7N 8J 8K 8L 8M 8N"},
+	{CountryJersey, "GJ GH MJ MH 2J GB"},
+	{CountryJohnstonIsland, "KH3"},
+	{CountryJordan, "JY"},
+	{CountryJuanFernandezIslands, "CE0Z XQ0Z XR0Z"},
+	{CountryKazakhstan, "UN UO UP UQ"},
+	{CountryKenya, "5Y 5Z"},
+	{CountryKermadecIslands, "ZL8"},
+	{CountryKingdomOfEswatini, "3DA"},
+	{CountryKuwait, "9K"},
+	{CountryKyrgyzstan, "EX"},
+	{CountryLakshadweepIslands, "VU7"},
+	{CountryLaos, "XW"},
+	{CountryLatvia, "YL"},
+	{CountryLebanon, "OD"},
+	{CountryLesotho, "7P"},
+	{CountryLiberia, "EL A8 D5 5L 5M 6Z"},
+	{CountryLibya, "5A"},
+	{CountryLiechtenstein, "HB0"},
+	{CountryLithuania, "LY"},
+	{CountryLordHoweIsland, "VK9L"},
+	{CountryLuxembourg, "LX"},
+	{CountryMacao, "XX9"},
+	{CountryMacquarieIsland, "VK0"},
+	{CountryMadagascar, "5R 5S 6X"},
+	{CountryMadeiraIslands, "CT3 CQ3 CR3 CS3 CT9 CQ9 CR9"},
+	{CountryMalawi, "7Q"},
+	{CountryMaldives, "8Q"},
+	{CountryMali, "TZ"},
+	{CountryMalpeloIsland, "HK0 HJ0"},
+	{CountryMalta, "9H"},
+	{CountryMarketReef, "OJ0"},
+	{CountryMarquesasIslands, "FO"},
+	{CountryMarshallIslands, "V7"},
+	{CountryMartinique, "FM"},
+	{CountryMauritania, "5T"},
+	{CountryMauritius, "3B8"},
+	{CountryMayotte, "FH"},
+	{CountryMellishReef, "VK9M"},
+	{CountryMexico, "XA XB XC XD XE XF XG XH XI 4A 4B 4C 6D 6E 6F 6G 6H 6I 6J"},
+	{CountryMicronesia, "V6"},
+	{CountryMinamiTorishima, "JD1"},
+	{CountryMoldova, "ER"},
+	{CountryMonaco, "3A"},
+	{CountryMongolia, "JT JU JV"},
+	{CountryMontenegro, "4O"},
+	{CountryMontserrat, "VP2M"},
+	{CountryMorocco, "CN 5C 5D 5E 5F 5G"},
+	{CountryMountAthos, "SV2ASP SY2A"},
+	{CountryMozambique, "C8 C9"},
+	{CountryMyanmar, "XY XZ"},
+	{CountryNamibia, "V5"},
+	{CountryNauru, "C2"},
+	{CountryNavassaIsland, "KP1"},
+	{CountryNepal, "9N"},
+	{CountryNetherlands, "PA PB PC PD PE PF PG PH PI"},
+	{CountryNewCaledonia, "FK"},
+	{CountryNewZealand, "ZL ZM"},
+	{CountryNewZealandSubantarcticIslands, "ZL9"},
+	{CountryNicaragua, "YN H6 H7 HT"},
+	{CountryNiger, "5U"},
+	{CountryNigeria, "5N 5O"},
+	{CountryNiue, "E6"},
+	{CountryNorfolkIsland, "VK9N"},
+	{CountryNorthCookIslands, "E5"},
+	{CountryNorthMacedoniaRepublicOf, "Z3"},
+	{CountryNorthernIreland, "GI GN MI MN 2I GB"},
+	{CountryNorway, "LA LB LC LD LE LF LG LH LI LJ LK LL LM LN"},
+	{CountryOgasawara, "JD1"},
+	{CountryOman, "A4"},
+	{CountryPakistan, "AP AQ AR AS 6P 6Q 6R 6S"},
+	{CountryPalau, "T8"},
+	{CountryPalestine, "E4"},
+	{CountryPanama, "HP HO H3 H8 H9 3E 3F"},
+	{CountryPapuaNewGuinea, "P2"},
+	{CountryParaguay, "ZP"},
+	{CountryPeru, "OA OB OC 4T"},
+	{CountryPeter1Island, "3Y"},
+	{CountryPhilippines, "DU DV DW DX DY DZ 4D 4E 4F 4G 4H 4I"},
+	{CountryPitcairnIsland, "VP6"},
+	{CountryPoland, "SP SN SO SQ SR 3Z HF"},
+	{CountryPortugal, "CT CQ CR CS"},
+	{CountryPratasIsland, "BV9P"},
+	{CountryPrinceEdwardMarionIslands, "ZS8"},
+	{CountryQatar, "A7"},
+	{CountryRepublicOfKorea, "HL 6K 6L 6M 6N D7 D8 D9 DS DT"},
+	{CountryRepublicOfKosovo, "Z6"},
+	{CountryRepublicOfSouthAfrica, "ZS ZR ZT ZU"},
+	{CountryRepublicOfTheCongo, "TN"},
+	{CountryReunionIsland, "FR"},
+	{CountryRevillagigedo, "XF4"},
+	{CountryRodriguesIsland, "3B9"},
+	{CountryRomania, "YO YP YQ YR"},
+	{CountryRotumaIsland, "3D2"},
+	{CountryRwanda, "9X"},
+	{CountrySabaStEustatius, "PJ5 PJ6"},
+	{CountrySableIsland, "CY0"},
+	{CountrySaintBarthelemy, "FJ"},
+	{CountrySaintMartin, "FS"},
+	{CountrySamoa, "5W"},
+	{CountrySanAndresProvidencia, "HK0 HJ0"},
+	{CountrySanFelixSanAmbrosio, "CE0X XQ0X XR0X"},
+	{CountrySanMarino, "T7"},
+	{CountrySaoTomePrincipe, "S9"},
+	{CountrySardinia, "IS0 IM0"},
+	{CountrySaudiArabia, "HZ 7Z 8Z"},
+	{CountryScarboroughReef, "BS7"},
+	{CountryScotland, "GM GS MM MS 2M GB"},
+	{CountrySenegal, "6V 6W"},
+	{CountrySerbia, "YT YU"},
+	{CountrySeychelles, "S7"},
+	{CountrySierraLeone, "9L"},
+	{CountrySingapore, "9V S6"},
+	{CountrySintMaarten, "PJ7"},
+	{CountrySlovakRepublic, "OM"},
+	{CountrySlovenia, "S5"},
+	{CountrySolomonIslands, "H4"},
+	{CountrySomalia, "T5 6O"},
+	{CountrySouthCookIslands, "E5"},
+	{CountrySouthGeorgiaIsland, "VP8"},
+	{CountrySouthOrkneyIslands, "VP8"},
+	{CountrySouthSandwichIslands, "VP8"},
+	{CountrySouthShetlandIslands, "VP8"},
+	{CountrySouthSudanRepublicOf, "Z8"},
+	{CountrySovereignMilitaryOrderOfMalta, "1A"},
+	{CountrySpain, "EA EB EC ED EE EF EG EH AM AN AO"},
+	{CountrySpratlyIslands, "1S"},
+	{CountrySriLanka, "4S 4P 4Q 4R"},
+	{CountryStHelena, "ZD7"},
+	{CountryStKittsNevis, "V4"},
+	{CountryStLucia, "J6"},
+	{CountryStPaulIsland, "CY9"},
+	{CountryStPierreMiquelon, "FP"},
+	{CountryStVincent, "J8"},
+	{CountrySudan, "ST 6T 6U"},
+	{CountrySuriname, "PZ"},
+	{CountrySvalbard, "JW"},
+	{CountrySweden, "SM SA SB SC SD SE SF SG SH SI SJ SK SL 7S 8S"},
+	{CountrySwitzerland, "HB HE"},
+	{CountrySyria, "YK 6C"},
+	{CountryTaiwan, "BM BN BO BP BQ BU BV BW BX"},
+	{CountryTajikistan, "EY"},
+	{CountryTanzania, "5H 5I"},
+	{CountryTemotuProvince, "H40"},
+	{CountryThailand, "HS E2"},
+	{CountryTheGambia, "C5"},
+	{CountryTimorLeste, "4W"},
+	{CountryTogo, "5V"},
+	{CountryTokelauIslands, "ZK3"},
+	{CountryTonga, "A3"},
+	{CountryTrinidadTobago, "9Y 9Z"},
+	{CountryTristanDaCunhaGoughIsland, "ZD9"},
+	{CountryTunisia, "3V TS"},
+	{CountryTurkey, "TA TB TC YM"},
+	{CountryTurkmenistan, "EZ"},
+	{CountryTurksCaicosIslands, "VP5"},
+	{CountryTuvalu, "T2"},
+	{CountryUganda, "5X"},
+	{CountryUkSovereignBaseAreasOnCyprus, "ZC4"},
+	{CountryUkraine, "UR US UT UU UV UW UX UY UZ EM EN EO"},
+	{CountryUnitedArabEmirates, "A6"},
+	{CountryUnitedNationsHq, "4U1U"},
+	{CountryUruguay, "CX CV CW"},
+	{CountryUzbekistan, "UJ UK UL UM"},
+	{CountryVanuatu, "YJ"},
+	{CountryVatican, "HV"},
+	{CountryVenezuela, "YV YW YX YY 4M"},
+	{CountryVietNam, "XV 3W"},
+	{CountryWKiribatiGilbertIslands, "T30"},
+	{CountryWales, "GW GC MW MC 2W GB"},
+	{CountryWallisFutunaIslands, "FW"},
+	{CountryWestMalaysia, "9M2 9M4 9W2 9W4"},
+	{CountryWesternSahara, "S0"},
+	{CountryWillisIsland, "VK9W"},
+	{CountryYemen, "7O"},
+	{CountryZambia, "9J 9I"},
+	{CountryZimbabwe, "Z2"},
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAllActiveCountriesHavePrefix(t *testing.T) {
+	found := make(map[CountryEnum]bool)
+	for _, cs := range callsignPrefixes {
+		for _, c := range cs {
+			found[c] = true
+		}
+	}
+	for _, e := range CountryEnumeration.Values {
+		c := e.(CountryEnum)
+		if c == CountryNone || c.Deleted == "true" {
+			continue
+		}
+		if !found[c] {
+			t.Errorf("no callsign prefix for %s %s", c.EntityCode, c.EntityName)
+		}
+	}
+}
+
+func TestDXCCFromCallsign(t *testing.T) {
+	tests := []struct {
+		call string
+		want []CountryEnum
+	}{
+		{call: "W1AW", want: []CountryEnum{CountryUnitedStatesOfAmerica}},
+		{call: "w1aw/4", want: []CountryEnum{CountryUnitedStatesOfAmerica}},
+		{call: "AA7BQ", want: []CountryEnum{CountryUnitedStatesOfAmerica}},
+		{call: "KL7AA", want: []CountryEnum{CountryAlaska}},
+		{call: "NH6Y", want: []CountryEnum{CountryHawaii}},
+		{call: "WP4ABC", want: []CountryEnum{CountryPuertoRico}},
+		{call: "KG4AB", want: []CountryEnum{CountryGuantanamoBay, CountryUnitedStatesOfAmerica}},
+		{call: "VE3ABC", want: []CountryEnum{CountryCanada}},
+		{call: "CY0S", want: []CountryEnum{CountrySableIsland}},
+		{call: "G0ABC", want: []CountryEnum{CountryEngland}},
+		{call: "GM3ABC", want: []CountryEnum{CountryScotland}},
+		{call: "2E0XYZ", want: []CountryEnum{CountryEngland}},
+		{call: "W6/G0ABC", want: []CountryEnum{CountryUnitedStatesOfAmerica}},
+		{call: "G0ABC/W6", want: []CountryEnum{CountryUnitedStatesOfAmerica}},
+		{call: "KH6/W1AW/P", want: []CountryEnum{CountryHawaii}},
+		{call: "DL1ABC/QRP", want: []CountryEnum{CountryFederalRepublicOfGermany}},
+		{call: "UA3ABC", want: []CountryEnum{CountryEuropeanRussia}},
+		{call: "RA9ABC", want: []CountryEnum{CountryAsiaticRussia}},
+		{call: "UA2FAA", want: []CountryEnum{CountryKaliningrad}},
+		{call: "PY0FF", want: []CountryEnum{CountryFernandoDeNoronha}},
+		{call: "PY2XYZ", want: []CountryEnum{CountryBrazil}},
+		{call: "FT5XO", want: []CountryEnum{CountryKerguelenIslands}},
+		{call: "IS0ABC", want: []CountryEnum{CountrySardinia}},
+		{call: "IK2ABC", want: []CountryEnum{CountryItaly}},
+		{call: "BV2A", want: []CountryEnum{CountryTaiwan}},
+		{call: "BY1AA", want: []CountryEnum{CountryChina}},
+		{call: "4U1UN", want: []CountryEnum{CountryUnitedNationsHq}},
+		{call: "VP8LP", want: []CountryEnum{CountryFalklandIslands, CountrySouthGeorgiaIsland, CountrySouthOrkneyIslands, CountrySouthSandwichIslands, CountrySouthShetlandIslands}},
+		{call: "W1AW/MM", want: []CountryEnum{}},
+		{call: "Q1XYZ", want: []CountryEnum{}},
+		{call: "", want: []CountryEnum{}},
+	}
+	for _, tc := range tests {
+		if diff := cmp.Diff(tc.want, DXCCFromCallsign(tc.call)); diff != "" {
+			t.Errorf("DXCCFromCallsign(%q) unexpected result, diff:\n%s", tc.call, diff)
+		}
+	}
+}
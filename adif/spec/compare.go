@@ -182,6 +182,52 @@ func compareBooleans(a, b string) (int, error) {
 	return 1, nil
 }
 
+// ParseLocation parses a Location value like LAT or LON in XDDD MM.MMM format,
+// e.g. "N041 42.850", returning the direction (N, S, E, or W) and decimal
+// degrees with south and west as negative numbers.
+func ParseLocation(s string) (dir rune, degrees float64, err error) {
+	dir, deg, min, err := parseLocation(s)
+	if err != nil {
+		return 0, 0, err
+	}
+	if min >= 60 {
+		return 0, 0, fmt.Errorf("location minutes out of range %q", s)
+	}
+	degrees = float64(deg) + min/60
+	max := 180.0
+	if dir == 'N' || dir == 'S' {
+		max = 90
+	}
+	if degrees > max {
+		return 0, 0, fmt.Errorf("location degrees out of range %q", s)
+	}
+	if dir == 'S' || dir == 'W' {
+		degrees = -degrees
+	}
+	return dir, degrees, nil
+}
+
+// ParseLatLon parses a latitude (N or S) and longitude (E or W) pair, e.g.
+// from LAT and LON fields, into decimal degrees with south and west as negative
+// numbers.
+func ParseLatLon(lat, lon string) (latDeg, lonDeg float64, err error) {
+	dir, latDeg, err := ParseLocation(lat)
+	if err != nil {
+		return 0, 0, err
+	}
+	if dir != 'N' && dir != 'S' {
+		return 0, 0, fmt.Errorf("invalid latitude direction %q", lat)
+	}
+	dir, lonDeg, err = ParseLocation(lon)
+	if err != nil {
+		return 0, 0, err
+	}
+	if dir != 'E' && dir != 'W' {
+		return 0, 0, fmt.Errorf("invalid longitude direction %q", lon)
+	}
+	return latDeg, lonDeg, nil
+}
+
 func parseLocation(s string) (dir rune, degrees int64, minutes float64, err error) {
 	g := locationPat.FindStringSubmatch(s)
 	if g == nil {
@@ -15,6 +15,7 @@
 package spec
 
 import (
+	"math"
 	"testing"
 
 	"golang.org/x/text/language"
@@ -489,3 +490,36 @@ func TestCompareLocations(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLatLon(t *testing.T) {
+	tests := []struct {
+		lat, lon         string
+		wantLat, wantLon float64
+		wantErr          bool
+	}{
+		{lat: "N041 42.850", lon: "W072 43.583", wantLat: 41.714167, wantLon: -72.726383},
+		{lat: "s033 51.583", lon: "e151 12.600", wantLat: -33.859717, wantLon: 151.21},
+		{lat: "N000 00.000", lon: "E180 00.000", wantLat: 0, wantLon: 180},
+		{lat: "N041 42.850", lon: "", wantErr: true},
+		{lat: "", lon: "W072 43.583", wantErr: true},
+		{lat: "E041 42.850", lon: "W072 43.583", wantErr: true},
+		{lat: "N041 42.850", lon: "S072 43.583", wantErr: true},
+		{lat: "N41 42.850", lon: "W072 43.583", wantErr: true},
+		{lat: "N041 60.000", lon: "W072 43.583", wantErr: true},
+		{lat: "N091 00.000", lon: "W072 43.583", wantErr: true},
+		{lat: "N041 42.850", lon: "W181 00.000", wantErr: true},
+		{lat: "41.714", lon: "-72.726", wantErr: true},
+	}
+	for _, tc := range tests {
+		lat, lon, err := ParseLatLon(tc.lat, tc.lon)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ParseLatLon(%q, %q) got %f, %f, want error", tc.lat, tc.lon, lat, lon)
+			}
+		} else if err != nil {
+			t.Errorf("ParseLatLon(%q, %q) got error %v", tc.lat, tc.lon, err)
+		} else if math.Abs(lat-tc.wantLat) > 0.000001 || math.Abs(lon-tc.wantLon) > 0.000001 {
+			t.Errorf("ParseLatLon(%q, %q) got %f, %f, want %f, %f", tc.lat, tc.lon, lat, lon, tc.wantLat, tc.wantLon)
+		}
+	}
+}
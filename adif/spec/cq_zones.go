@@ -16,6 +16,10 @@ package spec
 
 import "strings"
 
+// CQZones returns the CQ Zone numbers for the DXCC entity, e.g. []int{25} for
+// CountryJapan.  See CQZoneFor for details.
+func (e CountryEnum) CQZones() []int { return CQZoneFor(e.EntityCode) }
+
 // CQZoneFor returns a list of CQ Zone numbers for a DXCC entity code or country
 // name.  Returns an empty slice if the entity code or country is not known.
 // For some countries which span multiple CQ Zones, the CqZone property of the
@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+// cqZoneGridEntities computes CQ zones from a location in DXCC entities which
+// span more than one zone.  Boundaries are coarse: they follow state and
+// province borders by latitude bands a degree or two tall, so a locator near a
+// zone boundary may get the neighboring zone.  Regions where the boundary
+// isn't captured return 0, e.g. northern Québec, Labrador, and Nunavut.
+var cqZoneGridEntities = []struct {
+	c    CountryEnum
+	zone func(lat, lon float64) int
+}{
+	{CountryUnitedStatesOfAmerica, usCQZone},
+	{CountryCanada, canadaCQZone},
+	{CountryAustralia, australiaCQZone},
+}
+
+// CQZoneForGrid returns the CQ zone of a Maidenhead locator with at least 4
+// characters in the contiguous United States, southern Canada, or Australia,
+// e.g. 3 for CM87 (San Francisco) and 5 for FN31 (Connecticut).  Returns 0 if
+// the locator is invalid, is outside those regions, or is close enough to a
+// national border that the zone depends on the country.  The center of the
+// locator is used, so 4-character squares which straddle a zone boundary
+// resolve to one side.  Use CountryEnum.CQZoneForGrid if the DXCC entity is
+// known.
+func CQZoneForGrid(grid string) int {
+	lat, lon, ok := gridCenter(grid)
+	if !ok {
+		return 0
+	}
+	zone := 0
+	for _, e := range cqZoneGridEntities {
+		if b, ok := DXCCBounds(e.c.EntityCode); !ok || !b.Contains(lat, lon) {
+			continue
+		}
+		if z := e.zone(lat, lon); z != 0 {
+			if zone != 0 && zone != z {
+				return 0
+			}
+			zone = z
+		}
+	}
+	return zone
+}
+
+// CQZoneForGrid returns the CQ zone of a Maidenhead locator with at least 4
+// characters in the DXCC entity, or 0 if the zone can't be determined.  Only
+// the United States, Canada, and Australia are supported; other entities
+// return 0 even if they're in a single zone, see CQZones for those.
+func (e CountryEnum) CQZoneForGrid(grid string) int {
+	lat, lon, ok := gridCenter(grid)
+	if !ok {
+		return 0
+	}
+	for _, g := range cqZoneGridEntities {
+		if g.c.EntityCode != e.EntityCode {
+			continue
+		}
+		if b, ok := DXCCBounds(e.EntityCode); !ok || !b.Contains(lat, lon) {
+			return 0
+		}
+		return g.zone(lat, lon)
+	}
+	return 0
+}
+
+func gridCenter(grid string) (lat, lon float64, ok bool) {
+	if len(grid) < 4 {
+		return 0, 0, false
+	}
+	minLat, maxLat, minLon, maxLon, err := GridsquareBoundingBox(grid)
+	if err != nil {
+		return 0, 0, false
+	}
+	return (minLat + maxLat) / 2, (minLon + maxLon) / 2, true
+}
+
+// usCQZone handles the contiguous states: zone 3 is Washington, Oregon,
+// California, Idaho, Nevada, Utah, and Arizona; zone 5 is New England, the
+// mid-Atlantic states, West Virginia, South Carolina, Georgia, and Florida;
+// the rest are zone 4.  North Carolina is zone 4 even though it's on the coast.
+func usCQZone(lat, lon float64) int {
+	// Mexico is zone 6
+	switch {
+	case lon < -114.7 && lat < 32.5,
+		lon < -106.5 && lat < 31.3,
+		lon >= -106.5 && lon < -97.2 && lat < 31.8-(lon+106.5)*0.62:
+		return 0
+	}
+	// Idaho and Utah's eastern borders, then Arizona's
+	var west float64
+	switch {
+	case lat >= 47.5:
+		west = -116.05
+	case lat >= 45.5:
+		west = -115
+	case lat >= 44.5:
+		west = -113
+	case lat >= 41:
+		west = -111.05
+	default:
+		west = -109.05
+	}
+	if lon < west {
+		return 3
+	}
+	switch {
+	case lat >= 39.72: // Pennsylvania and New York
+		if lon > -80.52 {
+			return 5
+		}
+	case lat >= 39: // West Virginia along the Ohio River
+		if lon > -81.4 {
+			return 5
+		}
+	case lat >= 38:
+		if lon > -82.4 {
+			return 5
+		}
+	case lat >= 36.55: // West Virginia and Virginia
+		if lon > -83 {
+			return 5
+		}
+	case lat >= 35: // North Carolina and Tennessee
+	case lon >= -79.7: // South Carolina coast
+		if lat < 33.85+(lon+78.55)*-0.83 {
+			return 5
+		}
+	case lon >= -81: // South Carolina
+		if lat < 34.8+(lon+79.7)*-0.27 {
+			return 5
+		}
+	case lon >= -85.3: // South Carolina and Georgia
+		return 5
+	case lat < 31 && lon > -87.6: // Florida panhandle
+		return 5
+	}
+	return 4
+}
+
+// canadaCQZone handles British Columbia (3), Alberta through Ontario (4), the
+// Maritimes and southern Québec (5), Newfoundland (5), and Yukon (1).
+// Parts of the box south of 49° N are in the United States, which returns 0
+// west of Ontario and zones which match the neighboring states further east.
+func canadaCQZone(lat, lon float64) int {
+	if lat >= 60 {
+		// Yukon's eastern border runs from 124° W at 60° N to 136.5° W on the
+		// Beaufort Sea; Northwest Territories and Nunavut span zones 1, 2, and 4
+		if lon < -124-(lat-60)*1.3 {
+			return 1
+		}
+		return 0
+	}
+	if lat < 49 && lon < -95.15 {
+		return 0
+	}
+	// British Columbia and Alberta's border follows the Rocky Mountains
+	var bc float64
+	switch {
+	case lat >= 53.5:
+		bc = -120
+	case lat >= 52:
+		bc = -118.5
+	case lat >= 50.5:
+		bc = -116.5
+	default:
+		bc = -114.6
+	}
+	if lon < bc {
+		if lat > 54.5 && lon < -130 {
+			return 0 // Alaska panhandle
+		}
+		return 3
+	}
+	// Ontario and Québec's border follows the Ottawa River
+	var on float64
+	switch {
+	case lat >= 46.3:
+		on = -79.5
+	case lat >= 45.6:
+		on = -77.5
+	default:
+		on = -74.5
+	}
+	if lon < on {
+		// Pennsylvania and New York south of the Great Lakes and the St.
+		// Lawrence River
+		if lon > -80.52 {
+			var south float64
+			switch {
+			case lon < -79.76:
+				south = 42.3
+			case lon < -79:
+				south = 42.9
+			case lon < -76.3:
+				south = 43.6
+			default:
+				south = 44.3
+			}
+			if lat < south {
+				return 5
+			}
+		}
+		return 4
+	}
+	switch {
+	case lat < 48: // southern Québec and the Maritimes
+		return 5
+	case lat < 51.7 && lon > -59.5: // island of Newfoundland
+		return 5
+	}
+	return 0
+}
+
+// australiaCQZone returns 29 for Western Australia and Northern Territory and
+// 30 for the other states.
+func australiaCQZone(lat, lon float64) int {
+	if lon < 129 || (lon < 138 && lat > -26) {
+		return 29
+	}
+	return 30
+}
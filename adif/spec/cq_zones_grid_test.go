@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestCQZoneForGrid(t *testing.T) {
+	tests := []struct {
+		grid string
+		want int
+	}{
+		{grid: "CM87", want: 3},     // San Francisco
+		{grid: "DN13vo", want: 3},   // Boise
+		{grid: "DM79", want: 4},     // Denver
+		{grid: "EM95", want: 4},     // Charlotte, North Carolina
+		{grid: "FM14", want: 4},     // Wilmington, North Carolina
+		{grid: "EM94", want: 5},     // Columbia, South Carolina
+		{grid: "EM73", want: 5},     // Atlanta
+		{grid: "EL95", want: 5},     // Miami
+		{grid: "EM50", want: 4},     // Mobile, Alabama
+		{grid: "EN91", want: 4},     // Cleveland
+		{grid: "FN00", want: 5},     // Pittsburgh
+		{grid: "fn31pr", want: 5},   // Hartford
+		{grid: "FN02", want: 5},     // Buffalo
+		{grid: "CN89", want: 3},     // Vancouver
+		{grid: "DO21", want: 4},     // Calgary
+		{grid: "FN35", want: 5},     // Montréal
+		{grid: "FN84", want: 5},     // Halifax
+		{grid: "GN37", want: 5},     // St. John's
+		{grid: "CP20", want: 1},     // Whitehorse
+		{grid: "OF78", want: 29},    // Perth
+		{grid: "PH57", want: 29},    // Darwin
+		{grid: "QF56", want: 30},    // Sydney
+		{grid: "QE37", want: 30},    // Hobart
+		{grid: "FN03hp", want: 0},   // Toronto, across Lake Ontario from New York
+		{grid: "FP53", want: 0},     // Iqaluit, Nunavut
+		{grid: "DL49", want: 0},     // Hermosillo, Mexico
+		{grid: "PM95", want: 0},     // Tokyo
+		{grid: "FN", want: 0},       // too short
+		{grid: "FN3", want: 0},      // odd length
+		{grid: "ZZ99", want: 0},     // invalid
+		{grid: "", want: 0},         // empty
+		{grid: "FN31pr12", want: 5}, // extended square
+	}
+	for _, tc := range tests {
+		if got := CQZoneForGrid(tc.grid); got != tc.want {
+			t.Errorf("CQZoneForGrid(%q) got %d, want %d", tc.grid, got, tc.want)
+		}
+	}
+}
+
+func TestCountryCQZoneForGrid(t *testing.T) {
+	tests := []struct {
+		c    CountryEnum
+		grid string
+		want int
+	}{
+		{c: CountryCanada, grid: "FN03hp", want: 4},                // Toronto
+		{c: CountryUnitedStatesOfAmerica, grid: "FN02nv", want: 5}, // Buffalo
+		{c: CountryCanada, grid: "FN25", want: 4},                  // Ottawa
+		{c: CountryUnitedStatesOfAmerica, grid: "CN87", want: 3},   // Seattle
+		{c: CountryCanada, grid: "CN87", want: 0},                  // Seattle
+		{c: CountryUnitedStatesOfAmerica, grid: "DN48", want: 4},   // Kalispell, Montana
+		{c: CountryCanada, grid: "FO66", want: 0},                  // northern Québec
+		{c: CountryAustralia, grid: "PG66", want: 29},              // Alice Springs
+		{c: CountryAustralia, grid: "PF95", want: 30},              // Adelaide
+		{c: CountryUnitedStatesOfAmerica, grid: "QF56", want: 0},   // Sydney
+		{c: CountryJapan, grid: "PM95", want: 0},
+	}
+	for _, tc := range tests {
+		if got := tc.c.CQZoneForGrid(tc.grid); got != tc.want {
+			t.Errorf("%s.CQZoneForGrid(%q) got %d, want %d", tc.c.EntityName, tc.grid, got, tc.want)
+		}
+	}
+}
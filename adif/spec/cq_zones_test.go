@@ -14,7 +14,11 @@
 
 package spec
 
-import "testing"
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
 
 func TestAllCountriesCQZone(t *testing.T) {
 	var active, inactive []CountryEnum
@@ -45,6 +49,9 @@ func TestAllCountriesCQZone(t *testing.T) {
 				if len(CQZoneFor(c.EntityCode)) == 0 {
 					t.Errorf("CQZoneFor(%q) is missing", c.EntityCode)
 				}
+				if got, want := c.CQZones(), CQZoneFor(c.EntityName); !slices.Equal(got, want) {
+					t.Errorf("%s.CQZones() got %v, want %v", c.EntityName, got, want)
+				}
 			}
 		})
 	}
@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "strings"
+
+// LatLonBox is a region bounded by latitude and longitude in decimal degrees.
+// If MinLon is greater than MaxLon the box crosses the 180° meridian.
+type LatLonBox struct {
+	MinLat, MaxLat, MinLon, MaxLon float64
+}
+
+// Contains returns true if lat, lon is in the box, including the edges.
+func (b LatLonBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return lon >= b.MinLon && lon <= b.MaxLon
+	}
+	return lon >= b.MinLon || lon <= b.MaxLon
+}
+
+// Intersects returns true if the boxes overlap.  o must not cross the 180°
+// meridian.
+func (b LatLonBox) Intersects(o LatLonBox) bool {
+	if o.MaxLat < b.MinLat || o.MinLat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return o.MaxLon >= b.MinLon && o.MinLon <= b.MaxLon
+	}
+	return o.MaxLon >= b.MinLon || o.MinLon <= b.MaxLon
+}
+
+// DXCCBounds returns an approximate bounding box for a DXCC entity code or
+// country name.  ok is false if the entity is not known.  Only large and
+// frequently-contacted entities are included; boxes were drawn around the
+// mainland and nearby islands, excluding islands which are separate DXCC
+// entities (e.g. Australia doesn't include Lord Howe Island).  Boxes are
+// rough, so a location slightly outside the box may still be in the entity.
+func DXCCBounds(s string) (box LatLonBox, ok bool) {
+	box, ok = dxccBounds[strings.ToUpper(s)]
+	return
+}
+
+var dxccBounds = make(map[string]LatLonBox)
+
+func init() {
+	for _, b := range []struct {
+		c   CountryEnum
+		box LatLonBox
+	}{
+		{CountryAlaska, LatLonBox{MinLat: 51, MaxLat: 72, MinLon: 172, MaxLon: -129}},
+		{CountryArgentina, LatLonBox{MinLat: -55.1, MaxLat: -21.8, MinLon: -73.6, MaxLon: -53.6}},
+		{CountryAsiaticRussia, LatLonBox{MinLat: 41, MaxLat: 82, MinLon: 55, MaxLon: -169}},
+		{CountryAustralia, LatLonBox{MinLat: -43.7, MaxLat: -9.2, MinLon: 112.9, MaxLon: 153.7}},
+		{CountryBrazil, LatLonBox{MinLat: -33.8, MaxLat: 5.3, MinLon: -74, MaxLon: -34.7}},
+		{CountryCanada, LatLonBox{MinLat: 41.6, MaxLat: 83.2, MinLon: -141.1, MaxLon: -52.6}},
+		{CountryChile, LatLonBox{MinLat: -56, MaxLat: -17.5, MinLon: -75.7, MaxLon: -66.4}},
+		{CountryChina, LatLonBox{MinLat: 18.1, MaxLat: 53.6, MinLon: 73.5, MaxLon: 134.8}},
+		{CountryEngland, LatLonBox{MinLat: 49.8, MaxLat: 55.8, MinLon: -6.5, MaxLon: 1.8}},
+		{CountryEuropeanRussia, LatLonBox{MinLat: 41.2, MaxLat: 77, MinLon: 27.3, MaxLon: 69}},
+		{CountryFederalRepublicOfGermany, LatLonBox{MinLat: 47.3, MaxLat: 55.1, MinLon: 5.9, MaxLon: 15.1}},
+		{CountryFinland, LatLonBox{MinLat: 59.7, MaxLat: 70.1, MinLon: 20.5, MaxLon: 31.6}},
+		{CountryFrance, LatLonBox{MinLat: 42.3, MaxLat: 51.1, MinLon: -5.2, MaxLon: 8.3}},
+		{CountryHawaii, LatLonBox{MinLat: 18.9, MaxLat: 22.3, MinLon: -160.3, MaxLon: -154.8}},
+		{CountryIndia, LatLonBox{MinLat: 6.7, MaxLat: 37.1, MinLon: 68.1, MaxLon: 97.4}},
+		{CountryItaly, LatLonBox{MinLat: 35.4, MaxLat: 47.1, MinLon: 6.6, MaxLon: 18.6}},
+		{CountryJapan, LatLonBox{MinLat: 24, MaxLat: 45.6, MinLon: 122.9, MaxLon: 146}},
+		{CountryMexico, LatLonBox{MinLat: 14.5, MaxLat: 32.8, MinLon: -118.5, MaxLon: -86.7}},
+		{CountryNewZealand, LatLonBox{MinLat: -47.4, MaxLat: -34.3, MinLon: 166.4, MaxLon: 178.6}},
+		{CountryNorway, LatLonBox{MinLat: 57.9, MaxLat: 71.2, MinLon: 4.6, MaxLon: 31.1}},
+		{CountryRepublicOfSouthAfrica, LatLonBox{MinLat: -34.9, MaxLat: -22.1, MinLon: 16.4, MaxLon: 32.9}},
+		{CountrySpain, LatLonBox{MinLat: 36, MaxLat: 43.8, MinLon: -9.3, MaxLon: 3.4}},
+		{CountrySweden, LatLonBox{MinLat: 55.3, MaxLat: 69.1, MinLon: 10.9, MaxLon: 24.2}},
+		{CountryUnitedStatesOfAmerica, LatLonBox{MinLat: 24.5, MaxLat: 49.4, MinLon: -124.8, MaxLon: -66.9}},
+	} {
+		dxccBounds[b.c.EntityCode] = b.box
+		dxccBounds[b.c.EntityName] = b.box
+	}
+}
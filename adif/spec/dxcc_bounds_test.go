@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestDXCCBoundsContains(t *testing.T) {
+	tests := []struct {
+		dxcc     string
+		lat, lon float64
+		want     bool
+	}{
+		{dxcc: CountryUnitedStatesOfAmerica.EntityCode, lat: 41.7, lon: -72.7, want: true}, // Newington, CT
+		{dxcc: "united states of america", lat: 41.7, lon: -72.7, want: true},
+		{dxcc: CountryUnitedStatesOfAmerica.EntityCode, lat: -33.9, lon: 151.2, want: false},
+		{dxcc: CountryAustralia.EntityCode, lat: -33.9, lon: 151.2, want: true}, // Sydney
+		{dxcc: CountryJapan.EntityName, lat: 35.7, lon: 139.7, want: true},      // Tokyo
+		{dxcc: CountryEngland.EntityCode, lat: 51.5, lon: -0.1, want: true},     // London
+		{dxcc: CountryEngland.EntityCode, lat: 48.9, lon: 2.4, want: false},     // Paris
+		{dxcc: CountryAlaska.EntityCode, lat: 61.2, lon: -149.9, want: true},    // Anchorage
+		{dxcc: CountryAlaska.EntityCode, lat: 52.9, lon: 173.2, want: true},     // Attu Island
+		{dxcc: CountryAlaska.EntityCode, lat: 61.2, lon: 100, want: false},
+		{dxcc: CountryAsiaticRussia.EntityCode, lat: 64.7, lon: -177.5, want: true}, // Anadyr region
+		{dxcc: CountryAsiaticRussia.EntityCode, lat: 55, lon: 83, want: true},       // Novosibirsk
+		{dxcc: CountryAsiaticRussia.EntityCode, lat: 55.8, lon: 37.6, want: false},  // Moscow
+		{dxcc: CountryEuropeanRussia.EntityCode, lat: 55.8, lon: 37.6, want: true},
+	}
+	for _, tc := range tests {
+		box, ok := DXCCBounds(tc.dxcc)
+		if !ok {
+			t.Errorf("DXCCBounds(%q) not found", tc.dxcc)
+			continue
+		}
+		if got := box.Contains(tc.lat, tc.lon); got != tc.want {
+			t.Errorf("DXCCBounds(%q) = %v Contains(%f, %f) got %v, want %v", tc.dxcc, box, tc.lat, tc.lon, got, tc.want)
+		}
+	}
+	if box, ok := DXCCBounds(CountryLordHoweIsland.EntityCode); ok {
+		t.Errorf("DXCCBounds(%q) got %v, want not found", CountryLordHoweIsland.EntityCode, box)
+	}
+}
+
+func TestLatLonBoxIntersects(t *testing.T) {
+	usa := LatLonBox{MinLat: 24.5, MaxLat: 49.4, MinLon: -124.8, MaxLon: -66.9}
+	alaska := LatLonBox{MinLat: 51, MaxLat: 72, MinLon: 172, MaxLon: -129}
+	tests := []struct {
+		a, b LatLonBox
+		want bool
+	}{
+		{a: usa, b: LatLonBox{MinLat: 41, MaxLat: 42, MinLon: -74, MaxLon: -72}, want: true},
+		{a: usa, b: LatLonBox{MinLat: 49, MaxLat: 50, MinLon: -68, MaxLon: -66}, want: true},
+		{a: usa, b: LatLonBox{MinLat: 50, MaxLat: 51, MinLon: -68, MaxLon: -66}, want: false},
+		{a: usa, b: LatLonBox{MinLat: 41, MaxLat: 42, MinLon: -66, MaxLon: -64}, want: false},
+		{a: alaska, b: LatLonBox{MinLat: 52, MaxLat: 53, MinLon: 172, MaxLon: 174}, want: true},
+		{a: alaska, b: LatLonBox{MinLat: 61, MaxLat: 62, MinLon: -150, MaxLon: -148}, want: true},
+		{a: alaska, b: LatLonBox{MinLat: 61, MaxLat: 62, MinLon: -120, MaxLon: -118}, want: false},
+		{a: alaska, b: LatLonBox{MinLat: 61, MaxLat: 62, MinLon: 100, MaxLon: 102}, want: false},
+	}
+	for _, tc := range tests {
+		if got := tc.a.Intersects(tc.b); got != tc.want {
+			t.Errorf("%v.Intersects(%v) got %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"time"
+)
+
+// ValidFrom returns the first date contacts count for the DXCC entity, e.g.
+// January 1st, 1993 for CountryCzechRepublic.  See DXCCValidFrom for details.
+func (e CountryEnum) ValidFrom() time.Time { return DXCCValidFrom(e.EntityCode) }
+
+// DXCCValidFrom returns the date a DXCC entity code or country name was added
+// to the DXCC list, i.e. the first date a contact with a station in that
+// entity counts for it.  Earlier contacts in the same place count for the
+// entity which previously covered that area, e.g. Serbia for Kosovo.  Returns
+// the zero time if the entity has been on the list since DXCC began after World
+// War II or if the date is not known.  The ADIF specification marks deleted
+// entities but does not provide dates, so this list was compiled by hand from
+// ARRL DXCC announcements.
+//
+// TODO Add dates for other entities added since 1945, e.g. Palestine,
+// Timor-Leste, Ducie Island, and Swains Island.
+func DXCCValidFrom(s string) time.Time {
+	var d string
+	switch strings.ToUpper(s) {
+	default:
+		return time.Time{}
+	case CountryCroatia.EntityName, CountryCroatia.EntityCode,
+		CountrySlovenia.EntityName, CountrySlovenia.EntityCode:
+		d = "19910626"
+	case CountryNorthMacedoniaRepublicOf.EntityName, CountryNorthMacedoniaRepublicOf.EntityCode:
+		d = "19910908"
+	case CountryBosniaHerzegovina.EntityName, CountryBosniaHerzegovina.EntityCode:
+		d = "19911015"
+	case CountryCzechRepublic.EntityName, CountryCzechRepublic.EntityCode,
+		CountrySlovakRepublic.EntityName, CountrySlovakRepublic.EntityCode:
+		d = "19930101"
+	case CountryMontenegro.EntityName, CountryMontenegro.EntityCode:
+		d = "20060628"
+	case CountrySaintBarthelemy.EntityName, CountrySaintBarthelemy.EntityCode:
+		d = "20071214"
+	case CountryCuracao.EntityName, CountryCuracao.EntityCode,
+		CountrySintMaarten.EntityName, CountrySintMaarten.EntityCode,
+		CountrySabaStEustatius.EntityName, CountrySabaStEustatius.EntityCode,
+		CountryBonaire.EntityName, CountryBonaire.EntityCode:
+		d = "20101010"
+	case CountrySouthSudanRepublicOf.EntityName, CountrySouthSudanRepublicOf.EntityCode:
+		d = "20110714"
+	case CountryRepublicOfKosovo.EntityName, CountryRepublicOfKosovo.EntityCode:
+		d = "20180121"
+	}
+	t, _ := time.Parse("20060102", d)
+	return t
+}
@@ -72,6 +72,19 @@ func (e Enumeration) ScopeProperty() string {
 
 var Enumerations = make(map[string]Enumeration)
 
+// PrimaryAdminSubdivisionFor returns all Primary_Administrative_Subdivision
+// values (states, provinces, etc.) for a DXCC entity code, including deleted
+// and import-only values.  Returns an empty slice if the ADIF specification
+// does not define subdivisions for the entity.
+func PrimaryAdminSubdivisionFor(dxcc string) []PrimaryAdministrativeSubdivisionEnum {
+	vals := PrimaryAdministrativeSubdivisionEnumeration.ScopeValues(strings.TrimSpace(dxcc))
+	res := make([]PrimaryAdministrativeSubdivisionEnum, len(vals))
+	for i, v := range vals {
+		res[i] = v.(PrimaryAdministrativeSubdivisionEnum)
+	}
+	return res
+}
+
 func EnumerationNamed(s string) (e Enumeration, ok bool) {
 	// TODO generate this with a switch
 	e, ok = Enumerations[s]
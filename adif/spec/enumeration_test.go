@@ -14,7 +14,10 @@
 
 package spec
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestEnumerationDeclared(t *testing.T) {
 	if len(Enumerations) == 0 {
@@ -47,3 +50,35 @@ func TestEnumerationValues(t *testing.T) {
 		}
 	}
 }
+
+func TestPrimaryAdminSubdivisionFor(t *testing.T) {
+	tests := []struct {
+		dxcc     string
+		wantLen  int
+		wantCode string
+	}{
+		{dxcc: "1", wantLen: 13, wantCode: "QC"},
+		{dxcc: "291", wantLen: 49, wantCode: "DC"}, // Alaska and Hawaii are separate entities
+		{dxcc: " 6 ", wantLen: 1, wantCode: "AK"},
+		{dxcc: "0", wantLen: 0},
+		{dxcc: "", wantLen: 0},
+	}
+	for _, tc := range tests {
+		got := PrimaryAdminSubdivisionFor(tc.dxcc)
+		if len(got) != tc.wantLen {
+			t.Errorf("PrimaryAdminSubdivisionFor(%q) got %d values, want %d", tc.dxcc, len(got), tc.wantLen)
+		}
+		found := tc.wantCode == ""
+		for _, s := range got {
+			if s.DxccEntityCode != strings.TrimSpace(tc.dxcc) {
+				t.Errorf("PrimaryAdminSubdivisionFor(%q) got %s with DXCC %s", tc.dxcc, s.Code, s.DxccEntityCode)
+			}
+			if s.Code == tc.wantCode {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("PrimaryAdminSubdivisionFor(%q) does not contain %s", tc.dxcc, tc.wantCode)
+		}
+	}
+}
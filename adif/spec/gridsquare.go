@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maidenheadSizes is the number of divisions of each character pair in a
+// Maidenhead locator: field (A-R), square (0-9), subsquare (A-X), extended
+// square (0-9), and two more pairs for GRIDSQUARE_EXT.
+var maidenheadSizes = []int{18, 10, 24, 10, 24, 10}
+
+// GridsquareBoundingBox returns the latitude and longitude bounds of a
+// Maidenhead locator with 2 to 12 characters (GRIDSQUARE plus
+// GRIDSQUARE_EXT), e.g. FN31 covers 41° to 42° north and 72° to 74° west.
+// Letters are case-insensitive.  The minimum bounds are part of the grid
+// square; the maximum bounds are part of the adjacent square.
+func GridsquareBoundingBox(gs string) (minLat, maxLat, minLon, maxLon float64, err error) {
+	if gs == "" {
+		err = errors.New("empty grid square")
+		return
+	}
+	if len(gs)%2 != 0 || len(gs) > len(maidenheadSizes)*2 {
+		err = fmt.Errorf("invalid grid square length %q", gs)
+		return
+	}
+	up := strings.ToUpper(gs)
+	lonscale, latscale := 360.0, 180.0
+	for i := 0; i < len(up)/2; i++ {
+		size := maidenheadSizes[i]
+		lonc, latc := up[i*2], up[i*2+1]
+		first := byte('A')
+		if size == 10 {
+			first = '0'
+		}
+		if lonc < first || latc < first || int(lonc-first) >= size || int(latc-first) >= size {
+			err = fmt.Errorf("invalid grid square %q", gs)
+			return
+		}
+		lonscale /= float64(size)
+		latscale /= float64(size)
+		minLon += lonscale * float64(lonc-first)
+		minLat += latscale * float64(latc-first)
+	}
+	minLon -= 180
+	minLat -= 90
+	return minLat, minLat + latscale, minLon, minLon + lonscale, nil
+}
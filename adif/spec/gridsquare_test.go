@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGridsquareBoundingBox(t *testing.T) {
+	tests := []struct {
+		gs                             string
+		minLat, maxLat, minLon, maxLon float64
+	}{
+		{gs: "FN", minLat: 40, maxLat: 50, minLon: -80, maxLon: -60},
+		{gs: "FN31", minLat: 41, maxLat: 42, minLon: -74, maxLon: -72},
+		{gs: "fn31pr", minLat: 41.708333, maxLat: 41.75, minLon: -72.75, maxLon: -72.666667},
+		{gs: "FN31pr46", minLat: 41.733333, maxLat: 41.737500, minLon: -72.716667, maxLon: -72.708333},
+		{gs: "FN31pr46ab09", minLat: 41.733663, maxLat: 41.733681, minLon: -72.716667, maxLon: -72.716632},
+		{gs: "AA00", minLat: -90, maxLat: -89, minLon: -180, maxLon: -178},
+		{gs: "RR99xx99", minLat: 89.995833, maxLat: 90, minLon: 179.991667, maxLon: 180},
+		{gs: "JJ00aa", minLat: 0, maxLat: 0.041667, minLon: 0, maxLon: 0.083333},
+		{gs: "QF56", minLat: -34, maxLat: -33, minLon: 150, maxLon: 152},
+	}
+	const epsilon = 0.000001
+	for _, tc := range tests {
+		minLat, maxLat, minLon, maxLon, err := GridsquareBoundingBox(tc.gs)
+		if err != nil {
+			t.Errorf("GridsquareBoundingBox(%q) got error %v", tc.gs, err)
+			continue
+		}
+		got := []float64{minLat, maxLat, minLon, maxLon}
+		want := []float64{tc.minLat, tc.maxLat, tc.minLon, tc.maxLon}
+		for i := range got {
+			if math.Abs(got[i]-want[i]) > epsilon {
+				t.Errorf("GridsquareBoundingBox(%q) got %v, want %v", tc.gs, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestGridsquareBoundingBoxErrors(t *testing.T) {
+	for _, gs := range []string{"", "F", "FN3", "SN31", "FNAA", "FN31yy", "FN31pr4", "FN31pr46aa1", "FN31pr46aa99yy", "FN31 pr"} {
+		if minLat, maxLat, minLon, maxLon, err := GridsquareBoundingBox(gs); err == nil {
+			t.Errorf("GridsquareBoundingBox(%q) want error, got %v", gs, []float64{minLat, maxLat, minLon, maxLon})
+		}
+	}
+}
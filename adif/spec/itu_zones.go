@@ -16,6 +16,10 @@ package spec
 
 import "strings"
 
+// ITUZones returns the ITU Zone numbers for the DXCC entity, e.g. []int{45}
+// for CountryJapan.  See ITUZoneFor for details.
+func (e CountryEnum) ITUZones() []int { return ITUZoneFor(e.EntityCode) }
+
 // ITUZoneFor returns a list of ITU Zone numbers for a DXCC entity code or
 // country name.  Returns an empty slice if the entity code or country is not
 // known.  For some countries which span multiple CQ Zones, the CqZone property
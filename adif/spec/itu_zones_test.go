@@ -14,7 +14,11 @@
 
 package spec
 
-import "testing"
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
 
 func TestAllCountriesITUZone(t *testing.T) {
 	var active, inactive []CountryEnum
@@ -45,6 +49,9 @@ func TestAllCountriesITUZone(t *testing.T) {
 				if len(ITUZoneFor(c.EntityCode)) == 0 {
 					t.Errorf("ITUZoneFor(%q) is missing", c.EntityCode)
 				}
+				if got, want := c.ITUZones(), ITUZoneFor(c.EntityName); !slices.Equal(got, want) {
+					t.Errorf("%s.ITUZones() got %v, want %v", c.EntityName, got, want)
+				}
 			}
 		})
 	}
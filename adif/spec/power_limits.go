@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "strings"
+
+// fccPowerLimits are maximum transmitter power levels in watts for United
+// States amateur stations from 47 CFR 97.313 and 97.303.  The 2200m and 630m
+// limits are EIRP and the 60m limit is ERP, so these are only compared to
+// transmitter output as an approximation.  Other bands allow 1500 watts PEP.
+var fccPowerLimits = map[string]float64{
+	"2190m": 1,
+	"630m":  5,
+	"60m":   100,
+	"30m":   200,
+}
+
+// fccEntities are DXCC entities where amateur stations are licensed by the
+// United States Federal Communications Commission.
+var fccEntities = []CountryEnum{
+	CountryUnitedStatesOfAmerica, CountryAlaska, CountryHawaii,
+	CountryPuertoRico, CountryVirginIslands, CountryGuam,
+	CountryAmericanSamoa, CountryMarianaIslands,
+}
+
+// PowerLimitFor returns the maximum transmitter power in watts for amateur
+// stations in a DXCC entity (code or country name) on a band (a Band
+// enumeration value, e.g. 60m).  Returns false if the entity's power limits
+// are not known; currently only entities regulated by the United States FCC
+// have a table.
+func PowerLimitFor(dxcc, band string) (float64, bool) {
+	dxcc = strings.ToUpper(strings.TrimSpace(dxcc))
+	for _, c := range fccEntities {
+		if dxcc == c.EntityCode || dxcc == c.EntityName {
+			if w, ok := fccPowerLimits[strings.ToLower(band)]; ok {
+				return w, true
+			}
+			return 1500, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestPowerLimitFor(t *testing.T) {
+	tests := []struct {
+		dxcc, band string
+		want       float64
+		wantOk     bool
+	}{
+		{dxcc: "291", band: "20m", want: 1500, wantOk: true},
+		{dxcc: "291", band: "60m", want: 100, wantOk: true},
+		{dxcc: "291", band: "30M", want: 200, wantOk: true},
+		{dxcc: "291", band: "630m", want: 5, wantOk: true},
+		{dxcc: "291", band: "2190m", want: 1, wantOk: true},
+		{dxcc: "united states of america", band: "60m", want: 100, wantOk: true},
+		{dxcc: "110", band: "60m", want: 100, wantOk: true},
+		{dxcc: "6", band: "2m", want: 1500, wantOk: true},
+		{dxcc: "1", band: "60m", wantOk: false},
+		{dxcc: "", band: "60m", wantOk: false},
+	}
+	for _, tc := range tests {
+		got, ok := PowerLimitFor(tc.dxcc, tc.band)
+		if ok != tc.wantOk || got != tc.want {
+			t.Errorf("PowerLimitFor(%q, %q) got (%v, %v), want (%v, %v)", tc.dxcc, tc.band, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package spec
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -25,6 +26,8 @@ import (
 
 	"golang.org/x/exp/constraints"
 	"golang.org/x/exp/slices"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
 type Validity int
@@ -75,12 +78,15 @@ var (
 	sotaPat = regexp.MustCompile("^(?i)[A-Z0-9]{1,4}/[A-Z]{2}-[0-9]{3}$")
 	iotaPat = regexp.MustCompile("^(?i)(AF|AN|AS|EU|NA|OC|SA)-[0-9]{3}$")
 	wwffPat = regexp.MustCompile("^(?i)[A-Z0-9]{1,4}FF-[0-9]{4}$")
+	// Contest_ID enumeration values are mostly upper case words separated by hyphens
+	contestIDPat = regexp.MustCompile("^(?i)[A-Z0-9-]+$")
 )
 
 type ValidationContext struct {
 	UnknownEnumValueWarning bool      // if true, values not in an enumeration are a warning, otherwise an error
 	Now                     time.Time // comparison point for times-in-the-future checks
 	FieldValue              func(name string) string
+	Locale                  language.Tag // case folding rules for non-ASCII enumeration values in Intl fields
 }
 
 type FieldValidator func(value string, f Field, ctx ValidationContext) Validation
@@ -92,13 +98,13 @@ var TypeValidators = map[string]FieldValidator{
 	"IntlCharacter":            ValidateIntlCharacter,
 	"Date":                     ValidateDate,
 	"Digit":                    ValidateDigit,
-	"GridSquare":               gridsquareValidator(8),
-	"GridSquareExt":            gridsquareValidator(4),
-	"GridSquareList":           listValidator(gridsquareValidator(8)),
+	"GridSquare":               gridsquareValidator(8, 0),
+	"GridSquareExt":            gridsquareValidator(4, 4),
+	"GridSquareList":           ValidateGridsquareList,
 	"Integer":                  ValidateNumber,
 	"IntlString":               ValidateIntlString,
 	"IntlMultilineString":      ValidateIntlString,
-	"IOTARefNo":                formatValidator("IOTA reference", iotaPat),
+	"IOTARefNo":                ValidateIOTARef,
 	"Location":                 ValidateLocation,
 	"MultilineString":          ValidateString,
 	"Number":                   ValidateNumber,
@@ -108,11 +114,11 @@ var TypeValidators = map[string]FieldValidator{
 	"SOTARef":                  formatValidator("SOTA reference", sotaPat),
 	"String":                   ValidateString,
 	"Time":                     ValidateTime,
-	"WWFFRef":                  formatValidator("WWFF reference", wwffPat),
+	"WWFFRef":                  ValidateWWFFRef,
 	"AwardList":                ValidateNoop, // TODO
 	"CreditList":               ValidateNoop, // TODO
 	"SecondarySubdivisionList": ValidateNoop, // TODO
-	"SponsoredAwardList":       ValidateNoop, // TODO
+	"SponsoredAwardList":       listValidator(ValidateSponsoredAward),
 }
 
 func ValidateNoop(value string, f Field, ctx ValidationContext) Validation { return valid() }
@@ -146,11 +152,125 @@ func ValidateString(val string, f Field, ctx ValidationContext) Validation {
 			return errorf("%s not a printable ASCII string %q", f.Name, val)
 		}
 	}
+	if f.Name == ContestIdField.Name && val != "" {
+		ctx.UnknownEnumValueWarning = true
+		v := ValidateEnumeration(val, f, ctx)
+		if v.Validity != Valid {
+			if msg := contestIDFormatProblem(val); msg != "" {
+				return warningf("%s unknown contest %q %s", f.Name, val, msg)
+			}
+		}
+		return v
+	}
+	if f.Name == CountryField.Name || f.Name == MyCountryField.Name {
+		if v := validateCountryName(val, f, ctx); v.Validity != Valid {
+			return v
+		}
+	}
 	if f.EnumName != "" {
 		// CONTEST_ID and SUBMODE are string fiields with an enumeration; mismatches are warnings not errors
 		ctx.UnknownEnumValueWarning = true
 		return ValidateEnumeration(val, f, ctx)
 	}
+	if f.Name == RstSentField.Name || f.Name == RstRcvdField.Name {
+		return validateRSTDigits(val, f, ctx)
+	}
+	return valid()
+}
+
+// validateCountryName warns if COUNTRY (or MY_COUNTRY) is not the entity name
+// of DXCC (or MY_DXCC), ignoring case.  A name which is the entity name with
+// words missing, like "United States" for "UNITED STATES OF AMERICA", gets a
+// more specific message.
+func validateCountryName(val string, f Field, ctx ValidationContext) Validation {
+	dxccField := DxccField.Name
+	if f.Name == MyCountryField.Name {
+		dxccField = MyDxccField.Name
+	}
+	if val == "" || ctx.FieldValue == nil {
+		return valid()
+	}
+	code := strings.TrimSpace(ctx.FieldValue(dxccField))
+	if code == "" || code == "0" {
+		return valid()
+	}
+	var names []string
+	for _, e := range DxccEntityCodeEnumeration.Value(code) {
+		n := e.(DxccEntityCodeEnum).EntityName
+		if strings.EqualFold(val, n) {
+			return valid()
+		}
+		names = append(names, n)
+	}
+	if len(names) == 0 {
+		return valid() // unknown DXCC code is reported by that field
+	}
+	for _, e := range CountryEnumeration.Value(val) {
+		if c := e.(CountryEnum); c.EntityCode != code {
+			return warningf("%s %s is DXCC entity %s, not %s %s %s", f.Name, val, c.EntityCode, dxccField, code, strings.Join(names, " or "))
+		}
+	}
+	words := strings.Fields(strings.ToUpper(val))
+	for _, n := range names {
+		if len(words) > 0 && containsWords(strings.Fields(n), words) {
+			return warningf("%s %q is not the full %s %s entity name %s", f.Name, val, dxccField, code, n)
+		}
+	}
+	return warningf("%s %q does not match %s %s entity name %s", f.Name, val, dxccField, code, strings.Join(names, " or "))
+}
+
+// containsWords returns true if all words appear in have, in order.
+func containsWords(have, words []string) bool {
+	i := 0
+	for _, h := range have {
+		if i < len(words) && h == words[i] {
+			i++
+		}
+	}
+	return i == len(words)
+}
+
+// contestIDFormatProblem describes how a CONTEST_ID which isn't in the
+// Contest_ID enumeration differs from the conventions of enumerated values:
+// 2 to 30 upper case letters and digits separated by single hyphens.
+func contestIDFormatProblem(val string) string {
+	switch {
+	case strings.ContainsAny(val, "_ "):
+		return "should use hyphens rather than underscores or spaces"
+	case !contestIDPat.MatchString(val):
+		return "should only have letters, digits, and hyphens"
+	case strings.ToUpper(val) != val:
+		return "should be upper case"
+	case len(val) < 2 || len(val) > 30:
+		return "should be 2 to 30 characters long"
+	case strings.HasPrefix(val, "-") || strings.HasSuffix(val, "-"):
+		return "should not start or end with a hyphen"
+	case strings.Contains(val, "--"):
+		return "should not have consecutive hyphens"
+	default:
+		return ""
+	}
+}
+
+// phoneModes report readability and strength, without a tone digit.
+var phoneModes = map[string]bool{"SSB": true, "AM": true, "FM": true, "DIGITALVOICE": true}
+
+// validateRSTDigits warns if a numeric signal report has the wrong number of
+// digits for MODE: CW reports include tone, like 599, while phone reports are
+// just readability and strength, like 59.  Digital modes conventionally use
+// three digits, but two are common enough that neither is a warning, and
+// reports like -12 or 5NN aren't checked.
+func validateRSTDigits(val string, f Field, ctx ValidationContext) Validation {
+	if ctx.FieldValue == nil || (len(val) != 2 && len(val) != 3) || !allNumeric.MatchString(val) {
+		return valid()
+	}
+	mode := strings.ToUpper(ctx.FieldValue(ModeField.Name))
+	if mode == "CW" && len(val) == 2 {
+		return warningf("%s %s has 2 digits, CW reports have 3 like 599", f.Name, val)
+	}
+	if phoneModes[mode] && len(val) == 3 {
+		return warningf("%s %s has 3 digits, %s reports have 2 like 59", f.Name, val, mode)
+	}
 	return valid()
 }
 
@@ -169,10 +289,15 @@ func ValidateIntlCharacter(val string, f Field, ctx ValidationContext) Validatio
 }
 
 func ValidateIntlString(val string, f Field, ctx ValidationContext) Validation {
+	// invalid UTF-8 includes encoded surrogate halves, e.g. from CESU-8 or a
+	// UTF-16 string which was split in the middle of a pair
+	if !utf8.ValidString(val) {
+		return errorf("%s invalid Unicode encoding %q", f.Name, val)
+	}
 	for _, c := range val {
 		if c == '\n' || c == '\r' {
 			if !strings.Contains(f.Type.Name, "Multiline") {
-				return errorf("%s contains newlines but is not a MultilineIntlString %q", f.Name, val)
+				return errorf("%s contains newlines but is not an IntlMultilineString %q", f.Name, val)
 			}
 		}
 	}
@@ -180,9 +305,25 @@ func ValidateIntlString(val string, f Field, ctx ValidationContext) Validation {
 	if f.EnumScope != "" {
 		return ValidateEnumScope(val, f, ctx)
 	}
+	if f.EnumName != "" && val != "" {
+		return validateIntlEnumeration(val, f, ctx)
+	}
 	return valid()
 }
 
+// validateIntlEnumeration warns if val is not a value of f's enumeration,
+// comparing with the case rules of ctx.Locale.  Values which don't match are
+// not errors because fields like MY_COUNTRY_INTL may be translated.
+func validateIntlEnumeration(val string, f Field, ctx ValidationContext) Validation {
+	e := f.Enum()
+	for _, v := range e.Values {
+		if equalFoldLocale(val, v.String(), ctx.Locale) {
+			return valid()
+		}
+	}
+	return warningf("%s unknown value %q for enumeration %s", f.Name, val, e.Name)
+}
+
 func ValidateDigit(val string, f Field, ctx ValidationContext) Validation {
 	if len(val) != 1 {
 		return errorf("%s not a single digit %q", f.Name, val)
@@ -248,6 +389,12 @@ func ValidateNumber(val string, f Field, ctx ValidationContext) Validation {
 		}
 	}
 
+	if f.Name == FreqField.Name || f.Name == FreqRxField.Name {
+		if _, ok := bandContaining(num); !ok {
+			return warningf("%s %s MHz is not in an amateur radio band", f.Name, val)
+		}
+	}
+
 	iscq := f.Name == CqzField.Name || f.Name == MyCqZoneField.Name
 	isitu := f.Name == ItuzField.Name || f.Name == MyItuZoneField.Name
 	if iscq || isitu {
@@ -281,6 +428,20 @@ func ValidateNumber(val string, f Field, ctx ValidationContext) Validation {
 	return valid()
 }
 
+// bandContaining returns the BAND enumeration value whose frequency range
+// includes freq, in megahertz.
+func bandContaining(freq float64) (BandEnum, bool) {
+	for _, v := range BandEnumeration.Values {
+		b := v.(BandEnum)
+		lo, lerr := strconv.ParseFloat(b.LowerFreqMhz, 64)
+		hi, herr := strconv.ParseFloat(b.UpperFreqMhz, 64)
+		if lerr == nil && herr == nil && lo <= freq && freq <= hi {
+			return b, true
+		}
+	}
+	return BandEnum{}, false
+}
+
 func ValidateDate(val string, f Field, ctx ValidationContext) Validation {
 	if !allNumeric.MatchString(val) {
 		return errorf("%s invalid date %q", f.Name, val)
@@ -295,12 +456,30 @@ func ValidateDate(val string, f Field, ctx ValidationContext) Validation {
 	if d.Year() < 1930 {
 		return errorf("%s year before 1930 %q", f.Name, val)
 	}
+	if ctx.FieldValue != nil {
+		for _, other := range datesNotBefore[f.Name] {
+			ov := ctx.FieldValue(other)
+			if ov == "" {
+				continue
+			}
+			if od, err := time.Parse("20060102", ov); err == nil && d.Before(od) {
+				return errorf("%s value %q is before %s %q", f.Name, val, other, ov)
+			}
+		}
+	}
 	if !ctx.Now.IsZero() && d.After(ctx.Now) {
 		return warningf("%s value %q later than today", f.Name, val)
 	}
 	return valid()
 }
 
+// datesNotBefore maps a date field to fields with dates which must not be
+// later, e.g. a QSL can't be received before it was sent.
+var datesNotBefore = map[string][]string{
+	LotwQslsdateField.Name: {QsoDateField.Name},
+	LotwQslrdateField.Name: {LotwQslsdateField.Name, QsoDateField.Name},
+}
+
 func ValidateTime(val string, f Field, ctx ValidationContext) Validation {
 	if !allNumeric.MatchString(val) {
 		return errorf("%s invalid time %q", f.Name, val)
@@ -344,20 +523,72 @@ func ValidateLocation(val string, f Field, ctx ValidationContext) Validation {
 	if val == "" {
 		return valid()
 	}
-	g := locationPat.FindStringSubmatch(val)
-	if g == nil {
+	_, deg, min, err := parseLocation(val)
+	if err != nil {
 		return errorf("%s invalid location format, make sure to zero-pad %q", f.Name, val)
 	}
-	if deg, err := strconv.ParseInt(g[2], 10, 64); err != nil {
-		return errorf("%s non-numeric degrees in %q", f.Name, val)
-	} else if !between(deg, 0, 180) {
+	if !between(deg, 0, 180) {
 		return errorf("%s degrees out of range in %q", f.Name, val)
 	}
-	if min, err := strconv.ParseFloat(g[3], 10); err != nil {
-		return errorf("%s non-numeric degrees in %q", f.Name, val)
-	} else if !between(min, 0.0, 60.0) {
+	if !between(min, 0.0, 60.0) {
 		return errorf("%s minutes out of range in %q", f.Name, val)
 	}
+	return validateLocationGrid(val, f, ctx)
+}
+
+// locationGridFields maps location fields to the GRIDSQUARE and GRIDSQUARE_EXT
+// fields describing the same station.
+var locationGridFields = map[string][2]string{
+	LatField.Name:   {GridsquareField.Name, GridsquareExtField.Name},
+	LonField.Name:   {GridsquareField.Name, GridsquareExtField.Name},
+	MyLatField.Name: {MyGridsquareField.Name, MyGridsquareExtField.Name},
+	MyLonField.Name: {MyGridsquareField.Name, MyGridsquareExtField.Name},
+}
+
+// validateLocationGrid warns if a latitude or longitude is not in or adjacent
+// to the record's grid square.  The neighboring square is allowed since
+// rounding and low-precision locations can cross a boundary.
+func validateLocationGrid(val string, f Field, ctx ValidationContext) Validation {
+	gf, ok := locationGridFields[strings.ToUpper(f.Name)]
+	if !ok || ctx.FieldValue == nil {
+		return valid()
+	}
+	gs := ctx.FieldValue(gf[0])
+	if gs == "" {
+		return valid()
+	}
+	if len(gs) == 8 {
+		gs += ctx.FieldValue(gf[1])
+	}
+	south, north, west, east, err := GridsquareBoundingBox(gs)
+	if err != nil {
+		return valid()
+	}
+	latSize, lonSize := north-south, east-west
+	dir, d, err := ParseLocation(val)
+	if err != nil {
+		return valid()
+	}
+	lat := strings.HasSuffix(strings.ToUpper(f.Name), "LAT")
+	var near bool
+	switch {
+	case lat && (dir == 'N' || dir == 'S'):
+		near = d >= south-latSize && d <= south+2*latSize
+	case !lat && (dir == 'E' || dir == 'W'):
+		rel := d - west
+		for rel < 0 {
+			rel += 360
+		}
+		for rel >= 360 {
+			rel -= 360
+		}
+		near = rel <= 2*lonSize || rel >= 360-lonSize
+	default:
+		return valid()
+	}
+	if !near {
+		return warningf("%s %q is not in grid square %s=%q", f.Name, val, gf[0], gs)
+	}
 	return valid()
 }
 
@@ -390,16 +621,145 @@ func ValidateEnumeration(val string, f Field, ctx ValidationContext) Validation
 	}
 	if f.Name == ContField.Name {
 		if d := ctx.FieldValue(DxccField.Name); d != "" {
-			if c := ContinentFor(d); !strings.EqualFold(val, c.Abbreviation) {
+			if c := ContinentFor(d); c.Abbreviation != "" && !strings.EqualFold(val, c.Abbreviation) {
 				return warningf("continent %s does not match DXCC %s continent %s", val, d, c.Abbreviation)
 			}
 		}
 		if d := ctx.FieldValue(CountryField.Name); d != "" {
-			if c := ContinentFor(d); !strings.EqualFold(val, c.Abbreviation) {
+			if c := ContinentFor(d); c.Abbreviation != "" && !strings.EqualFold(val, c.Abbreviation) {
 				return warningf("continent %s does not match country %s continent %s", val, d, c.Abbreviation)
 			}
 		}
 	}
+	if f.Name == ArrlSectField.Name || f.Name == MyArrlSectField.Name {
+		dxccField := DxccField.Name
+		if f.Name == MyArrlSectField.Name {
+			dxccField = MyDxccField.Name
+		}
+		if d := strings.TrimSpace(ctx.FieldValue(dxccField)); d != "" {
+			sect := vals[0].(ArrlSectionEnum)
+			if sect.DxccEntityCode != "" && !slices.Contains(strings.Split(sect.DxccEntityCode, ","), d) {
+				return warningf("%s %s (%s) is not a section for %s %s", f.Name, val, sect.SectionName, dxccField, d)
+			}
+		}
+	}
+	if f.Name == BandField.Name || f.Name == BandRxField.Name {
+		// BAND and BAND_RX may differ for crossband contacts, e.g. satellites,
+		// but each frequency should be in its own band
+		freqField := FreqField.Name
+		if f.Name == BandRxField.Name {
+			freqField = FreqRxField.Name
+		}
+		if fv := ctx.FieldValue(freqField); fv != "" {
+			freq, err := strconv.ParseFloat(fv, 64)
+			b := vals[0].(BandEnum)
+			lo, lerr := strconv.ParseFloat(b.LowerFreqMhz, 64)
+			hi, herr := strconv.ParseFloat(b.UpperFreqMhz, 64)
+			if err == nil && lerr == nil && herr == nil && (freq < lo || freq > hi) {
+				return warningf("%s %s MHz is not in %s %s, expected %s to %s MHz", freqField, fv, f.Name, val, b.LowerFreqMhz, b.UpperFreqMhz)
+			}
+		}
+	}
+	if f.Name == DxccField.Name || f.Name == MyDxccField.Name {
+		if from := DXCCValidFrom(val); !from.IsZero() {
+			qd := ctx.FieldValue(QsoDateField.Name)
+			if d, err := time.Parse("20060102", qd); err == nil && d.Before(from) {
+				return errorf("%s %s was not a DXCC entity until %s but %s is %s", f.Name, val, from.Format("2006-01-02"), QsoDateField.Name, qd)
+			}
+		}
+		callField := CallField.Name
+		if f.Name == MyDxccField.Name {
+			callField = StationCallsignField.Name
+		}
+		if call := ctx.FieldValue(callField); call != "" {
+			cs := DXCCFromCallsign(call)
+			if len(cs) > 0 && !slices.ContainsFunc(cs, func(c CountryEnum) bool { return c.EntityCode == val }) {
+				names := make([]string, len(cs))
+				for i, c := range cs {
+					names[i] = c.EntityCode + " " + c.EntityName
+				}
+				return warningf("%s %s does not match %s %s prefix, expected %s", f.Name, val, callField, call, strings.Join(names, " or "))
+			}
+		}
+	}
+	if df, ok := sentDateFields[f.Name]; ok && strings.EqualFold(val, "Y") && ctx.FieldValue != nil {
+		dv := ctx.FieldValue(df)
+		if dv == "" {
+			return warningf("%s is %s but %s is not set", f.Name, val, df)
+		}
+		qv := ctx.FieldValue(QsoDateField.Name)
+		d, derr := time.Parse("20060102", dv)
+		q, qerr := time.Parse("20060102", qv)
+		if derr == nil && qerr == nil && d.After(q.AddDate(1, 0, 0)) {
+			return warningf("%s is %s but %s %s is more than a year after %s %s", f.Name, val, df, dv, QsoDateField.Name, qv)
+		}
+	}
+	if df, ok := rcvdDateFields[f.Name]; ok && strings.EqualFold(val, "Y") && ctx.FieldValue != nil {
+		// paper QSLs are only expected to be dated if they came via the bureau
+		if f.Name != QslRcvdField.Name || strings.EqualFold(ctx.FieldValue(QslRcvdViaField.Name), "B") {
+			if ctx.FieldValue(df) == "" {
+				return warningf("%s is %s but %s is not set", f.Name, val, df)
+			}
+		}
+	}
+	return valid()
+}
+
+// sentDateFields maps upload and QSL sent status fields to the date the QSO
+// was sent.  A "Y" status without a date, or with a date long after the QSO,
+// may be a stale flag from a log which was never actually uploaded.
+var sentDateFields = map[string]string{
+	ClublogQsoUploadStatusField.Name:  ClublogQsoUploadDateField.Name,
+	EqslQslSentField.Name:             EqslQslsdateField.Name,
+	HamlogeuQsoUploadStatusField.Name: HamlogeuQsoUploadDateField.Name,
+	HamqthQsoUploadStatusField.Name:   HamqthQsoUploadDateField.Name,
+	HrdlogQsoUploadStatusField.Name:   HrdlogQsoUploadDateField.Name,
+	LotwQslSentField.Name:             LotwQslsdateField.Name,
+	QrzcomQsoUploadStatusField.Name:   QrzcomQsoUploadDateField.Name,
+}
+
+// rcvdDateFields maps QSL received status fields to the date the confirmation
+// was received.  A "Y" status without a date may indicate a hand-edited log.
+var rcvdDateFields = map[string]string{
+	EqslQslRcvdField.Name: EqslQslrdateField.Name,
+	LotwQslRcvdField.Name: LotwQslrdateField.Name,
+	QslRcvdField.Name:     QslrdateField.Name,
+}
+
+// ValidateIOTARef checks the format of an IOTA reference and warns if the
+// continent prefix does not match the continent of the DXCC entity or country.
+// IOTA is compared with DXCC/COUNTRY and MY_IOTA with MY_DXCC/MY_COUNTRY.
+func ValidateIOTARef(val string, f Field, ctx ValidationContext) Validation {
+	if v := formatValidator("IOTA reference", iotaPat)(val, f, ctx); v.Validity != Valid || val == "" {
+		return v
+	}
+	dxccf, countryf := DxccField, CountryField
+	if f.Name == MyIotaField.Name {
+		dxccf, countryf = MyDxccField, MyCountryField
+	}
+	for _, df := range []Field{dxccf, countryf} {
+		if d := ctx.FieldValue(df.Name); d != "" {
+			if c := ContinentFor(d); c.Abbreviation != "" && !strings.EqualFold(val[0:2], c.Abbreviation) {
+				return warningf("%s %s does not match %s %s continent %s", f.Name, val, df.Name, d, c.Abbreviation)
+			}
+			break
+		}
+	}
+	return valid()
+}
+
+// ValidateWWFFRef checks the xxFF-nnnn format of a WWFF reference and warns if
+// the national program xx doesn't look like a callsign prefix.  Programs
+// generally follow DXCC entity prefixes, e.g. KFF for the United States and
+// ONFF for Belgium.
+func ValidateWWFFRef(val string, f Field, ctx ValidationContext) Validation {
+	if v := formatValidator("WWFF reference", wwffPat)(val, f, ctx); v.Validity != Valid || val == "" {
+		return v
+	}
+	prog := strings.ToUpper(val[:strings.LastIndex(val, "-")-2])
+	if len(DXCCFromCallsign(prog)) == 0 {
+		return warningf("%s %s unknown WWFF program %s", f.Name, val, prog+"FF")
+	}
 	return valid()
 }
 
@@ -455,7 +815,11 @@ func ValidateEnumScope(val string, f Field, ctx ValidationContext) Validation {
 	return valid()
 }
 
-func gridsquareValidator(maxLen int) FieldValidator {
+// gridsquareValidator checks Maidenhead locators up to maxLen characters.
+// offset is the position in a full locator whose rules apply to the first
+// character, e.g. 4 for GRIDSQUARE_EXT: it holds the fifth and sixth pairs,
+// which have the same letters A-X then digits as the third and fourth pairs.
+func gridsquareValidator(maxLen, offset int) FieldValidator {
 	return func(val string, f Field, ctx ValidationContext) Validation {
 		if val == "" {
 			return valid()
@@ -482,8 +846,86 @@ func gridsquareValidator(maxLen int) FieldValidator {
 				}
 			}
 		}
+		// field (first pair) is A-R, subsquare (third pair) is A-X
+		for i, c := range strings.ToUpper(val) {
+			switch i + offset {
+			case 0, 1:
+				if c > 'R' {
+					return warningf("%s field letter %c out of range A-R %q", f.Name, c, val)
+				}
+			case 4, 5:
+				if c > 'X' {
+					return warningf("%s subsquare letter %c out of range A-X %q", f.Name, c, val)
+				}
+			}
+		}
+		// GRIDSQUARE_EXT holds characters 9 and up, so the locator needs 8 first
+		if offset > 0 && ctx.FieldValue != nil {
+			base := strings.TrimSuffix(f.Name, "_EXT")
+			if g := ctx.FieldValue(base); g != "" && len(g) != 8 {
+				return warningf("%s %q extends an 8-character %s but got %q", f.Name, val, base, g)
+			}
+		}
+		return valid()
+	}
+}
+
+// ValidateGridsquareList checks the format of each locator in a list and, for
+// VUCC_GRIDS and MY_VUCC_GRIDS, warns if the grid squares don't form a
+// connected group.  A VUCC contact on a grid line or corner lists two or four
+// grid squares, so each must touch another at an edge or corner.
+func ValidateGridsquareList(val string, f Field, ctx ValidationContext) Validation {
+	if res := listValidator(gridsquareValidator(8, 0))(val, f, ctx); res.Validity != Valid {
+		return res
+	}
+	if f.Name != VuccGridsField.Name && f.Name != MyVuccGridsField.Name {
+		return valid()
+	}
+	type square struct{ lat, lon float64 } // southwest corner
+	var squares []square
+	for _, v := range strings.Split(val, ",") {
+		if v == "" {
+			continue
+		}
+		if len(v) < 4 {
+			return valid() // fields are too large to check adjacency
+		}
+		lat, _, lon, _, err := GridsquareBoundingBox(v[:4])
+		if err != nil {
+			return valid()
+		}
+		squares = append(squares, square{lat: lat, lon: lon})
+	}
+	// squares are 1° of latitude by 2° of longitude
+	adjacent := func(a, b square) bool {
+		dlon := math.Abs(a.lon - b.lon)
+		if dlon > 180 { // wraps around at 180 degrees longitude
+			dlon = 360 - dlon
+		}
+		return dlon <= 2 && math.Abs(a.lat-b.lat) <= 1
+	}
+	if len(squares) < 2 {
 		return valid()
 	}
+	connected := make([]bool, len(squares))
+	connected[0] = true
+	queue := []int{0}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for i, sq := range squares {
+			if !connected[i] && adjacent(squares[cur], sq) {
+				connected[i] = true
+				queue = append(queue, i)
+			}
+		}
+	}
+	for _, c := range connected {
+		if !c {
+			return warningf("%s grid squares are not adjacent %q", f.Name, val)
+		}
+	}
+	return valid()
 }
 
 func listValidator(fv FieldValidator) FieldValidator {
@@ -500,6 +942,23 @@ func listValidator(fv FieldValidator) FieldValidator {
 	}
 }
 
+// ValidateSponsoredAward checks that an award name such as ADIF_CENTURY_BASIC
+// starts with a Sponsor from the Award_Sponsor enumeration.
+func ValidateSponsoredAward(val string, f Field, ctx ValidationContext) Validation {
+	if val == "" {
+		return valid()
+	}
+	sponsors := make([]string, len(AwardSponsorEnumeration.Values))
+	for i, v := range AwardSponsorEnumeration.Values {
+		p := v.(AwardSponsorEnum).Sponsor
+		if len(val) > len(p) && strings.EqualFold(val[:len(p)], p) {
+			return valid()
+		}
+		sponsors[i] = p
+	}
+	return errorf("%s award %q does not start with a known sponsor: %s", f.Name, val, strings.Join(sponsors, " "))
+}
+
 func formatValidator(name string, p *regexp.Regexp) FieldValidator {
 	return func(val string, f Field, ctx ValidationContext) Validation {
 		if val == "" {
@@ -514,6 +973,26 @@ func formatValidator(name string, p *regexp.Regexp) FieldValidator {
 
 func isASCIIChar(c rune) bool { return between(c, 32, 126) }
 
+// equalFoldLocale compares a and b case-insensitively.  ASCII strings use
+// strings.EqualFold; other strings are compared in lower case using the rules
+// of lang, e.g. Turkish dotless ı.
+func equalFoldLocale(a, b string, lang language.Tag) bool {
+	if isASCII(a) && isASCII(b) {
+		return strings.EqualFold(a, b)
+	}
+	c := cases.Lower(lang)
+	return c.String(a) == c.String(b)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 func between[T constraints.Ordered](val, low, high T) bool {
 	return val >= low && val <= high
 }
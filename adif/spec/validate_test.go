@@ -17,6 +17,8 @@ package spec
 import (
 	"testing"
 	"time"
+
+	"golang.org/x/text/language"
 )
 
 type validateTest struct {
@@ -230,6 +232,70 @@ func TestValidateDateRelative(t *testing.T) {
 	}
 }
 
+func TestValidateDateOrder(t *testing.T) {
+	tests := []struct {
+		validateTest
+		qsoDate, sent string
+	}{
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20240105", want: Valid}},
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20240105", want: Valid}, qsoDate: "20240101", sent: "20240102"},
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20240102", want: Valid}, qsoDate: "20240102", sent: "20240102"},
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20240101", want: InvalidError}, qsoDate: "20240101", sent: "20240102"},
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20231231", want: InvalidError}, qsoDate: "20240101"},
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20231231", want: InvalidError}, sent: "20240101"},
+		{validateTest: validateTest{field: LotwQslrdateField, value: "20240105", want: Valid}, qsoDate: "2024-01-10", sent: "bogus"},
+		{validateTest: validateTest{field: LotwQslsdateField, value: "20240102", want: Valid}, qsoDate: "20240101"},
+		{validateTest: validateTest{field: LotwQslsdateField, value: "20240101", want: Valid}, qsoDate: "20240101"},
+		{validateTest: validateTest{field: LotwQslsdateField, value: "20231231", want: InvalidError}, qsoDate: "20240101"},
+		{validateTest: validateTest{field: QsoDateField, value: "20240110", want: Valid}, sent: "20240101"},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string {
+			switch name {
+			case QsoDateField.Name:
+				return tc.qsoDate
+			case LotwQslsdateField.Name:
+				return tc.sent
+			default:
+				return ""
+			}
+		}}
+		testValidator(t, tc.validateTest, ctx, "TestValidateDateOrder")
+	}
+}
+
+func TestValidateSentDate(t *testing.T) {
+	tests := []struct {
+		validateTest
+		qsoDate, sent string
+	}{
+		{validateTest: validateTest{field: QrzcomQsoUploadStatusField, value: "Y", want: Valid}, qsoDate: "20240101", sent: "20240102"},
+		{validateTest: validateTest{field: QrzcomQsoUploadStatusField, value: "Y", want: Valid}, sent: "20240102"},
+		{validateTest: validateTest{field: QrzcomQsoUploadStatusField, value: "Y", want: InvalidWarning}, qsoDate: "20010101"},
+		{validateTest: validateTest{field: QrzcomQsoUploadStatusField, value: "Y", want: InvalidWarning}, qsoDate: "20010101", sent: "20240102"},
+		{validateTest: validateTest{field: QrzcomQsoUploadStatusField, value: "N", want: Valid}, qsoDate: "20010101"},
+		{validateTest: validateTest{field: QrzcomQsoUploadStatusField, value: "M", want: Valid}, qsoDate: "20010101"},
+		{validateTest: validateTest{field: ClublogQsoUploadStatusField, value: "Y", want: InvalidWarning}, qsoDate: "20240101"},
+		{validateTest: validateTest{field: LotwQslSentField, value: "Y", want: Valid}, qsoDate: "20231231", sent: "20241231"},
+		{validateTest: validateTest{field: LotwQslSentField, value: "Y", want: InvalidWarning}, qsoDate: "20231231", sent: "20250101"},
+		{validateTest: validateTest{field: LotwQslSentField, value: "R", want: Valid}, qsoDate: "20231231"},
+		{validateTest: validateTest{field: EqslQslSentField, value: "y", want: InvalidWarning}, qsoDate: "20231231"},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string {
+			switch name {
+			case QsoDateField.Name:
+				return tc.qsoDate
+			case QrzcomQsoUploadDateField.Name, ClublogQsoUploadDateField.Name, LotwQslsdateField.Name, EqslQslsdateField.Name:
+				return tc.sent
+			default:
+				return ""
+			}
+		}}
+		testValidator(t, tc.validateTest, ctx, "TestValidateSentDate")
+	}
+}
+
 func TestValidateTimeRelative(t *testing.T) {
 	now := time.Date(2023, time.October, 31, 12, 34, 56, 0, time.UTC)
 	yesterday := "20231030"
@@ -320,6 +386,10 @@ func TestValidateIntlString(t *testing.T) {
 		{field: MyAntennaIntlField, value: "blank\r\n\r\nline", want: InvalidError},
 		{field: MyCityIntlField, value: "line end\n", want: InvalidError},
 		{field: MyCountryIntlField, value: "\r\n", want: InvalidError},
+		{field: MyNameIntlField, value: "lone \xed\xa0\xbd surrogate", want: InvalidError},
+		{field: CommentIntlField, value: "surrogate pair \xed\xa0\xbd\xed\xb8\x80", want: InvalidError},
+		{field: MyCityIntlField, value: "Latin-1 M\xfcnchen", want: InvalidError},
+		{field: CountryIntlField, value: "truncated \xf0\x9f\x87", want: InvalidError},
 	}
 	for _, tc := range tests {
 		testValidator(t, tc, emptyCtx, "ValidateIntlString")
@@ -340,6 +410,7 @@ func TestValidateIntlMultilineString(t *testing.T) {
 		{field: NotesIntlField, value: "line end\n", want: Valid},
 		{field: QslmsgIntlField, value: "\r\n", want: Valid},
 		{field: RigIntlField, value: "hello\tworld\r\n", want: Valid},
+		{field: AddressIntlField, value: "lone\r\n\xed\xb2\x80\r\nsurrogate", want: InvalidError},
 	}
 	for _, tc := range tests {
 		testValidator(t, tc, emptyCtx, "ValidateIntlMultilineString")
@@ -606,23 +677,51 @@ func TestValidateContestID(t *testing.T) {
 		{field: ContestIdField, value: "70-31-FLAVORS", want: InvalidWarning},
 		{field: ContestIdField, value: "RAC", want: Valid},
 		{field: ContestIdField, value: "R.A.C.", want: InvalidWarning},
+		{field: ContestIdField, value: "MY-CLUB-SPRINT", want: InvalidWarning},
+		{field: ContestIdField, value: "-CQ-WW", want: InvalidWarning},
+		{field: ContestIdField, value: "CQ--WW", want: InvalidWarning},
+		{field: ContestIdField, value: "X", want: InvalidWarning},
 	}
 	for _, tc := range tests {
 		testValidator(t, tc, emptyCtx, "ValidateContestId")
 	}
 }
 
+func TestContestIDFormatProblem(t *testing.T) {
+	tests := []struct{ value, want string }{
+		{value: "MY-CLUB-SPRINT", want: ""},
+		{value: "NAQP-CW", want: ""},
+		{value: "CO_QSO_PARTY", want: "should use hyphens rather than underscores or spaces"},
+		{value: "CO QSO PARTY", want: "should use hyphens rather than underscores or spaces"},
+		{value: "R.A.C.", want: "should only have letters, digits, and hyphens"},
+		{value: "my-club-sprint", want: "should be upper case"},
+		{value: "X", want: "should be 2 to 30 characters long"},
+		{value: "THE-VERY-LONG-CLUB-SPRINT-CONTEST", want: "should be 2 to 30 characters long"},
+		{value: "-CQ-WW", want: "should not start or end with a hyphen"},
+		{value: "CQ-WW-", want: "should not start or end with a hyphen"},
+		{value: "CQ--WW", want: "should not have consecutive hyphens"},
+	}
+	for _, tc := range tests {
+		if got := contestIDFormatProblem(tc.value); got != tc.want {
+			t.Errorf("contestIDFormatProblem(%q) got %q want %q", tc.value, got, tc.want)
+		}
+	}
+}
+
 func TestValidateGridsquare(t *testing.T) {
 	tests := []validateTest{
 		{field: GridsquareField, value: "", want: Valid},
-		// First letter pair is only valid A-R, thuogh we don't check this
+		// First letter pair is only valid A-R
 		{field: GridsquareField, value: "AA", want: Valid},
 		{field: MyGridsquareField, value: "rr", want: Valid},
 		{field: GridsquareField, value: "AA00", want: Valid},
 		{field: MyGridsquareField, value: "CD12", want: Valid},
 		{field: GridsquareField, value: "jk28", want: Valid},
-		{field: MyGridsquareField, value: "XX99", want: Valid},
-		// Second letter pair is only valid A-X, thuogh we don't check this
+		{field: MyGridsquareField, value: "XX99", want: InvalidWarning},
+		{field: GridsquareField, value: "SA", want: InvalidWarning},
+		{field: MyGridsquareField, value: "as12", want: InvalidWarning},
+		{field: GridsquareField, value: "ZZ00aa", want: InvalidWarning},
+		// Second letter pair is only valid A-X
 		{field: GridsquareField, value: "AB34ef", want: Valid},
 		{field: MyGridsquareField, value: "gh56IJ", want: Valid},
 		{field: GridsquareField, value: "KL78mn", want: Valid},
@@ -630,6 +729,9 @@ func TestValidateGridsquare(t *testing.T) {
 		{field: GridsquareField, value: "AA00xx99", want: Valid},
 		{field: MyGridsquareField, value: "rh63NG50", want: Valid},
 		{field: GridsquareField, value: "rr99aa00", want: Valid},
+		{field: MyGridsquareField, value: "FN31YA", want: InvalidWarning},
+		{field: GridsquareField, value: "fn31pz", want: InvalidWarning},
+		{field: MyGridsquareField, value: "FN31zz00", want: InvalidWarning},
 		{field: MyGridsquareField, value: ",", want: InvalidError},
 		{field: MyGridsquareField, value: "F,", want: InvalidError},
 		{field: MyGridsquareField, value: "JK3,", want: InvalidError},
@@ -660,14 +762,15 @@ func TestGridsquareExt(t *testing.T) {
 	// Gridsquare extension has max length 4 (2 letters, 2 numbers)
 	tests := []validateTest{
 		{field: GridsquareExtField, value: "", want: Valid},
-		// First letter pair is only valid A-R, thuogh we don't check this
+		// Extension letters are subsquares, valid A-X
 		{field: GridsquareExtField, value: "AA", want: Valid},
 		{field: MyGridsquareExtField, value: "rr", want: Valid},
 		{field: GridsquareExtField, value: "AA00", want: Valid},
 		{field: MyGridsquareExtField, value: "CD12", want: Valid},
 		{field: GridsquareExtField, value: "jk28", want: Valid},
 		{field: MyGridsquareExtField, value: "XX99", want: Valid},
-		// Second letter pair is only valid A-X, thuogh we don't check this
+		{field: GridsquareExtField, value: "YA", want: InvalidWarning},
+		{field: MyGridsquareExtField, value: "az12", want: InvalidWarning},
 		{field: GridsquareExtField, value: "AB34ef", want: InvalidError},
 		{field: MyGridsquareExtField, value: "gh56IJ", want: InvalidError},
 		{field: GridsquareExtField, value: "KL78mn", want: InvalidError},
@@ -690,19 +793,37 @@ func TestGridsquareExt(t *testing.T) {
 	for _, tc := range tests {
 		testValidator(t, tc, emptyCtx, "ValidateGridsquareExt")
 	}
+	withGrid := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: GridsquareExtField, value: "BQ", want: Valid}, values: map[string]string{"GRIDSQUARE": "FN01MH42"}},
+		{validateTest: validateTest{field: MyGridsquareExtField, value: "BQ12", want: Valid}, values: map[string]string{"MY_GRIDSQUARE": "fn01mh42"}},
+		{validateTest: validateTest{field: GridsquareExtField, value: "BQ", want: InvalidWarning}, values: map[string]string{"GRIDSQUARE": "FN01MH"}},
+		{validateTest: validateTest{field: MyGridsquareExtField, value: "BQ", want: InvalidWarning}, values: map[string]string{"MY_GRIDSQUARE": "FN01", "GRIDSQUARE": "FN01MH42"}},
+	}
+	for _, tc := range withGrid {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateGridsquareExt")
+	}
 }
 
 func TestGridsquareList(t *testing.T) {
-	// Validator doesn't currently check that locators are adjacent
 	tests := []validateTest{
 		{field: VuccGridsField, value: "", want: Valid},
 		{field: MyVuccGridsField, value: ",", want: Valid},
 		{field: MyVuccGridsField, value: ",HI59", want: Valid},
 		{field: VuccGridsField, value: "DN70,DM79", want: Valid},
 		{field: MyVuccGridsField, value: "DN70,DM79,DN80,DM89", want: Valid},
-		{field: VuccGridsField, value: "AA00xx,RR99aa", want: Valid},
-		{field: MyVuccGridsField, value: "AA00xx,RR99aa,GO83NU", want: Valid},
+		{field: VuccGridsField, value: "dn70,dm89", want: Valid},
+		{field: VuccGridsField, value: "DM79,DN80,DN70,DM89", want: Valid},
+		{field: VuccGridsField, value: "AL09,RL99", want: Valid},
+		{field: VuccGridsField, value: "FN31pr,FN32aa", want: Valid},
 		{field: VuccGridsField, value: "Rq98Po87,nm65LK43,JH21,ig", want: Valid},
+		{field: VuccGridsField, value: "AA00xx,RR99aa", want: InvalidWarning},
+		{field: MyVuccGridsField, value: "AA00xx,RR99aa,GO83NU", want: InvalidWarning},
+		{field: VuccGridsField, value: "DN70,DN72", want: InvalidWarning},
+		{field: MyVuccGridsField, value: "DN70,DM79,DN90,DM99", want: InvalidWarning},
 		{field: VuccGridsField, value: " ", want: InvalidError},
 		{field: MyVuccGridsField, value: " , ", want: InvalidError},
 		{field: VuccGridsField, value: "DN70;DM79", want: InvalidError},
@@ -784,6 +905,187 @@ func TestValidateIOTARef(t *testing.T) {
 	}
 }
 
+func TestValidateSponsoredAwardList(t *testing.T) {
+	tests := []validateTest{
+		{field: AwardSubmittedField, value: "", want: Valid},
+		{field: AwardSubmittedField, value: "ADIF_CENTURY_BASIC", want: Valid},
+		{field: AwardSubmittedField, value: "ADIF_CENTURY_BASIC,ADIF_CENTURY_SILVER,ADIF_SPECTRUM_100-160m", want: Valid},
+		{field: AwardGrantedField, value: "ARRL_DXCC,cq_waz,WABAG_BASIC", want: Valid},
+		{field: AwardGrantedField, value: "DARC_DLD_100,JARL_AJD", want: Valid},
+		{field: AwardSubmittedField, value: "DXCC", want: InvalidError},
+		{field: AwardSubmittedField, value: "ARRL_", want: InvalidError},
+		{field: AwardSubmittedField, value: "ARRL-DXCC", want: InvalidError},
+		{field: AwardGrantedField, value: "ADIF_CENTURY_BASIC,WAS", want: InvalidError},
+	}
+	for _, tc := range tests {
+		testValidator(t, tc, emptyCtx, "ValidateSponsoredAward")
+	}
+}
+
+func TestValidateIOTAContinent(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: IotaField, value: "NA-046", want: Valid}, values: map[string]string{"DXCC": CountryUnitedStatesOfAmerica.EntityCode}},
+		{validateTest: validateTest{field: IotaField, value: "EU-005", want: InvalidWarning}, values: map[string]string{"DXCC": CountryUnitedStatesOfAmerica.EntityCode}},
+		{validateTest: validateTest{field: IotaField, value: "oc-001", want: Valid}, values: map[string]string{"COUNTRY": CountryAustralia.EntityName}},
+		{validateTest: validateTest{field: IotaField, value: "AF-004", want: InvalidWarning}, values: map[string]string{"COUNTRY": CountryAustralia.EntityName}},
+		{validateTest: validateTest{field: IotaField, value: "AF-004", want: Valid}, values: map[string]string{"DXCC": CountryCanaryIslands.EntityCode, "COUNTRY": CountrySpain.EntityName}},
+		{validateTest: validateTest{field: IotaField, value: "EU-005", want: Valid}, values: map[string]string{"MY_DXCC": CountryUnitedStatesOfAmerica.EntityCode}},
+		{validateTest: validateTest{field: MyIotaField, value: "EU-005", want: Valid}, values: map[string]string{"MY_DXCC": CountryEngland.EntityCode}},
+		{validateTest: validateTest{field: MyIotaField, value: "NA-005", want: InvalidWarning}, values: map[string]string{"MY_COUNTRY": CountryEngland.EntityName}},
+		{validateTest: validateTest{field: MyIotaField, value: "NA-005", want: Valid}, values: map[string]string{"DXCC": CountryEngland.EntityCode}},
+		{validateTest: validateTest{field: IotaField, value: "AS-005", want: Valid}, values: map[string]string{"DXCC": "9999"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateIOTARef")
+	}
+}
+
+func TestValidateDXCCCallsign(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"CALL": "W1AW"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: InvalidWarning}, values: map[string]string{"CALL": "VE3ABC"}},
+		{validateTest: validateTest{field: DxccField, value: "1", want: Valid}, values: map[string]string{"CALL": "VE3ABC"}},
+		{validateTest: validateTest{field: DxccField, value: "1", want: Valid}, values: map[string]string{"CALL": "VE3/W1AW"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"CALL": "W1AW/P"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"CALL": "W1AW/M"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"CALL": "W1AW/QRP"}},
+		{validateTest: validateTest{field: DxccField, value: CountryAustralia.EntityCode, want: Valid}, values: map[string]string{"CALL": "K1JT/VK2"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: InvalidWarning}, values: map[string]string{"CALL": "K1JT/VK2"}},
+		{validateTest: validateTest{field: DxccField, value: CountryFederalRepublicOfGermany.EntityCode, want: Valid}, values: map[string]string{"CALL": "W1AW/DL1"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"CALL": "W1AW/MM"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"CALL": "Q1XYZ"}},
+		{validateTest: validateTest{field: DxccField, value: "291", want: Valid}, values: map[string]string{"STATION_CALLSIGN": "VE3ABC"}},
+		{validateTest: validateTest{field: MyDxccField, value: "291", want: InvalidWarning}, values: map[string]string{"STATION_CALLSIGN": "VE3ABC", "CALL": "W1AW"}},
+		{validateTest: validateTest{field: MyDxccField, value: "223", want: Valid}, values: map[string]string{"STATION_CALLSIGN": "G0ABC"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateEnumeration")
+	}
+}
+
+func TestValidateRSTDigits(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: RstSentField, value: "599", want: Valid}, values: map[string]string{"MODE": "CW"}},
+		{validateTest: validateTest{field: RstRcvdField, value: "57", want: InvalidWarning}, values: map[string]string{"MODE": "cw"}},
+		{validateTest: validateTest{field: RstSentField, value: "59", want: Valid}, values: map[string]string{"MODE": "SSB"}},
+		{validateTest: validateTest{field: RstRcvdField, value: "599", want: InvalidWarning}, values: map[string]string{"MODE": "SSB"}},
+		{validateTest: validateTest{field: RstSentField, value: "339", want: InvalidWarning}, values: map[string]string{"MODE": "FM"}},
+		{validateTest: validateTest{field: RstRcvdField, value: "44", want: Valid}, values: map[string]string{"MODE": "AM"}},
+		{validateTest: validateTest{field: RstSentField, value: "599", want: InvalidWarning}, values: map[string]string{"MODE": "DIGITALVOICE"}},
+		{validateTest: validateTest{field: RstSentField, value: "599", want: Valid}, values: map[string]string{"MODE": "RTTY"}},
+		{validateTest: validateTest{field: RstRcvdField, value: "59", want: Valid}, values: map[string]string{"MODE": "RTTY"}},
+		{validateTest: validateTest{field: RstSentField, value: "-12", want: Valid}, values: map[string]string{"MODE": "FT8"}},
+		{validateTest: validateTest{field: RstSentField, value: "5NN", want: Valid}, values: map[string]string{"MODE": "CW"}},
+		{validateTest: validateTest{field: RstSentField, value: "59", want: Valid}, values: map[string]string{}},
+		{validateTest: validateTest{field: CommentField, value: "59", want: Valid}, values: map[string]string{"MODE": "CW"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateString")
+	}
+}
+
+func TestValidateBandFreq(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: BandField, value: "20m", want: Valid}, values: map[string]string{"FREQ": "14.074"}},
+		{validateTest: validateTest{field: BandField, value: "20m", want: Valid}, values: map[string]string{"FREQ": "14.35"}},
+		{validateTest: validateTest{field: BandField, value: "20M", want: InvalidWarning}, values: map[string]string{"FREQ": "7.074"}},
+		{validateTest: validateTest{field: BandField, value: "20m", want: Valid}, values: map[string]string{"FREQ_RX": "7.074"}},
+		{validateTest: validateTest{field: BandField, value: "2m", want: Valid}, values: map[string]string{"FREQ": "145.9", "BAND_RX": "70cm", "FREQ_RX": "435.3"}},
+		{validateTest: validateTest{field: BandRxField, value: "70cm", want: Valid}, values: map[string]string{"FREQ": "145.9", "BAND": "2m", "FREQ_RX": "435.3"}},
+		{validateTest: validateTest{field: BandRxField, value: "70cm", want: InvalidWarning}, values: map[string]string{"FREQ": "435.3", "FREQ_RX": "145.9"}},
+		{validateTest: validateTest{field: BandRxField, value: "40m", want: Valid}, values: map[string]string{"FREQ": "3.5"}},
+		// invalid frequencies are reported by the number validator
+		{validateTest: validateTest{field: BandRxField, value: "40m", want: Valid}, values: map[string]string{"FREQ_RX": "7 MHz"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateEnumeration")
+	}
+}
+
+func TestValidateArrlSectionDXCC(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: ArrlSectField, value: "CT", want: Valid}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: ArrlSectField, value: "ON", want: Valid}, values: map[string]string{"DXCC": "1"}},
+		{validateTest: validateTest{field: ArrlSectField, value: "PAC", want: Valid}, values: map[string]string{"DXCC": "110"}},
+		{validateTest: validateTest{field: ArrlSectField, value: "CT", want: Valid}, values: map[string]string{}},
+		{validateTest: validateTest{field: ArrlSectField, value: "CT", want: Valid}, values: map[string]string{"MY_DXCC": "1"}},
+		{validateTest: validateTest{field: MyArrlSectField, value: "ab", want: Valid}, values: map[string]string{"MY_DXCC": "1", "DXCC": "291"}},
+		{validateTest: validateTest{field: ArrlSectField, value: "CT", want: InvalidWarning}, values: map[string]string{"DXCC": "1"}},
+		{validateTest: validateTest{field: ArrlSectField, value: "GTA", want: InvalidWarning}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: ArrlSectField, value: "WWA", want: InvalidWarning}, values: map[string]string{"DXCC": "230"}},
+		{validateTest: validateTest{field: MyArrlSectField, value: "BC", want: InvalidWarning}, values: map[string]string{"MY_DXCC": "291", "DXCC": "1"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateEnumeration")
+	}
+}
+
+func TestValidateFreqInBand(t *testing.T) {
+	tests := []validateTest{
+		{field: FreqField, value: "7.074", want: Valid},
+		{field: FreqField, value: "7.3", want: Valid},
+		{field: FreqField, value: "0.1375", want: Valid},
+		{field: FreqField, value: "10368.1", want: Valid},
+		{field: FreqRxField, value: "435.3", want: Valid},
+		{field: FreqField, value: "7.650", want: InvalidWarning},
+		{field: FreqField, value: "200", want: InvalidWarning},
+		{field: FreqField, value: "14074", want: InvalidWarning},
+		{field: FreqRxField, value: "11.0", want: InvalidWarning},
+	}
+	for _, tc := range tests {
+		testValidator(t, tc, emptyCtx, "ValidateNumber")
+	}
+}
+
+func TestValidateLocationGrid(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: LatField, value: "N041 42.888", want: Valid}, values: map[string]string{"GRIDSQUARE": "FN31pr"}},
+		{validateTest: validateTest{field: LonField, value: "W072 43.632", want: Valid}, values: map[string]string{"GRIDSQUARE": "FN31pr"}},
+		{validateTest: validateTest{field: LonField, value: "W072 50.000", want: Valid}, values: map[string]string{"GRIDSQUARE": "fn31PR"}},
+		{validateTest: validateTest{field: LonField, value: "W072 55.000", want: InvalidWarning}, values: map[string]string{"GRIDSQUARE": "FN31pr"}},
+		{validateTest: validateTest{field: LatField, value: "N040 00.000", want: InvalidWarning}, values: map[string]string{"GRIDSQUARE": "FN31pr"}},
+		{validateTest: validateTest{field: LatField, value: "N040 00.000", want: Valid}, values: map[string]string{"GRIDSQUARE": "FN"}},
+		{validateTest: validateTest{field: LatField, value: "S033 52.000", want: InvalidWarning}, values: map[string]string{"GRIDSQUARE": "FN31"}},
+		{validateTest: validateTest{field: LatField, value: "N041 42.888", want: Valid}, values: map[string]string{"GRIDSQUARE": "FN31pr21", "GRIDSQUARE_EXT": "rn"}},
+		{validateTest: validateTest{field: LatField, value: "N041 42.888", want: InvalidWarning}, values: map[string]string{"GRIDSQUARE": "FN31pr21", "GRIDSQUARE_EXT": "ra"}},
+		{validateTest: validateTest{field: LatField, value: "N041 42.888", want: Valid}, values: map[string]string{"MY_GRIDSQUARE": "JN58"}},
+		{validateTest: validateTest{field: MyLatField, value: "N041 42.888", want: InvalidWarning}, values: map[string]string{"MY_GRIDSQUARE": "JN58"}},
+		{validateTest: validateTest{field: MyLonField, value: "E011 30.000", want: Valid}, values: map[string]string{"MY_GRIDSQUARE": "JN58", "GRIDSQUARE": "FN31"}},
+		// neighboring squares across the antimeridian
+		{validateTest: validateTest{field: LonField, value: "W179 30.000", want: Valid}, values: map[string]string{"GRIDSQUARE": "RB"}},
+		{validateTest: validateTest{field: LonField, value: "E179 30.000", want: Valid}, values: map[string]string{"GRIDSQUARE": "AB"}},
+		// invalid grid squares are reported by the grid square validator
+		{validateTest: validateTest{field: LatField, value: "N041 42.888", want: Valid}, values: map[string]string{"GRIDSQUARE": "ZZ99"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "ValidateLocation")
+	}
+}
+
 func TestValidatePOTARef(t *testing.T) {
 	tests := []validateTest{
 		{field: PotaRefField, value: "", want: Valid},
@@ -870,6 +1172,10 @@ func TestValidateWWFFRef(t *testing.T) {
 		{field: WwffRefField, value: "ZSAA-1234", want: InvalidError},
 		{field: MyWwffRefField, value: "SHFF-0014 Serengeti", want: InvalidError},
 		{field: WwffRefField, value: "KFF-0043,KFF-0042", want: InvalidError},
+		{field: WwffRefField, value: "onff-0042", want: Valid},
+		{field: MyWwffRefField, value: "KH6FF-0001", want: Valid},
+		{field: WwffRefField, value: "QFF-0001", want: InvalidWarning},
+		{field: MyWwffRefField, value: "XXXFF-1234", want: InvalidWarning},
 	}
 	for _, tc := range tests {
 		testValidator(t, tc, emptyCtx, "ValidateWWFFRef")
@@ -946,7 +1252,46 @@ func TestValidateITUZone(t *testing.T) {
 				return ""
 			}
 		}}
-		testValidator(t, tc.validateTest, ctx, "TestValidateCQZone")
+		testValidator(t, tc.validateTest, ctx, "TestValidateITUZone")
+	}
+}
+
+func TestValidateMyITUZone(t *testing.T) {
+	tests := []struct {
+		validateTest
+		dxcc, mydxcc, country, mycountry string
+	}{
+		{validateTest: validateTest{field: MyItuZoneField, value: "90", want: Valid}},
+		{validateTest: validateTest{field: MyItuZoneField, value: "1", want: Valid}, mydxcc: CountryAlaska.EntityCode},
+		{validateTest: validateTest{field: MyItuZoneField, value: "02", want: Valid}, mydxcc: CountryAlaska.EntityCode},
+		{validateTest: validateTest{field: MyItuZoneField, value: "12", want: InvalidError}, mydxcc: CountryAlaska.EntityCode},
+		{validateTest: validateTest{field: MyItuZoneField, value: "33", want: Valid}, mydxcc: CountryAsiaticRussia.EntityCode},
+		{validateTest: validateTest{field: MyItuZoneField, value: "42", want: InvalidError}, mydxcc: CountryAsiaticRussia.EntityCode},
+		{validateTest: validateTest{field: MyItuZoneField, value: "36", want: Valid}, mycountry: CountryCanaryIslands.EntityName},
+		// MY_DXCC takes precedence over MY_COUNTRY
+		{validateTest: validateTest{field: MyItuZoneField, value: "45", want: Valid}, mydxcc: CountryJapan.EntityCode, mycountry: CountryCanaryIslands.EntityName},
+		{validateTest: validateTest{field: MyItuZoneField, value: "36", want: InvalidError}, mydxcc: CountryJapan.EntityCode, mycountry: CountryCanaryIslands.EntityName},
+		// DXCC and COUNTRY describe the other station, not MY_ITU_ZONE
+		{validateTest: validateTest{field: MyItuZoneField, value: "45", want: Valid}, dxcc: CountryAlaska.EntityCode, country: CountryAlaska.EntityName},
+		{validateTest: validateTest{field: MyItuZoneField, value: "45", want: InvalidError}, dxcc: CountryJapan.EntityCode, mydxcc: CountryAlaska.EntityCode},
+		{validateTest: validateTest{field: ItuzField, value: "45", want: Valid}, dxcc: CountryJapan.EntityCode, mydxcc: CountryAlaska.EntityCode},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string {
+			switch name {
+			case DxccField.Name:
+				return tc.dxcc
+			case MyDxccField.Name:
+				return tc.mydxcc
+			case CountryField.Name:
+				return tc.country
+			case MyCountryField.Name:
+				return tc.mycountry
+			default:
+				return ""
+			}
+		}}
+		testValidator(t, tc.validateTest, ctx, "TestValidateMyITUZone")
 	}
 }
 
@@ -961,6 +1306,9 @@ func TestValidateContinent(t *testing.T) {
 		{validateTest: validateTest{field: ContField, value: "EU", want: InvalidWarning}, dxcc: CountryCanaryIslands.EntityCode, country: ""},
 		{validateTest: validateTest{field: ContField, value: "EU", want: Valid}, dxcc: "", country: "Iceland"},
 		{validateTest: validateTest{field: ContField, value: "AS", want: InvalidWarning}, dxcc: CountryBolivia.EntityCode, country: ""},
+		{validateTest: validateTest{field: ContField, value: "NA", want: InvalidWarning}, dxcc: "", country: "Iceland"},
+		{validateTest: validateTest{field: ContField, value: "NA", want: Valid}, dxcc: "999", country: ""},
+		{validateTest: validateTest{field: ContField, value: "AF", want: Valid}, dxcc: "", country: "Atlantis"},
 	}
 	for _, tc := range tests {
 		ctx := ValidationContext{FieldValue: func(name string) string {
@@ -973,6 +1321,102 @@ func TestValidateContinent(t *testing.T) {
 				return ""
 			}
 		}}
-		testValidator(t, tc.validateTest, ctx, "TestValidateCountry")
+		testValidator(t, tc.validateTest, ctx, "TestValidateContinent")
+	}
+}
+
+func TestValidateQSLRcvdDate(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: LotwQslRcvdField, value: "Y", want: Valid}, values: map[string]string{"LOTW_QSLRDATE": "20240105"}},
+		{validateTest: validateTest{field: LotwQslRcvdField, value: "Y", want: InvalidWarning}, values: map[string]string{}},
+		{validateTest: validateTest{field: LotwQslRcvdField, value: "y", want: InvalidWarning}, values: map[string]string{"QSLRDATE": "20240105"}},
+		{validateTest: validateTest{field: LotwQslRcvdField, value: "N", want: Valid}, values: map[string]string{}},
+		{validateTest: validateTest{field: EqslQslRcvdField, value: "Y", want: Valid}, values: map[string]string{"EQSL_QSLRDATE": "20240105"}},
+		{validateTest: validateTest{field: EqslQslRcvdField, value: "Y", want: InvalidWarning}, values: map[string]string{"LOTW_QSLRDATE": "20240105"}},
+		{validateTest: validateTest{field: EqslQslRcvdField, value: "R", want: Valid}, values: map[string]string{}},
+		{validateTest: validateTest{field: QslRcvdField, value: "Y", want: Valid}, values: map[string]string{"QSL_RCVD_VIA": "B", "QSLRDATE": "20240105"}},
+		{validateTest: validateTest{field: QslRcvdField, value: "Y", want: InvalidWarning}, values: map[string]string{"QSL_RCVD_VIA": "b"}},
+		{validateTest: validateTest{field: QslRcvdField, value: "Y", want: Valid}, values: map[string]string{"QSL_RCVD_VIA": "D"}},
+		{validateTest: validateTest{field: QslRcvdField, value: "Y", want: Valid}, values: map[string]string{}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "TestValidateQSLRcvdDate")
+	}
+}
+
+func TestValidateCountryName(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: CountryField, value: "UNITED STATES OF AMERICA", want: Valid}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: CountryField, value: "United States of America", want: Valid}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: CountryField, value: "United States", want: InvalidWarning}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: CountryField, value: "Canada", want: InvalidWarning}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: CountryField, value: "USA", want: InvalidWarning}, values: map[string]string{"DXCC": "291"}},
+		{validateTest: validateTest{field: CountryField, value: "USA", want: Valid}, values: map[string]string{}},
+		{validateTest: validateTest{field: CountryField, value: "Canada", want: Valid}, values: map[string]string{"DXCC": "0"}},
+		{validateTest: validateTest{field: CountryField, value: "Canada", want: Valid}, values: map[string]string{"DXCC": "9999"}},
+		{validateTest: validateTest{field: CountryField, value: "Canada", want: Valid}, values: map[string]string{"MY_DXCC": "291"}},
+		{validateTest: validateTest{field: MyCountryField, value: "European Russia", want: Valid}, values: map[string]string{"MY_DXCC": "54"}},
+		{validateTest: validateTest{field: MyCountryField, value: "Russia", want: InvalidWarning}, values: map[string]string{"MY_DXCC": "54"}},
+		{validateTest: validateTest{field: MyCountryField, value: "Asiatic Russia", want: InvalidWarning}, values: map[string]string{"MY_DXCC": "54", "DXCC": "15"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "TestValidateCountryName")
+	}
+}
+
+func TestValidateDXCCValidFrom(t *testing.T) {
+	tests := []struct {
+		validateTest
+		values map[string]string
+	}{
+		{validateTest: validateTest{field: DxccField, value: "522", want: InvalidError}, values: map[string]string{"QSO_DATE": "20050612"}},
+		{validateTest: validateTest{field: DxccField, value: "522", want: InvalidError}, values: map[string]string{"QSO_DATE": "20180120"}},
+		{validateTest: validateTest{field: DxccField, value: "522", want: Valid}, values: map[string]string{"QSO_DATE": "20180121"}},
+		{validateTest: validateTest{field: DxccField, value: "522", want: Valid}, values: map[string]string{}},
+		{validateTest: validateTest{field: DxccField, value: "296", want: Valid}, values: map[string]string{"QSO_DATE": "20050612"}},
+		{validateTest: validateTest{field: DxccField, value: "503", want: InvalidError}, values: map[string]string{"QSO_DATE": "19921231"}},
+		{validateTest: validateTest{field: MyDxccField, value: "520", want: InvalidError}, values: map[string]string{"QSO_DATE": "20091010"}},
+		{validateTest: validateTest{field: MyDxccField, value: "520", want: Valid}, values: map[string]string{"QSO_DATE": "20101010"}},
+	}
+	for _, tc := range tests {
+		ctx := ValidationContext{FieldValue: func(name string) string { return tc.values[name] }}
+		testValidator(t, tc.validateTest, ctx, "TestValidateDXCCValidFrom")
+	}
+	if got := CountrySouthSudanRepublicOf.ValidFrom(); got != time.Date(2011, 7, 14, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("CountrySouthSudanRepublicOf.ValidFrom() got %v", got)
+	}
+	if got := DXCCValidFrom("CANADA"); !got.IsZero() {
+		t.Errorf("DXCCValidFrom(CANADA) got %v, want zero time", got)
+	}
+}
+
+func TestEqualFoldLocale(t *testing.T) {
+	tests := []struct {
+		a, b string
+		lang language.Tag
+		want bool
+	}{
+		{a: "NY", b: "ny", lang: language.Und, want: true},
+		{a: "NY", b: "NJ", lang: language.Und, want: false},
+		{a: "ÅLAND", b: "åland", lang: language.Und, want: true},
+		{a: "STRASSE", b: "straße", lang: language.German, want: false},
+		{a: "STRAẞE", b: "straße", lang: language.German, want: true},
+		{a: "KIRIKKALE", b: "kırıkkale", lang: language.Und, want: false},
+		{a: "KIRIKKALE", b: "kırıkkale", lang: language.Turkish, want: true},
+		{a: "İZMİR", b: "izmir", lang: language.Turkish, want: true},
+		{a: "ÅLAND", b: "åland", lang: language.Swedish, want: true},
+	}
+	for _, tc := range tests {
+		if got := equalFoldLocale(tc.a, tc.b, tc.lang); got != tc.want {
+			t.Errorf("equalFoldLocale(%q, %q, %s) got %v, want %v", tc.a, tc.b, tc.lang, got, tc.want)
+		}
 	}
 }
@@ -33,7 +33,9 @@ func NewTSVIO() *TSVIO { return &TSVIO{} }
 
 func (_ *TSVIO) String() string { return "tsv" }
 
-func (o *TSVIO) Read(r io.Reader) (*Logfile, error) {
+func (o *TSVIO) Read(r io.Reader) (*Logfile, error) { return readAll(o, r) }
+
+func (o *TSVIO) ReadRecords(r io.Reader, fn func(*Logfile, *Record) bool) (*Logfile, error) {
 	scan := bufio.NewScanner(r)
 	if !scan.Scan() {
 		return nil, errors.New("no TSV header row")
@@ -64,7 +66,7 @@ func (o *TSVIO) Read(r io.Reader) (*Logfile, error) {
 		line++
 		fs := strings.Split(scan.Text(), "\t")
 		if len(fs) > len(head) {
-			return nil, fmt.Errorf("line %d has %d fields, more than %d in TSV header", len(l.Records)+2, len(fs), len(head))
+			return nil, fmt.Errorf("line %d has %d fields, more than %d in TSV header", line, len(fs), len(head))
 		}
 		if len(fs) == 1 && fs[0] == "" {
 			continue // skip blank lines
@@ -73,7 +75,9 @@ func (o *TSVIO) Read(r io.Reader) (*Logfile, error) {
 		for i, f := range fs {
 			fields[i] = Field{Name: head[i], Value: o.unescape(f)}
 		}
-		l.AddRecord(NewRecord(fields...))
+		if !fn(l, NewRecord(fields...)) {
+			return l, nil
+		}
 	}
 	if err := scan.Err(); err != nil {
 		return nil, fmt.Errorf("reading TSV line %d: %w", line, err)
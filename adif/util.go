@@ -98,3 +98,12 @@ func cutPrefix(s, prefix string) (after string, found bool) {
 	}
 	return s, false
 }
+
+// cutSuffix is a polyfill for strings.CutSuffix which was introduced in Go 1.20.
+func cutSuffix(s, suffix string) (before string, found bool) {
+	// TODO when upgrading to Go 1.20+ remove this polyfill
+	if strings.HasSuffix(s, suffix) {
+		return s[:len(s)-len(suffix)], true
+	}
+	return s, false
+}
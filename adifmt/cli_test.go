@@ -36,6 +36,8 @@ func TestScripts(t *testing.T) {
 	})
 }
 
+// testPrepare sets fields in the existing header rather than replacing it with
+// adif.NewRecord because the header may have app-defined fields from input.
 func testPrepare(l *adif.Logfile) {
 	l.Header.SetComment(fmt.Sprintf("Generated with %d records by %s", len(l.Records), helpUrl))
 	l.Header.Set(adif.Field{Name: spec.AdifVerField.Name, Value: spec.ADIFVersion})
@@ -17,6 +17,8 @@ package main
 import (
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/flwyd/adif-multitool/adif/spec"
 	"github.com/flwyd/adif-multitool/cmd"
@@ -25,10 +27,41 @@ import (
 type cmdConfig struct {
 	cmd.Command
 	Configure func(*cmd.Context, *flag.FlagSet)
+	// ConfigureCommand is used instead of Configure by commands like watch
+	// which run the command named by their --command flag.
+	ConfigureCommand func(ctx *cmd.Context, fs *flag.FlagSet, sub cmdConfig)
 }
 
 var (
-	catConf = cmdConfig{Command: cmd.Cat}
+	annotateConf = cmdConfig{Command: cmd.Annotate,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.AnnotateContext{}
+			fs.StringVar(&cctx.Lookup, "lookup", "", "Lookup table `file` with key fields and fields to add, in any input format")
+			fs.Var(&cctx.Key, "key", "Comma-separated or multiple instance `fields` to match between records and the lookup table (default CALL)")
+			fs.Var(&cctx.Add, "add", "Comma-separated or multiple instance `fields` to copy from the lookup table (default all non-key fields)")
+			fs.BoolVar(&cctx.Overwrite, "overwrite", false, "Replace non-empty fields with lookup table values")
+			fs.BoolVar(&cctx.WarnMissing, "warn-missing", false, "Print a warning for records which are not in the lookup table")
+			ctx.CommandCtx = &cctx
+		}}
+
+	catConf = cmdConfig{Command: cmd.Cat,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.CatContext{}
+			fs.Var(&cctx.SetIfField, "set-if-field", "Set `field=value:when:other=match` in records where field other equals match, ignoring case (repeatable)")
+			fs.StringVar(&cctx.SequenceField, "add-sequence-field", "", "Add a `field` to each record with its position in the output")
+			fs.IntVar(&cctx.SequenceStart, "start", 1, "First `number` for --add-sequence-field, e.g. 0 for zero-based numbering")
+			fs.StringVar(&cctx.Append, "append", "", "Add records to the end of ADI `file` instead of standard output, creating it if needed")
+			ctx.CommandCtx = &cctx
+		}}
+
+	convertConf = cmdConfig{Command: cmd.Convert,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.ConvertContext{}
+			fs.Var(&ctx.InputFormat, "from", "Input `format`, same as --input")
+			fs.Var(&ctx.OutputFormat, "to", "Output `format`, same as --output")
+			fs.BoolVar(&cctx.WarnDroppedFields, "warn-dropped-fields", false, "Print a warning for records with fields the output format can't represent")
+			ctx.CommandCtx = &cctx
+		}}
 
 	countConf = cmdConfig{Command: cmd.Count,
 		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
@@ -52,15 +85,21 @@ var (
 			fs.Var(cctx.Cond.OrIfFlag(), "or-if", "Only edit records where `condition` is true or any previous --if group is true (repeatable)")
 			fs.Var(cctx.Cond.OrIfNotFlag(), "or-if-not", "Only edit records where `condition` is false or any previous --if group is true (repeatable)")
 			fs.Var(&cctx.Add, "add", "Add `field=value` if field is not already in a record (repeatable)")
-			fs.Var(&cctx.Set, "set", "Set `field=value` for all records (repeatable)")
 			fs.Var(&cctx.Rename, "rename", "Rename `old=new` field for all records (repeatable)")
 			fs.Var(&cctx.Remove, "remove", "Remove `fields` from all records (comma-separated, repeatable)")
 			fs.BoolVar(&cctx.RemoveBlank, "remove-blank", false, "Remove all blank fields")
+			fs.Var(&cctx.ReplaceFields, "field", "Replace --from text with --to in `fields` (comma-separated, repeatable)")
+			fs.StringVar(&cctx.ReplaceFrom, "from", "", "`text` to replace in --field values")
+			fs.StringVar(&cctx.ReplaceTo, "to", "", "Replacement `text` for --from, may be empty")
+			fs.BoolVar(&cctx.Regex, "regex", false, "Interpret --from as a regular expression")
 			fs.Var(&cctx.FromZone, "time-zone-from", "Adjust times and dates from this time `zone` into -time-zone-to (default UTC)")
 			fs.Var(&cctx.ToZone, "time-zone-to", "Adjust times and dates into this time `zone` from -time-zone-from (default UTC)")
+			fs.Var(&cctx.Record, "record", "Open record `number` (starting from 0) of one ADI or ADX file in $EDITOR and save changes to the file")
 			ctx.CommandCtx = &cctx
 		}}
 
+	filterConf = cmdConfig{Command: cmd.Filter}
+
 	findConf = cmdConfig{Command: cmd.Find,
 		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
 			cctx := cmd.FindContext{}
@@ -81,6 +120,15 @@ var (
 			ctx.CommandCtx = &cctx
 		}}
 
+	generateSpecConf = cmdConfig{Command: cmd.GenerateSpec}
+
+	headConf = cmdConfig{Command: cmd.Head,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.HeadContext{}
+			fs.IntVar(&cctx.Count, "count", 10, "Print the first `num` records; if negative, print all but the last -num records")
+			ctx.CommandCtx = &cctx
+		}}
+
 	helpConf = cmdConfig{Command: cmd.Command{
 		Name: "help", Description: "Print program or command usage information",
 		Run: func(*cmd.Context, []string) error {
@@ -93,6 +141,28 @@ var (
 			cctx := cmd.InferContext{}
 			fs.Var(&cctx.Fields, "fields", "Comma-separated or multiple instance field `names` to infer if absent")
 			fs.BoolVar(&cctx.CommentLog, "comment-log", false, "Add record comments with a list of successfully inferred fields")
+			fs.Var(&cctx.InferFrom, "infer-from", "Comma-separated or multiple instance free-text field `names` to search for values like CW speed (default COMMENT,NOTES)")
+			ctx.CommandCtx = &cctx
+		}}
+
+	lookupConf = cmdConfig{Command: cmd.Lookup,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.LookupContext{}
+			fs.StringVar(&cctx.CQZone, "cq-zone", "", "Print CQ zones for DXCC `entity` code or name")
+			fs.StringVar(&cctx.ITUZone, "itu-zone", "", "Print ITU zones for DXCC `entity` code or name")
+			fs.StringVar(&cctx.DXCCForCallsign, "dxcc-for-callsign", "", "Print possible DXCC entities for `callsign` prefix")
+			fs.StringVar(&cctx.ISOFromDXCC, "iso-from-dxcc", "", "Print ISO 3166-1 country codes for DXCC `entity` code or name")
+			ctx.CommandCtx = &cctx
+		}}
+
+	normalizeConf = cmdConfig{Command: cmd.Normalize}
+
+	sampleConf = cmdConfig{Command: cmd.Sample,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.SampleContext{}
+			fs.IntVar(&cctx.Count, "count", 0, "Select `num` records at random")
+			fs.Float64Var(&cctx.Fraction, "fraction", 0, "Select each record with probability `p` between 0 and 1")
+			fs.Int64Var(&cctx.Seed, "seed", 0, "Random `number` seed for a reproducible sample; 0 selects differently each run")
 			ctx.CommandCtx = &cctx
 		}}
 
@@ -120,6 +190,29 @@ var (
 			ctx.CommandCtx = &cctx
 		}}
 
+	summaryConf = cmdConfig{Command: cmd.Summary,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.SummaryContext{}
+			fs.StringVar(&cctx.Contest, "contest", "", "Contest `id` for scoring rules, e.g. CQ-WW-CW (default from CONTEST_ID field)")
+			ctx.CommandCtx = &cctx
+		}}
+
+	tailConf = cmdConfig{Command: cmd.Tail,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.TailContext{}
+			fs.IntVar(&cctx.Count, "count", 10, "Print the last `num` records; if negative, print all but the first -num records")
+			ctx.CommandCtx = &cctx
+		}}
+
+	teeConf = cmdConfig{Command: cmd.Tee,
+		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
+			cctx := cmd.TeeContext{}
+			fs.Var(&cctx.Outputs, "outputs", "Comma-separated or multiple instance `[format:]file` to write in addition to standard output; format may also be a --preset name")
+			fs.BoolVar(&cctx.CreateDirectory, "create-dirs", false, "Create any needed parent directories of the output file(s)")
+			fs.BoolVar(&cctx.OverwriteExisting, "overwrite-existing", false, "Overwrite output files if they already exist")
+			ctx.CommandCtx = &cctx
+		}}
+
 	validateConf = cmdConfig{Command: cmd.Validate,
 		Configure: func(ctx *cmd.Context, fs *flag.FlagSet) {
 			cctx := cmd.ValidateContext{RequiredFields: make(cmd.FieldList, 0, 16)}
@@ -128,6 +221,33 @@ var (
 			fs.Var(cctx.Cond.OrIfFlag(), "or-if", "Only check required-fields when `condition` is true or any previous --if group is true (repeatable)")
 			fs.Var(cctx.Cond.OrIfNotFlag(), "or-if-not", "Only check required-fields when `condition` is false or any previous --if group is true (repeatable)")
 			fs.Var(&cctx.RequiredFields, "required-fields", "Field `names` which must be present and non-empty in a valid record")
+			fs.Var(&cctx.Severity, "severity", "Only print problems with `level` (warning or error)")
+			fs.Var(&cctx.MinSeverity, "min-severity", "Only print problems at or above `level` (warning or error)")
+			fs.Var(&cctx.FailOn, "fail-on", "Exit with an error status if any problem is at or above `level` (warning or error, default error)")
+			fs.BoolVar(&cctx.Quiet, "quiet", false, "Only print the summary of errors and warnings, not each problem")
+			fs.BoolVar(&cctx.Verbose, "verbose", false, "Also print fields without problems")
+			fs.BoolVar(&cctx.AllowCallsignVariation, "allow-callsign-variation", false, "Don't warn if STATION_CALLSIGN changes within a file, e.g. for multi-op logs")
+			fs.BoolVar(&cctx.CheckGeoPlausibility, "check-geo-plausibility", false, "Warn if GRIDSQUARE or MY_GRIDSQUARE is far from the DXCC entity")
+			fs.BoolVar(&cctx.CheckModeBand, "check-mode-band", false, "Warn about modes which are unusual on the record's band, e.g. FM on 40m")
+			fs.BoolVar(&cctx.CheckSOTA, "check-sota", false, "Warn about records missing MY_SOTA_REF in a SOTA activation log")
+			fs.IntVar(&cctx.MinFreqPrecision, "min-freq-precision", 0, "Warn if FREQ or FREQ_RX has fewer than `n` decimal places, e.g. 3 for kHz precision")
+			fs.IntVar(&cctx.MaxFreqPrecision, "max-freq-precision", 0, "Warn if FREQ or FREQ_RX has more than `n` decimal places, e.g. 6 for Hz precision")
+			fs.BoolVar(&cctx.CheckAppFields, "check-app-fields", false, "Warn about APP_ field names which don't match APP_PROGRAMID_FIELDNAME or the PROGRAMID header")
+			fs.BoolVar(&cctx.CheckPowerLimits, "check-power-limits", false, "Warn if TX_PWR is more than the legal limit for the band (United States only)")
+			fs.StringVar(&cctx.DXCC, "dxcc", "", "Logging station's DXCC entity `code` for --check-power-limits if MY_DXCC is not set")
+			fs.BoolVar(&cctx.CheckPrecision, "check-precision", false, "Warn if DISTANCE is more precise than a 4-character GRIDSQUARE or MY_GRIDSQUARE supports")
+			fs.DurationVar(&cctx.MinQSODuration, "min-qso-duration", 0, "Warn if TIME_OFF is less than `duration` after TIME_ON (at least 15s for FT8)")
+			fs.DurationVar(&cctx.MaxQSODuration, "max-qso-duration", 0, "Warn if TIME_OFF is more than `duration` after TIME_ON")
+			fs.BoolVar(&cctx.CheckDups, "check-dups", false, "Warn about duplicate records with the same CALL, QSO_DATE, TIME_ON, BAND, and MODE")
+			fs.Var(&cctx.DupKey, "dup-key", "Comma-separated or multiple instance field `names` which identify duplicate records, implies --check-dups")
+			fs.DurationVar(&cctx.DupTimeTolerance, "dup-time-tolerance", 0, "Treat records with matching --dup-key fields and QSO_DATE+TIME_ON within `duration` as duplicates, implies --check-dups")
+			fs.Var(&cctx.UniqueIDFields, "check-id-uniqueness", "Report an error if `fields` (comma-separated or repeatable) have the same value in more than one record")
+			fs.Var(&cctx.FieldValues, "validate-field", "Validate `field=value` without reading input files, printing the result (repeatable)")
+			fs.BoolVar(&cctx.CheckSerials, "check-serials", false, "Check that STX serial numbers count up from 1 without gaps or duplicates")
+			fs.BoolVar(&cctx.WarnLocalTime, "warn-local-time", false, "Warn if most contacts would be in the middle of the night at the station's location, suggesting TIME_ON is not UTC")
+			fs.BoolVar(&cctx.POTAAPI, "pota-api", false, "Check that POTA_REF and MY_POTA_REF parks exist with the Parks on the Air API (requires network)")
+			fs.StringVar(&cctx.SOTADBPath, "sota-db-path", "", "SOTA summits list CSV `file` for checking that SOTA_REF and MY_SOTA_REF exist")
+			fs.StringVar(&cctx.WWFFDBPath, "wwff-db-path", "", "WWFF directory CSV `file` for checking that WWFF_REF and MY_WWFF_REF exist")
 			ctx.CommandCtx = &cctx
 		}}
 
@@ -142,22 +262,71 @@ var (
 		}}}
 
 	cmds = []cmdConfig{
+		annotateConf,
 		catConf,
+		convertConf,
 		countConf,
 		editConf,
+		filterConf,
 		findConf,
 		fixConf,
 		flattenConf,
+		generateSpecConf,
+		headConf,
 		helpConf,
 		inferConf,
+		lookupConf,
+		normalizeConf,
+		sampleConf,
 		saveConf,
 		selectConf,
 		sortConf,
+		summaryConf,
+		tailConf,
+		teeConf,
 		validateConf,
 		versionConf,
+		watchConf,
 	}
+
+	// watch uses ConfigureCommand to also add flags for the command it runs
+	watchConf = cmdConfig{Command: cmd.Watch, ConfigureCommand: configureWatch}
 )
 
+// configureWatch adds flags for sub, so they can be given to watch, and then
+// the flags for watch itself.
+func configureWatch(ctx *cmd.Context, fs *flag.FlagSet, sub cmdConfig) {
+	cctx := cmd.WatchContext{CommandName: sub.Name}
+	if sub.Run != nil && sub.ConfigureCommand == nil {
+		if sub.Configure != nil {
+			sub.Configure(ctx, fs)
+		}
+		cctx.Command = sub.Command
+		cctx.CommandCtx = ctx.CommandCtx
+	}
+	fs.StringVar(&cctx.CommandName, "command", cctx.CommandName, "`name` of the command to run on new records, options for that command can also be given")
+	fs.DurationVar(&cctx.Interval, "interval", time.Second, "`duration` between checks for new records")
+	fs.BoolVar(&cctx.FromStart, "from-start", false, "Process records already in the file, not just new ones")
+	ctx.CommandCtx = &cctx
+}
+
+// commandFlag finds the --command flag value before flags are parsed.
+func commandFlag(args []string) string {
+	for i, a := range args {
+		name := strings.TrimLeft(a, "-")
+		if name == a {
+			continue
+		}
+		if name == "command" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(name, "command=") {
+			return strings.TrimPrefix(name, "command=")
+		}
+	}
+	return "cat"
+}
+
 func commandNamed(name string) (cmdConfig, bool) {
 	for _, c := range cmds {
 		if c.Name == name {
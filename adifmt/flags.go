@@ -16,6 +16,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"unicode/utf8"
 
@@ -54,6 +55,10 @@ type mapValue struct {
 	m       map[string]string
 	key     string
 	allowed []string
+	// if warn is not nil, values not in allowed are accepted with a warning
+	// which mentions label
+	warn  io.Writer
+	label string
 }
 
 func (v *mapValue) String() string {
@@ -63,7 +68,10 @@ func (v *mapValue) String() string {
 func (v *mapValue) Set(s string) error {
 	val := strings.ToUpper(s)
 	if !slices.Contains(v.allowed, val) {
-		return fmt.Errorf("%q is not in %s", val, strings.Join(v.allowed, ", "))
+		if v.warn == nil {
+			return fmt.Errorf("%q is not in %s", val, strings.Join(v.allowed, ", "))
+		}
+		fmt.Fprintf(v.warn, "Warning: %q is not a known %s value, expected one of %s\n", val, v.label, strings.Join(v.allowed, ", "))
 	}
 	v.m[v.key] = val
 	return nil
@@ -73,6 +81,31 @@ func (v *mapValue) Get() any {
 	return v.String()
 }
 
+// fieldMapValue sets "From=TO" pairs in a map of upper case column names to
+// ADIF field names.  Pairs can be comma-separated or repeated.
+type fieldMapValue struct{ m map[string]string }
+
+func (v fieldMapValue) String() string {
+	res := make([]string, 0, len(v.m))
+	for k, f := range v.m {
+		res = append(res, k+"="+f)
+	}
+	slices.Sort(res)
+	return strings.Join(res, ",")
+}
+
+func (v fieldMapValue) Set(s string) error {
+	for _, p := range strings.Split(s, ",") {
+		from, to, found := strings.Cut(p, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !found || from == "" || to == "" {
+			return fmt.Errorf(`expected "Column=FIELD_NAME", got %q`, p)
+		}
+		v.m[strings.ToUpper(from)] = strings.ToUpper(to)
+	}
+	return nil
+}
+
 type languageValue struct{ *language.Tag }
 
 func (v *languageValue) Set(s string) error {
@@ -18,25 +18,40 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/flwyd/adif-multitool/adif"
 	"github.com/flwyd/adif-multitool/adif/spec"
+	"github.com/flwyd/adif-multitool/cmd"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 type formatConfig interface {
 	Format() adif.Format
-	IO() adif.ReadWriter
+	// IO returns an adif.Writer, which is also an adif.Reader unless the format
+	// is output-only.
+	IO() adif.Writer
 	AddFlags(fs *flag.FlagSet)
 	Help() string
 }
 
+var (
+	// ADIZ files use the same ADI and ADX options
+	adiIO = adif.NewADIIO()
+	adxIO = adif.NewADXIO()
+)
+
 var formatConfigs = []formatConfig{
-	adiConfig{adif.NewADIIO()},
-	adxConfig{adif.NewADXIO()},
+	adiConfig{adiIO},
+	adizConfig{adif.NewADIZIO(adiIO, adxIO)},
+	adxConfig{adxIO},
 	cabrilloConfig{adif.NewCabrilloIO()},
 	csvConfig{adif.NewCSVIO()},
+	ediConfig{adif.NewEDIIO()},
 	jsonConfig{adif.NewJSONIO()},
+	markdownConfig{adif.NewMarkdownWriter()},
 	tsvConfig{adif.NewTSVIO()},
 }
 
@@ -53,13 +68,16 @@ type adiConfig struct{ io *adif.ADIIO }
 
 func (c adiConfig) Format() adif.Format { return adif.FormatADI }
 
-func (c adiConfig) IO() adif.ReadWriter { return c.io }
+func (c adiConfig) IO() adif.Writer { return c.io }
 
 func (c adiConfig) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.io.ASCIIOnly, "adi-ascii-only", false,
 		"ADI files: error on any non-ASCII characters, instead of writing UTF-8")
 	fs.BoolVar(&c.io.LowerCase, "adi-lower-case", false,
 		"ADI files: print tags in lower case instead of upper case")
+	fs.BoolVar(&c.io.Lenient, "adi-lenient", false,
+		"ADI files: warn rather than fail on missing <EOR> and extra <EOH> tags")
+	c.io.Warnings = os.Stderr
 	sepHelp := "options: " + strings.Join(adif.SeparatorNames(), ", ")
 	fs.Var(&c.io.FieldSep, "adi-field-separator",
 		"ADI files: field `separator`\n"+sepHelp)
@@ -76,14 +94,30 @@ ADIF specification.
 `
 }
 
+type adizConfig struct{ io *adif.ADIZIO }
+
+func (c adizConfig) Format() adif.Format { return adif.FormatADIZ }
+
+func (c adizConfig) IO() adif.Writer { return c.io }
+
+func (c adizConfig) AddFlags(fs *flag.FlagSet) {}
+
+func (c adizConfig) Help() string {
+	return `ADIZ is a ZIP archive containing a single ADI or ADX file, which makes large
+logs smaller to store and share.  ADI and ADX options apply to the file inside
+the archive.  Output archives contain one ADI file named log.adi.
+`
+}
+
 type adxConfig struct{ io *adif.ADXIO }
 
 func (c adxConfig) Format() adif.Format { return adif.FormatADX }
 
-func (c adxConfig) IO() adif.ReadWriter { return c.io }
+func (c adxConfig) IO() adif.Writer { return c.io }
 
 func (c adxConfig) AddFlags(fs *flag.FlagSet) {
 	fs.IntVar(&c.io.Indent, "adx-indent", 1, "ADX files: indent nested XML structures `n` spaces, 0 for no whitespace")
+	fs.BoolVar(&c.io.ValidateSchema, "adx-validate-schema", false, "ADX files: check output is well-formed and follows the ADX schema structure before writing")
 }
 
 func (c adxConfig) Help() string {
@@ -97,27 +131,46 @@ type cabrilloConfig struct{ io *adif.CabrilloIO }
 
 func (c cabrilloConfig) Format() adif.Format { return adif.FormatCabrillo }
 
-func (c cabrilloConfig) IO() adif.ReadWriter { return c.io }
+func (c cabrilloConfig) IO() adif.Writer { return c.io }
 
 func (c cabrilloConfig) AddFlags(fs *flag.FlagSet) {
 	c.io.CreatedBy = "ADIF Multitool " + version
-	fs.BoolVar(&c.io.TabDelimiter, "cabrillo-delimiter-tab", false, "Cabrillo files: use tabs rather than space-aligned columns")
+	c.io.ScorerFor = cmd.CabrilloScorer
+	fs.BoolVar(&c.io.TabDelimiter, "cabrillo-tab-delimiter", false, "Cabrillo files: use tabs rather than space-aligned columns")
+	fs.BoolVar(&c.io.TabDelimiter, "cabrillo-delimiter-tab", false, "Cabrillo files: alias for --cabrillo-tab-delimiter")
 	fs.IntVar(&c.io.LowPowerMax, "cabrillo-max-power-low", c.io.LowPowerMax, "Higest allowed power in `watts` considered LOW power by the contest")
 	fs.IntVar(&c.io.QRPPowerMax, "cabrillo-max-power-qrp", c.io.QRPPowerMax, "Higest alqrped power in `watts` considered QRP power by the contest")
 	fs.StringVar(&c.io.Callsign, "cabrillo-callsign", "", "Cabrillo files: CALLSIGN header `value`")
-	fs.IntVar(&c.io.ClaimedScore, "cabrillo-claimed-score", 0, "Cabrillo files: CLAIMED-SCORE header `value`")
+	fs.IntVar(&c.io.ClaimedScore, "cabrillo-claimed-score", 0, "Cabrillo files: CLAIMED-SCORE header `value`, computed for contests known by the summary command if not set")
 	fs.StringVar(&c.io.Club, "cabrillo-club", "", "Cabrillo files: CLUB header `value`")
 	// TODO Operators (string slice)
 	fs.StringVar(&c.io.Contest, "cabrillo-contest", "", "Cabrillo files: CONTEST header `value`")
+	fs.BoolVar(&c.io.CheckExchange, "cabrillo-check-exchange", false, "Cabrillo files: check that STX_STRING and SRX_STRING match the --cabrillo-contest exchange for some contests")
 	fs.StringVar(&c.io.Email, "cabrillo-email", "", "Cabrillo files: EMAIL address header `value`")
 	fs.StringVar(&c.io.GridLocator, "cabrillo-grid-locator", "", "Cabrillo files: GRID-LOCATOR header `value`")
 	fs.StringVar(&c.io.Location, "cabrillo-location", "", "Cabrillo files: LOCATION header `value` (e.g. ARRL section)")
 	fs.StringVar(&c.io.Name, "cabrillo-name", "", "Cabrillo files: NAME header `value` (your name or club name)")
 	fs.StringVar(&c.io.Address, "cabrillo-address", "", "Cabrillo files: ADDRESS header `value` (include newlines)")
-	fs.StringVar(&c.io.Soapbox, "cabrillo-soapbox", "", "Cabrillo files: SOAPBOX header `value` (free-form comment)")
-	// TODO MinReportedOfftime (duration)
+	fs.Func("cabrillo-soapbox", "Cabrillo files: SOAPBOX header `value` (free-form comment), repeatable for multiple lines", func(s string) error {
+		if c.io.Soapbox != "" {
+			c.io.Soapbox += "\n"
+		}
+		c.io.Soapbox += s
+		return nil
+	})
+	fs.DurationVar(&c.io.MinReportedOfftime, "cabrillo-min-offtime", 0, "Cabrillo files: add OFFTIME headers for gaps between QSOs at least this `duration`, e.g. 30m or 1h")
 	fs.Var(&c.io.MyExchange, "cabrillo-my-exchange", "Cabrillo files: `field` ("+adif.CabrilloFieldExample+") configuration of my exchange, repeatable")
 	fs.Var(&c.io.TheirExchange, "cabrillo-their-exchange", "Cabrillo files: `field` ("+adif.CabrilloFieldExample+") configuration of their exchange, repeatable")
+	templates := maps.Keys(adif.CabrilloTemplates)
+	slices.Sort(templates)
+	fs.Func("cabrillo-template", "Cabrillo files: exchange configuration `name` for a popular contest ("+strings.Join(templates, ", ")+"), overridden by exchange flags", func(s string) error {
+		t, ok := adif.CabrilloTemplates[strings.ToUpper(s)]
+		if !ok {
+			return fmt.Errorf("unknown Cabrillo template %q, known templates: %s", s, strings.Join(templates, ", "))
+		}
+		c.io.Template = t
+		return nil
+	})
 	fs.Var(&c.io.ExtraFields, "cabrillo-extra-field", "Cabrillo files: `field` added at the end of QSO lines, repeatable, e.g. APP_CABRILLO_TRANSMITTER_ID")
 	// TODO delete deprecated flags
 	fs.Func("cabrillo-my-exchange-field", "Deprecated", func(_ string) error {
@@ -130,9 +183,11 @@ func (c cabrilloConfig) AddFlags(fs *flag.FlagSet) {
 		return errors.New("--cabrillo-their-exchange-field-alt has been replaced with --cabrillo-their-exchange")
 	})
 	for v, a := range adif.CabrilloCategoryValues {
-		fs.Var(&mapValue{c.io.Categories, v, a},
-			"cabrillo-category-"+strings.ToLower(v),
+		mv := &mapValue{m: c.io.Categories, key: v, allowed: a, warn: os.Stderr, label: "CATEGORY-" + v}
+		fs.Var(mv, "cabrillo-"+strings.ToLower(v),
 			fmt.Sprintf("Cabrillo files: CATEGORY-%s header `value` (%s)", v, strings.Join(a, ", ")))
+		fs.Var(mv, "cabrillo-category-"+strings.ToLower(v),
+			fmt.Sprintf("Cabrillo files: alias for --cabrillo-%s", strings.ToLower(v)))
 	}
 }
 
@@ -147,8 +202,17 @@ Fields are printed in QSO: lines separated by space.  The field header is
 printed in a comment above the field column.  If multiple ADIF fields are
 separated by / the Cabrillo QSO will include the first non-blank field value.
 At least one field must have a value unless the ? suffix is given or a default
-value is specified after an = character.  For contest exchange examples, see
-` + helpUrl + `#cabrillo
+value is specified after an = character.  Examples for popular contests:
+  CQ WW:        --cabrillo-my-exchange=rst:RST_SENT=599 \
+                --cabrillo-my-exchange=zone:CQZ/STX_STRING
+  ARRL DX:      --cabrillo-my-exchange=rst:RST_SENT=599 \
+                --cabrillo-my-exchange=state:MY_ARRL_SECT
+  Sweepstakes:  --cabrillo-my-exchange=precedence:STX_STRING \
+                --cabrillo-their-exchange=check:SRX_STRING/ARRL_SECT
+The --cabrillo-template option configures both exchanges for some contests,
+e.g. --cabrillo-template=CQ-WW-DX; --cabrillo-my-exchange and
+--cabrillo-their-exchange replace the template's values for that side.
+For more contest exchange examples, see ` + helpUrl + `#cabrillo
 `
 }
 
@@ -156,7 +220,7 @@ type csvConfig struct{ io *adif.CSVIO }
 
 func (c csvConfig) Format() adif.Format { return adif.FormatCSV }
 
-func (c csvConfig) IO() adif.ReadWriter { return c.io }
+func (c csvConfig) IO() adif.Writer { return c.io }
 
 func (c csvConfig) AddFlags(fs *flag.FlagSet) {
 	// TODO csv-lower-case
@@ -167,13 +231,16 @@ func (c csvConfig) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.io.RequireFullRecord, "csv-require-all-fields", false, "CSV files: error if fewer fields in a record than in header")
 	fs.BoolVar(&c.io.TrimLeadingSpace, "csv-trim-space", false, "CSV files: ignore leading space in fields")
 	fs.BoolVar(&c.io.CRLF, "csv-crlf", false, "CSV files: output MS Windows line endings")
+	fs.Var(&c.io.Quote, "csv-quote", "CSV files: quoting `style` for output values\noptions: "+strings.Join(adif.CSVQuoteNames(), ", "))
 	fs.BoolVar(&c.io.OmitHeader, "csv-omit-header", false, "CSV files: don't output the header line")
+	fs.Var(fieldMapValue{c.io.FieldMap}, "csv-field-map", "CSV files: rename input `columns` to ADIF fields, e.g. Date=QSO_DATE,Callsign=CALL (repeatable)")
 }
 
 func (c csvConfig) Help() string {
 	return `CSV (comma-separated values) is a widely-used format for sharing tabular data
 defined at https://datatracker.ietf.org/doc/html/rfc4180
-ADIF field names (case-insensitive) must appear in the first line.  Values
+ADIF field names (case-insensitive) must appear in the first line, or be
+mapped from other column names with --csv-field-map.  Values
 must be surrounded by double quotes (") if they contain commas, line breaks,
 or double quotes, which are repeated ("") as an escape.  Input is not required
 to have the same number of values in each line; output will have one value for
@@ -183,11 +250,36 @@ in a spreadsheet program or transcribed from paper logs.
 `
 }
 
+type ediConfig struct{ io *adif.EDIIO }
+
+func (c ediConfig) Format() adif.Format { return adif.FormatEDI }
+
+func (c ediConfig) IO() adif.Writer { return c.io }
+
+func (c ediConfig) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.io.Callsign, "edi-callsign", "", "EDI files: PCall header `value`, default STATION_CALLSIGN")
+	fs.StringVar(&c.io.Club, "edi-club", "", "EDI files: PClub header `value`")
+	fs.StringVar(&c.io.Contest, "edi-contest", "", "EDI files: TName header `value`, default CONTEST_ID")
+	fs.StringVar(&c.io.Exchange, "edi-exchange", "", "EDI files: PExch header `value`, default STX_STRING")
+	fs.StringVar(&c.io.Locator, "edi-locator", "", "EDI files: PWWLo header `value`, default MY_GRIDSQUARE")
+	fs.StringVar(&c.io.Section, "edi-section", "", "EDI files: PSect header `value` (contest category)")
+}
+
+func (c ediConfig) Help() string {
+	return `EDI (REG1TEST) is a text file format for VHF, UHF, and microwave contest logs
+in IARU Region 1, described at https://www.ok2kkw.com/ediformat.htm
+An EDI file covers a single band.  QSO lines use QSO_DATE, TIME_ON, CALL, MODE,
+RST_SENT, STX, RST_RCVD, SRX, SRX_STRING, and GRIDSQUARE.  Headers which are
+not set by options or inferred from the log can be set with APP_EDI_ header
+fields, e.g. APP_EDI_RCALL.
+`
+}
+
 type jsonConfig struct{ io *adif.JSONIO }
 
 func (c jsonConfig) Format() adif.Format { return adif.FormatJSON }
 
-func (c jsonConfig) IO() adif.ReadWriter { return c.io }
+func (c jsonConfig) IO() adif.Writer { return c.io }
 
 func (c jsonConfig) AddFlags(fs *flag.FlagSet) {
 	// TODO json-lower-case
@@ -206,11 +298,31 @@ properties of the header and record objects and all values are strings unless
 `
 }
 
+type markdownConfig struct{ io *adif.MarkdownWriter }
+
+func (c markdownConfig) Format() adif.Format { return adif.FormatMarkdown }
+
+func (c markdownConfig) IO() adif.Writer { return c.io }
+
+func (c markdownConfig) AddFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.io.MaxWidth, "markdown-max-width", 0, "Markdown output: truncate values longer than `n` characters with ..., 0 for no limit")
+}
+
+func (c markdownConfig) Help() string {
+	return `Markdown tables are a convenient way to share a few records in a forum post,
+chat message, or documentation page.  Field names are column headers and
+each record is one row.  Markdown is an output-only format; use the select
+command to choose which fields to include and --markdown-max-width to shorten
+long fields like COMMENT and NOTES.  Line breaks in values are replaced with
+spaces.
+`
+}
+
 type tsvConfig struct{ io *adif.TSVIO }
 
 func (c tsvConfig) Format() adif.Format { return adif.FormatTSV }
 
-func (c tsvConfig) IO() adif.ReadWriter { return c.io }
+func (c tsvConfig) IO() adif.Writer { return c.io }
 
 func (c tsvConfig) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.io.CRLF, "tsv-crlf", false, "TSV files: output MS Windows line endings")
@@ -102,11 +102,18 @@ func runMain(prepare func(l *adif.Logfile)) int {
 		fmt.Fprintf(os.Stderr, "Run %s help for more details\n", os.Args[0])
 		return 2
 	}
-	if c.Configure != nil {
-		c.Configure(ctx, fs)
-	}
 	// filenames can come before or after flags, but not interspersed
 	args := os.Args[2:]
+	if c.ConfigureCommand != nil {
+		name := commandFlag(args)
+		sub, ok := commandNamed(name)
+		if !ok {
+			sub.Name = name
+		}
+		c.ConfigureCommand(ctx, fs, sub)
+	} else if c.Configure != nil {
+		c.Configure(ctx, fs)
+	}
 	firstflag := slices.IndexFunc(args, func(s string) bool { return strings.HasPrefix(s, "-") })
 	if firstflag < 0 {
 		firstflag = len(args)
@@ -115,6 +122,9 @@ func runMain(prepare func(l *adif.Logfile)) int {
 	args = args[firstflag:]
 	fs.Parse(args)
 	nonflags = append(nonflags, fs.Args()...)
+	if ctx.BatchSize > 0 && !c.Batches {
+		fmt.Fprintf(os.Stderr, "Warning: --batch-size has no effect on %s, reading all records\n", c.Name)
+	}
 	err := c.Run(ctx, nonflags)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running %s: %v\n", name, err)
@@ -140,12 +150,23 @@ func buildContext(fs *flag.FlagSet, prepare func(l *adif.Logfile)) *cmd.Context
 		Prepare: prepare,
 	}
 	for _, f := range formatConfigs {
-		ctx.Readers[f.Format()] = f.IO()
+		if r, ok := f.IO().(adif.Reader); ok {
+			ctx.Readers[f.Format()] = r
+		}
 		ctx.Writers[f.Format()] = f.IO()
 	}
 
 	// General flags
 	fmtopts := "options: " + strings.Join(adif.FormatNames(), ", ")
+	fs.IntVar(&ctx.BatchSize, "batch-size", 0,
+		"Process and write ADI output `records` at a time to save memory, for select and validate")
+	fs.Func("compress", "Compress output with `method` gzip", func(s string) error {
+		if !strings.EqualFold(s, "gzip") {
+			return fmt.Errorf("unknown compression method %q, only gzip is supported", s)
+		}
+		ctx.Compress = "gzip"
+		return nil
+	})
 	fs.Var(&ctx.FieldOrder, "field-order", "Comma-separated `field` order for output (repeatable)")
 	fs.Var(&ctx.InputFormat, "input",
 		"input `format` when it cannot be inferred from file extension\n"+fmtopts)
@@ -153,6 +174,16 @@ func buildContext(fs *flag.FlagSet, prepare func(l *adif.Logfile)) *cmd.Context
 		"output `format` written to stdout\n"+fmtopts)
 	fs.Var(&languageValue{Tag: &ctx.Locale}, "locale",
 		"BCP-47 `language` code for IntlString comparisons e.g. da, pt-BR, zh-Hant")
+	fs.BoolVar(&ctx.OmitEmpty, "omit-empty", false,
+		"Don't output fields with empty values in ADI and ADX records")
+	ctx.SetFields = cmd.NewFieldAssignments(cmd.ValidateAlphanumName)
+	fs.Var(&ctx.SetFields, "set", "Set `field=value` in all output records (repeatable)")
+	ctx.SetIfEmpty = cmd.NewFieldAssignments(cmd.ValidateAlphanumName)
+	fs.Var(&ctx.SetIfEmpty, "set-if-empty", "Set `field=value` in output records where field is blank or not set (repeatable)")
+	fs.Var(&ctx.Preset, "preset",
+		"Adjust input and output for a service's ADIF conventions with preset `name`\noptions: "+presetHelp())
+	fs.BoolVar(&ctx.ShowProgress, "progress", false,
+		"Print progress reading and writing large files to standard error")
 	fs.BoolVar(&ctx.SuppressAppHeaders, "suppress-app-headers", false,
 		"Don't output app-defined headers, to comply with ADIF 3.1.4 spec")
 	fs.Var(&ctx.UserdefFields, "userdef",
@@ -160,6 +191,15 @@ func buildContext(fs *flag.FlagSet, prepare func(l *adif.Logfile)) *cmd.Context
 	return ctx
 }
 
+func presetHelp() string {
+	names := cmd.PresetNames()
+	s := make([]string, len(names))
+	for i, n := range names {
+		s[i] = fmt.Sprintf("%s (%s)", n, cmd.Presets[n].Description)
+	}
+	return strings.Join(s, ", ")
+}
+
 func usage(fs *flag.FlagSet, term string) func() {
 	return func() {
 		out := fs.Output()
@@ -175,7 +215,10 @@ func usage(fs *flag.FlagSet, term string) func() {
 		if c, ok := commandNamed(term); ok {
 			fmt.Fprintf(out, "%s: %s\n", c.Name, c.Description)
 			cfs := flag.NewFlagSet(term, flag.ContinueOnError)
-			if c.Configure != nil {
+			if c.ConfigureCommand != nil {
+				sub, _ := commandNamed(commandFlag(nil))
+				c.ConfigureCommand(&cmd.Context{}, cfs, sub)
+			} else if c.Configure != nil {
 				c.Configure(&cmd.Context{}, cfs)
 			}
 			cfs.SetOutput(out)
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+var Annotate = Command{Name: "annotate", Run: runAnnotate, Help: helpAnnotate,
+	Description: "Add fields from a lookup table with matching key fields"}
+
+type AnnotateContext struct {
+	// Lookup is the name of a file in any supported format with key fields and
+	// fields to add.
+	Lookup string
+	// Key fields must match (case-insensitive) between a record and a row in
+	// the lookup table.  Defaults to CALL.
+	Key FieldList
+	// Add is the list of fields to copy from the lookup table; if empty, all
+	// non-key fields are copied.
+	Add FieldList
+	// Overwrite replaces non-empty record fields with lookup table values.
+	Overwrite bool
+	// WarnMissing prints a warning for records not in the lookup table.
+	WarnMissing bool
+}
+
+func helpAnnotate() string {
+	return `The lookup file can be in any input format, e.g. a CSV club roster with CALL
+and NAME columns.  Records are matched to the lookup table by the --key fields,
+ignoring case and surrounding space.  By default, only empty or missing fields
+are set; use --overwrite to replace existing values.  Records which don't match
+any lookup table entry are left unchanged.
+`
+}
+
+func runAnnotate(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*AnnotateContext)
+	if cctx.Lookup == "" {
+		return errors.New("annotate requires a --lookup file")
+	}
+	key := cctx.Key
+	if len(key) == 0 {
+		key = FieldList{spec.CallField.Name}
+	}
+	table, add, err := readLookupTable(ctx, cctx.Lookup, key, cctx.Add)
+	if err != nil {
+		return err
+	}
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		updateFieldOrder(acc.Out, add)
+		for i, r := range l.Records {
+			k, ok := lookupKey(r, key)
+			row := table[k]
+			if !ok || row == nil {
+				if cctx.WarnMissing {
+					fmt.Fprintf(os.Stderr, "Warning: %s record %d: %s %q not in %s\n", l, i+1, strings.Join(key, ","), strings.ReplaceAll(k, "\x00", ","), cctx.Lookup)
+				}
+				acc.Out.AddRecord(r)
+				continue
+			}
+			for _, n := range add {
+				v, ok := row.Get(n)
+				if !ok || v.Value == "" {
+					continue
+				}
+				if !cctx.Overwrite {
+					if e, ok := r.Get(n); ok && e.Value != "" {
+						continue
+					}
+				}
+				if err := r.Set(adif.Field{Name: n, Value: v.Value, Type: v.Type}); err != nil {
+					return err
+				}
+			}
+			acc.Out.AddRecord(r)
+		}
+	}
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
+
+// readLookupTable reads a logfile and returns its records by key, along with
+// the fields to add: either the add argument or all non-key fields.
+func readLookupTable(ctx *Context, filename string, key, add FieldList) (map[string]*adif.Record, []string, error) {
+	// --input applies to log files, the lookup table format is inferred
+	lctx := *ctx
+	lctx.InputFormat = ""
+	l, err := readFile(&lctx, filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(add) == 0 {
+		iskey := make(map[string]bool)
+		for _, k := range key {
+			iskey[strings.ToUpper(k)] = true
+		}
+		order := l.FieldOrder
+		for _, r := range l.Records {
+			for _, f := range r.Fields() {
+				order = append(order, f.Name)
+			}
+		}
+		seen := make(map[string]bool)
+		for _, n := range order {
+			n = strings.ToUpper(n)
+			if !iskey[n] && !seen[n] {
+				add = append(add, n)
+				seen[n] = true
+			}
+		}
+	}
+	table := make(map[string]*adif.Record)
+	for i, r := range l.Records {
+		k, ok := lookupKey(r, key)
+		if !ok {
+			continue
+		}
+		if _, dup := table[k]; dup {
+			return nil, nil, fmt.Errorf("%s record %d: duplicate %s %q", filename, i+1, strings.Join(key, ","), strings.ReplaceAll(k, "\x00", ","))
+		}
+		table[k] = r
+	}
+	return table, add, nil
+}
+
+// lookupKey returns the normalized values of key fields, or false if all are
+// empty.
+func lookupKey(r *adif.Record, key FieldList) (string, bool) {
+	vals := make([]string, len(key))
+	ok := false
+	for i, k := range key {
+		f, _ := r.Get(k)
+		vals[i] = strings.ToUpper(strings.TrimSpace(f.Value))
+		if vals[i] != "" {
+			ok = true
+		}
+	}
+	return strings.Join(vals, "\x00"), ok
+}
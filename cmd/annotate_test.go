@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnnotate(t *testing.T) {
+	csv := adif.NewCSVIO()
+	lookup := `CALL,NAME,COUNTRY,GRIDSQUARE
+w1aw,Hiram,United States of America,FN31
+K9ABC,,Canada,FN25
+`
+	log := `CALL,NAME,BAND
+W1AW,,20m
+k9abc,Bob,40m
+N0CALL,Nobody,2m
+`
+	tests := []struct {
+		name string
+		cctx AnnotateContext
+		want string
+	}{
+		{
+			name: "all fields",
+			cctx: AnnotateContext{Lookup: "lookup.csv"},
+			want: `CALL,NAME,BAND,COUNTRY,GRIDSQUARE
+W1AW,Hiram,20m,United States of America,FN31
+k9abc,Bob,40m,Canada,FN25
+N0CALL,Nobody,2m,,
+`,
+		},
+		{
+			name: "selected fields",
+			cctx: AnnotateContext{Lookup: "lookup.csv", Key: FieldList{"CALL"}, Add: FieldList{"GRIDSQUARE"}},
+			want: `CALL,NAME,BAND,GRIDSQUARE
+W1AW,,20m,FN31
+k9abc,Bob,40m,FN25
+N0CALL,Nobody,2m,
+`,
+		},
+		{
+			name: "overwrite",
+			cctx: AnnotateContext{Lookup: "lookup.csv", Add: FieldList{"NAME"}, Overwrite: true},
+			want: `CALL,NAME,BAND
+W1AW,Hiram,20m
+k9abc,Bob,40m
+N0CALL,Nobody,2m
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			ctx := &Context{
+				OutputFormat: adif.FormatCSV,
+				Readers:      readers(csv),
+				Writers:      writers(csv),
+				Out:          out,
+				CommandCtx:   &tc.cctx,
+				fs:           fakeFilesystem{map[string]string{"lookup.csv": lookup, "log.csv": log}}}
+			if err := Annotate.Run(ctx, []string{"log.csv"}); err != nil {
+				t.Fatalf("Annotate.Run(ctx) got error %v", err)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("Annotate.Run(ctx) unexpected output, diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotateDuplicateKey(t *testing.T) {
+	csv := adif.NewCSVIO()
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          &bytes.Buffer{},
+		CommandCtx:   &AnnotateContext{Lookup: "lookup.csv"},
+		fs: fakeFilesystem{map[string]string{
+			"lookup.csv": "CALL,NAME\nW1AW,Hiram\nw1aw ,Percy\n",
+			"log.csv":    "CALL\nW1AW\n"}}}
+	err := Annotate.Run(ctx, []string{"log.csv"})
+	if err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("Annotate.Run(ctx) with duplicate lookup key got error %v, want duplicate", err)
+	}
+}
@@ -14,10 +14,57 @@
 
 package cmd
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+)
+
 var Cat = Command{Name: "cat", Run: runCat,
 	Description: "Concatenate all input files to standard output"}
 
+type CatContext struct {
+	// SetIfField fields are set before the global --set and --set-if-empty
+	// options are applied by write.
+	SetIfField ConditionalAssignments
+	// SequenceField, if set, is a field added to each record with the record's
+	// position in the output, counting from SequenceStart.
+	SequenceField string
+	SequenceStart int
+	// Append, if set, is an ADI file which records are added to instead of
+	// writing to standard output.  The file is created if it doesn't exist.
+	Append string
+}
+
 func runCat(ctx *Context, args []string) error {
+	cctx, ok := ctx.CommandCtx.(*CatContext)
+	if !ok || cctx == nil {
+		cctx = &CatContext{}
+	}
+	set := make(map[string]bool)
+	for _, f := range ctx.SetFields.values {
+		set[f.Name] = true
+	}
+	for _, c := range cctx.SetIfField.values {
+		if set[c.set.Name] {
+			return fmt.Errorf("%q in both --set and --set-if-field", c.set.Name)
+		}
+	}
+	seqName := strings.ToUpper(cctx.SequenceField)
+	if seqName != "" && !adifNamePat.MatchString(seqName) {
+		return fmt.Errorf("invalid sequence field name %q", seqName)
+	}
+	if cctx.Append != "" && ctx.OutputFormat.IsValid() && ctx.OutputFormat != adif.FormatADI {
+		return fmt.Errorf("--append only supports ADI output, not %s", ctx.OutputFormat)
+	}
+	if cctx.Append != "" && ctx.Compress != "" {
+		return errors.New("--append does not support compressed output")
+	}
+	seq := cctx.SequenceStart
 	acc, err := newAccumulator(ctx)
 	if err != nil {
 		return err
@@ -28,10 +75,85 @@ func runCat(ctx *Context, args []string) error {
 			return err
 		}
 		updateFieldOrder(acc.Out, l.FieldOrder)
-		acc.Out.Records = append(acc.Out.Records, l.Records...)
+		for _, r := range l.Records {
+			if seqName != "" {
+				if err := r.Set(adif.Field{Name: seqName, Value: strconv.Itoa(seq), Type: adif.TypeNumber}); err != nil {
+					return err
+				}
+				seq++
+			}
+			// conditions apply to input values, before --set changes them
+			cond := make([]adif.Field, 0, len(cctx.SetIfField.values))
+			for _, c := range cctx.SetIfField.values {
+				if c.matches(r) {
+					cond = append(cond, c.set)
+				}
+			}
+			if err := setFields(r, cond, false); err != nil {
+				return err
+			}
+			acc.Out.AddRecord(r)
+		}
 	}
 	if err := acc.prepare(); err != nil {
 		return err
 	}
+	if cctx.Append != "" {
+		return appendLog(ctx, acc.Out, cctx.Append)
+	}
 	return write(ctx, acc.Out)
 }
+
+// appendLog adds the records in l to the end of an existing ADI file without
+// writing another header.  If the file doesn't exist it is created with a
+// header, so a logging program can add contacts one at a time.
+func appendLog(ctx *Context, l *adif.Logfile, file string) error {
+	if f, err := adif.GuessFormatFromName(file); err != nil || f != adif.FormatADI {
+		return fmt.Errorf("--append only supports ADI files, not %s", file)
+	}
+	fs := ctx.fs
+	if fs == nil {
+		fs = osFilesystem{}
+	}
+	adi, ok := ctx.Writers[adif.FormatADI].(*adif.ADIIO)
+	if !ok {
+		adi = adif.NewADIIO()
+	}
+	w := *adi
+	var out io.WriteCloser
+	var err error
+	if fs.Exists(file) {
+		w.AppendRecords = true
+		out, err = fs.Append(file)
+	} else {
+		out, err = fs.Create(file)
+	}
+	if err != nil {
+		return err
+	}
+	actx := *ctx
+	actx.Out = out
+	actx.OutputFormat = adif.FormatADI
+	// a gzip stream can have several members, so records can be appended
+	actx.Compress = compressionFor(file, "")
+	actx.Writers = map[adif.Format]adif.Writer{adif.FormatADI: &w}
+	if err := write(&actx, l); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func setFields(r *adif.Record, fields []adif.Field, onlyEmpty bool) error {
+	for _, f := range fields {
+		if onlyEmpty {
+			if v, ok := r.Get(f.Name); ok && v.Value != "" {
+				continue
+			}
+		}
+		if err := r.Set(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
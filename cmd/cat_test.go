@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/flwyd/adif-multitool/adif"
@@ -121,6 +122,165 @@ Fox Trot,Golf,Hotel,,
 	}
 }
 
+func TestSetFields(t *testing.T) {
+	csv := adif.NewCSVIO()
+	file1 := `CALL,MY_GRIDSQUARE,STATION_CALLSIGN
+K1A,,N0CALL
+K2B,FN31,
+K3C,EM12,
+`
+	want := `CALL,MY_GRIDSQUARE,STATION_CALLSIGN,OPERATOR
+K1A,FN31pr,W1AW,W1AW
+K2B,FN31,W1AW,W1AW
+K3C,EM12,W1AW,W1AW
+`
+	tests := []struct {
+		cmd  Command
+		cctx any
+	}{
+		{cmd: Cat, cctx: &CatContext{}},
+		{cmd: Head, cctx: &HeadContext{Count: 10}},
+	}
+	for _, tc := range tests {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          out,
+			Prepare:      testPrepare("My Comment", "3.1.4", "cat test", "1.2.3"),
+			SetFields:    NewFieldAssignments(ValidateAlphanumName),
+			SetIfEmpty:   NewFieldAssignments(ValidateAlphanumName),
+			CommandCtx:   tc.cctx,
+			fs:           fakeFilesystem{map[string]string{"foo.csv": file1}}}
+		ctx.SetFields.Set("station_callsign=W1AW")
+		ctx.SetFields.Set("OPERATOR=W1AW")
+		ctx.SetIfEmpty.Set("my_gridsquare=FN31pr")
+		if err := tc.cmd.Run(ctx, []string{"foo.csv"}); err != nil {
+			t.Errorf("%s.Run(ctx) got error %v", tc.cmd.Name, err)
+		} else if diff := cmp.Diff(want, out.String()); diff != "" {
+			t.Errorf("%s.Run(ctx, foo.csv) unexpected output, diff:\n%s", tc.cmd.Name, diff)
+		}
+		ctx.SetIfEmpty.Set("OPERATOR=K1A")
+		if err := tc.cmd.Run(ctx, []string{"foo.csv"}); err == nil {
+			t.Errorf("%s.Run(ctx) with OPERATOR in --set and --set-if-empty want error", tc.cmd.Name)
+		}
+	}
+}
+
+func TestCatSetIfField(t *testing.T) {
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	file1 := `CALL,LOTW_QSL_SENT,QRZCOM_QSO_UPLOAD_STATUS
+K1A,Y,
+K2B,N,
+K3C,y,M
+K4D,,
+`
+	cctx := &CatContext{}
+	for _, s := range []string{"qrzcom_qso_upload_status=Y:when:lotw_qsl_sent=Y", "COMMENT=not sent:when:LOTW_QSL_SENT="} {
+		if err := cctx.SetIfField.Set(s); err != nil {
+			t.Fatalf("SetIfField.Set(%q) got error %v", s, err)
+		}
+	}
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "cat test", "1.2.3"),
+		CommandCtx:   cctx,
+		fs:           fakeFilesystem{map[string]string{"foo.csv": file1}}}
+	if err := Cat.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Errorf("Cat.Run(ctx) got error %v", err)
+	} else {
+		got := out.String()
+		want := `CALL,LOTW_QSL_SENT,QRZCOM_QSO_UPLOAD_STATUS,COMMENT
+K1A,Y,Y,
+K2B,N,,
+K3C,y,Y,
+K4D,,,not sent
+`
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Cat.Run(ctx, foo.csv) unexpected output, diff:\n%s", diff)
+		}
+	}
+	for _, s := range []string{"CALL=W1AW", "CALL=W1AW:when:", "=Y:when:LOTW_QSL_SENT=Y", "X Y=1:when:CALL=K1A"} {
+		if err := cctx.SetIfField.Set(s); err == nil {
+			t.Errorf("SetIfField.Set(%q) want error", s)
+		}
+	}
+}
+
+func TestCatAppend(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	existing := "My Log\n<ADIF_VER:5>3.1.4 <EOH>\n<CALL:3>K1A <MODE:2>CW <EOR>\n"
+	fs := fakeFilesystem{map[string]string{
+		"log.adi": existing,
+		"new.csv": "CALL,MODE\nK2B,SSB\nK3C,FT8\n",
+	}}
+	out := &bytes.Buffer{}
+	ctx := &Context{
+		Readers:    readers(adi, csv),
+		Writers:    writers(adi, csv),
+		Out:        out,
+		Prepare:    testPrepare("My Comment", "3.1.4", "cat test", "1.2.3"),
+		CommandCtx: &CatContext{Append: "log.adi"},
+		fs:         fs}
+	if err := Cat.Run(ctx, []string{"new.csv"}); err != nil {
+		t.Fatalf("Cat.Run(ctx) with --append got error %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Cat.Run(ctx) with --append want no standard output, got %s", out)
+	}
+	want := existing + "<CALL:3>K2B <MODE:3>SSB <EOR>\n<CALL:3>K3C <MODE:3>FT8 <EOR>\n"
+	if diff := cmp.Diff(want, fs.files["log.adi"]); diff != "" {
+		t.Errorf("Cat.Run(ctx) with --append unexpected log.adi, diff:\n%s", diff)
+	}
+
+	ctx.CommandCtx = &CatContext{Append: "created.adi"}
+	if err := Cat.Run(ctx, []string{"new.csv"}); err != nil {
+		t.Fatalf("Cat.Run(ctx) with --append new file got error %v", err)
+	}
+	want = "My Comment\n<ADIF_VER:5>3.1.4 <PROGRAMID:8>cat test <PROGRAMVERSION:5>1.2.3 <EOH>\n<CALL:3>K2B <MODE:3>SSB <EOR>\n<CALL:3>K3C <MODE:3>FT8 <EOR>\n"
+	if diff := cmp.Diff(want, fs.files["created.adi"]); diff != "" {
+		t.Errorf("Cat.Run(ctx) with --append unexpected created.adi, diff:\n%s", diff)
+	}
+
+	ctx.CommandCtx = &CatContext{Append: "new.csv"}
+	if err := Cat.Run(ctx, []string{"log.adi"}); err == nil {
+		t.Errorf("Cat.Run(ctx) with --append to a CSV file want error")
+	}
+}
+
+func TestCatSequenceField(t *testing.T) {
+	csv := adif.NewCSVIO()
+	files := map[string]string{"foo.csv": "CALL\nK1A\nK2B\n", "bar.csv": "CALL,BAND\nK3C,20m\n"}
+	tests := []struct {
+		start int
+		want  string
+	}{
+		{start: 1, want: "CALL,BAND,SEQ_NUM\nK1A,,1\nK2B,,2\nK3C,20m,3\n"},
+		{start: 0, want: "CALL,BAND,SEQ_NUM\nK1A,,0\nK2B,,1\nK3C,20m,2\n"},
+	}
+	for _, tc := range tests {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          out,
+			CommandCtx:   &CatContext{SequenceField: "seq_num", SequenceStart: tc.start},
+			fs:           fakeFilesystem{files}}
+		if err := Cat.Run(ctx, []string{"foo.csv", "bar.csv"}); err != nil {
+			t.Errorf("Cat.Run(ctx) with start %d got error %v", tc.start, err)
+		} else if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+			t.Errorf("Cat.Run(ctx, foo.csv, bar.csv) with start %d unexpected output, diff:\n%s", tc.start, diff)
+		}
+	}
+}
+
 func TestCatPreserveAppHeaders(t *testing.T) {
 	adi := adif.NewADIIO()
 	out := &bytes.Buffer{}
@@ -159,3 +319,33 @@ func TestCatPreserveAppHeaders(t *testing.T) {
 		}
 	}
 }
+
+func TestCatProgressRecords(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("CALL\n")
+	for i := 0; i < 2500; i++ {
+		sb.WriteString("K1A\n")
+	}
+	progress := &strings.Builder{}
+	csv := adif.NewCSVIO()
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          &bytes.Buffer{},
+		ShowProgress: true,
+		ProgressOut:  progress,
+		CommandCtx:   &CatContext{},
+		fs:           fakeFilesystem{map[string]string{"foo.csv": sb.String()}}}
+	if err := Cat.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Fatalf("Cat.Run(ctx) got error %v", err)
+	}
+	want := `processing record 1000 from foo.csv
+processing record 2000 from foo.csv
+read 2500 records from foo.csv
+writing 2500 records
+`
+	if diff := cmp.Diff(want, progress.String()); diff != "" {
+		t.Errorf("Cat.Run(ctx) --progress unexpected output, diff:\n%s", diff)
+	}
+}
@@ -19,4 +19,6 @@ type Command struct {
 	Description string
 	Run         func(ctx *Context, args []string) error
 	Help        func() string
+	// Batches is true if Run reads Context.BatchSize records at a time
+	Batches bool
 }
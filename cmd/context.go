@@ -21,6 +21,7 @@ package cmd
 
 import (
 	"io"
+	"os"
 
 	"github.com/flwyd/adif-multitool/adif"
 	"github.com/flwyd/adif-multitool/adif/spec"
@@ -38,10 +39,27 @@ type Context struct {
 	FieldOrder         FieldList
 	UserdefFields      UserdefFieldList
 	SuppressAppHeaders bool
+	OmitEmpty          bool
+	Preset             Preset
+	ShowProgress       bool
+	ProgressOut        io.Writer
+	Compress           string
+	BatchSize          int
+	SetFields          FieldAssignments
+	SetIfEmpty         FieldAssignments
 	Prepare            func(*adif.Logfile)
 	fs                 filesystem
 }
 
+// progressOut returns the writer for --progress messages, standard error unless
+// ProgressOut is set.
+func (c *Context) progressOut() io.Writer {
+	if c.ProgressOut == nil {
+		return os.Stderr
+	}
+	return c.ProgressOut
+}
+
 func testPrepare(comment, adifVer, progName, progVer string) func(l *adif.Logfile) {
 	return func(l *adif.Logfile) {
 		l.Header.SetComment(comment)
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+)
+
+var Convert = Command{Name: "convert", Run: runConvert, Help: helpConvert,
+	Description: "Convert between formats, optionally warning about dropped fields"}
+
+type ConvertContext struct {
+	// WarnDroppedFields prints a warning for each record with fields which the
+	// output format can't represent.
+	WarnDroppedFields bool
+}
+
+func helpConvert() string {
+	return `--from and --to are synonyms for --input and --output.  Some formats, like
+Cabrillo, can only represent certain fields; --warn-dropped-fields prints the
+non-empty fields in each record which will not be in the output.
+`
+}
+
+func runConvert(ctx *Context, args []string) error {
+	cctx, ok := ctx.CommandCtx.(*ConvertContext)
+	if !ok || cctx == nil {
+		cctx = &ConvertContext{}
+	}
+	format := ctx.OutputFormat
+	if !format.IsValid() {
+		format = adif.FormatADI
+	}
+	var supported map[string]bool
+	if cctx.WarnDroppedFields {
+		if lim, ok := ctx.Writers[format].(adif.FieldLimiter); ok {
+			supported = make(map[string]bool)
+			for _, n := range lim.SupportedFields() {
+				supported[strings.ToUpper(n)] = true
+			}
+		}
+	}
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		for i, r := range l.Records {
+			if supported != nil {
+				if dropped := droppedFields(r, supported); len(dropped) > 0 {
+					fmt.Fprintf(os.Stderr, "Warning: %s record %d: %s output drops %s\n", l, i+1, format, strings.Join(dropped, ", "))
+				}
+			}
+			acc.Out.AddRecord(r)
+		}
+	}
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
+
+func droppedFields(r *adif.Record, supported map[string]bool) []string {
+	var res []string
+	for _, f := range r.Fields() {
+		if f.Value != "" && !supported[strings.ToUpper(f.Name)] {
+			res = append(res, f.Name)
+		}
+	}
+	return res
+}
@@ -15,12 +15,18 @@
 package cmd
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/flwyd/adif-multitool/adif"
 	"github.com/flwyd/adif-multitool/adif/spec"
+	"golang.org/x/exp/slices"
 )
 
 var Edit = Command{Name: "edit", Run: runEdit, Help: helpEdit,
@@ -35,6 +41,14 @@ type EditContext struct {
 	Cond        ConditionValue
 	FromZone    TimeZone
 	ToZone      TimeZone
+	Record      RecordIndex
+	// ReplaceFields are fields where text matching ReplaceFrom is replaced by
+	// ReplaceTo.  If Regex is true, ReplaceFrom is a regular expression and
+	// ReplaceTo can refer to capture groups like ${1}.
+	ReplaceFields FieldList
+	ReplaceFrom   string
+	ReplaceTo     string
+	Regex         bool
 }
 
 func helpEdit() string {
@@ -44,11 +58,37 @@ func helpEdit() string {
 		spec.TimeOffField.Name,
 		spec.QsoDateField.Name,
 		spec.QsoDateOffField.Name,
-	)
+	) + `
+The global --set and --set-if-empty options only change records matching the
+conditions, --set-if-empty acting like --add.
+
+--field COMMENT --from 'IOTA ' --to '' removes "IOTA " from COMMENT values.
+With --regex, --from is a Go regular expression (https://pkg.go.dev/regexp/syntax)
+and --to can refer to capture groups, e.g. --from '(\d+)W' --to '$1'.
+
+With --record N, record number N (starting from 0) of a single ADI or ADX
+input file is opened in the editor named by the EDITOR environment variable.
+After the editor exits, the changed record is written back to the input file,
+compressed if the file was gzipped.
+`
 }
 
 func runEdit(ctx *Context, args []string) error {
 	cctx := ctx.CommandCtx.(*EditContext)
+	if _, ok := cctx.Record.Get(); ok {
+		return editRecord(ctx, cctx, args)
+	}
+	// edit applies the global --set and --set-if-empty options itself so they
+	// only change records matching the conditions, like --set and --add
+	if len(ctx.SetFields.values) > 0 || len(ctx.SetIfEmpty.values) > 0 {
+		c := *cctx
+		c.Set.values = append(slices.Clone(cctx.Set.values), ctx.SetFields.values...)
+		c.Add.values = append(slices.Clone(cctx.Add.values), ctx.SetIfEmpty.values...)
+		cctx = &c
+		wctx := *ctx
+		wctx.SetFields, wctx.SetIfEmpty = FieldAssignments{}, FieldAssignments{}
+		ctx = &wctx
+	}
 	remove := make(map[string]bool)
 	for _, n := range cctx.Remove {
 		remove[n] = true
@@ -84,6 +124,14 @@ func runEdit(ctx *Context, args []string) error {
 			return fmt.Errorf("%q in --set and --rename %s=%s, set would override rename", v, f.Name, f.Value)
 		}
 	}
+	replace, err := newReplacer(cctx)
+	if err != nil {
+		return err
+	}
+	replaceFields := make(map[string]bool)
+	for _, n := range cctx.ReplaceFields {
+		replaceFields[n] = true
+	}
 	fromTz := cctx.FromZone.Get()
 	toTz := cctx.ToZone.Get()
 	adjustTz := fromTz.String() != toTz.String()
@@ -111,6 +159,9 @@ func runEdit(ctx *Context, args []string) error {
 				if remove[f.Name] {
 					continue
 				}
+				if replaceFields[f.Name] {
+					f.Value = replace(f.Value)
+				}
 				if cctx.RemoveBlank && f.Value == "" {
 					continue
 				}
@@ -167,6 +218,136 @@ func runEdit(ctx *Context, args []string) error {
 	return write(ctx, acc.Out)
 }
 
+// newReplacer returns a function which applies the --from and --to
+// substitution to a field value, or an identity function if no substitution
+// is configured.
+func newReplacer(cctx *EditContext) (func(string) string, error) {
+	if cctx.ReplaceFrom == "" {
+		if len(cctx.ReplaceFields) > 0 || cctx.ReplaceTo != "" || cctx.Regex {
+			return nil, fmt.Errorf("--field, --to, and --regex require --from")
+		}
+		return func(s string) string { return s }, nil
+	}
+	if len(cctx.ReplaceFields) == 0 {
+		return nil, fmt.Errorf("--from %q requires --field", cctx.ReplaceFrom)
+	}
+	if !cctx.Regex {
+		return func(s string) string { return strings.ReplaceAll(s, cctx.ReplaceFrom, cctx.ReplaceTo) }, nil
+	}
+	re, err := regexp.Compile(cctx.ReplaceFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from regular expression: %w", err)
+	}
+	return func(s string) string { return re.ReplaceAllString(s, cctx.ReplaceTo) }, nil
+}
+
+// editRecord opens one record from a file in a text editor as an ADI file and
+// replaces the record in the original file with the edited version.
+func editRecord(ctx *Context, cctx *EditContext, args []string) error {
+	idx, _ := cctx.Record.Get()
+	if len(args) != 1 || args[0] == "-" || args[0] == os.Stdin.Name() {
+		return fmt.Errorf("--record requires exactly one input file, got %v", args)
+	}
+	if len(cctx.Add.values) > 0 || len(cctx.Set.values) > 0 || len(cctx.Rename.values) > 0 ||
+		len(ctx.SetFields.values) > 0 || len(ctx.SetIfEmpty.values) > 0 ||
+		len(cctx.Remove) > 0 || cctx.RemoveBlank || len(cctx.Cond.Get().Terms) > 0 ||
+		cctx.FromZone.tz != nil || cctx.ToZone.tz != nil || len(cctx.ReplaceFields) > 0 || cctx.ReplaceFrom != "" {
+		return fmt.Errorf("--record cannot be combined with other edit options")
+	}
+	fname := args[0]
+	format := ctx.InputFormat
+	if !format.IsValid() {
+		f, err := adif.GuessFormatFromName(fname)
+		if err != nil {
+			return fmt.Errorf("cannot edit %s in place, set --input: %w", fname, err)
+		}
+		format = f
+	}
+	if format != adif.FormatADI && format != adif.FormatADX {
+		return fmt.Errorf("--record can only edit ADI or ADX files, %s is %s", fname, format)
+	}
+	w, ok := ctx.Writers[format]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", format)
+	}
+	compressed, err := isGzipped(ctx, fname)
+	if err != nil {
+		return err
+	}
+	l, err := readFile(ctx, fname)
+	if err != nil {
+		return err
+	}
+	if idx >= len(l.Records) {
+		return fmt.Errorf("cannot edit record %d, %s has %d records", idx, fname, len(l.Records))
+	}
+	orig := l.Records[idx]
+
+	tmp, err := os.CreateTemp("", "adifmt-edit-*.adi")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	adi := adif.NewADIIO()
+	adi.FieldSep = adif.SeparatorNewline
+	el := adif.NewLogfile()
+	el.Userdef = l.Userdef
+	el.AddRecord(orig)
+	err = adi.Write(el, tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write record to %s: %w", tmp.Name(), err)
+	}
+	if err := launchEditor(tmp.Name()); err != nil {
+		return fmt.Errorf("editor failed, %s unchanged: %w", fname, err)
+	}
+	in, err := os.Open(tmp.Name())
+	if err != nil {
+		return err
+	}
+	edited, err := adi.Read(in)
+	in.Close()
+	if err != nil {
+		return fmt.Errorf("could not read edited record, %s unchanged: %w", fname, err)
+	}
+	if len(edited.Records) != 1 {
+		return fmt.Errorf("edited file has %d records, want 1; %s unchanged", len(edited.Records), fname)
+	}
+	rec := edited.Records[0]
+	if rec.Equal(orig) && rec.GetComment() == orig.GetComment() {
+		fmt.Fprintf(os.Stderr, "record %d unchanged, not writing %s\n", idx, fname)
+		return nil
+	}
+	l.Records[idx] = rec
+	fs := ctx.fs
+	if fs == nil {
+		fs = osFilesystem{}
+	}
+	return fs.Replace(fname, func(out io.Writer) error {
+		if !compressed {
+			return w.Write(l, out)
+		}
+		z := gzip.NewWriter(out)
+		if err := w.Write(l, z); err != nil {
+			return err
+		}
+		return z.Close()
+	})
+}
+
+// launchEditor runs the user's text editor on file and waits for it to exit.
+var launchEditor = func(file string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	c := exec.Command(editor[0], append(editor[1:], file)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c.Run()
+}
+
 func adjustTimeZone(r *adif.Record, from, to *time.Location) error {
 	dayfmt := "20060102"
 	adjust := func(timef, dayf, dayfallback adif.Field) error {
@@ -16,7 +16,12 @@ package cmd
 
 import (
 	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -250,6 +255,29 @@ func TestCyclicRename(t *testing.T) {
 	}
 }
 
+func TestEditGlobalSet(t *testing.T) {
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	cond := ConditionValue{}
+	cond.IfFlag().Set("MODE=CW")
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          out,
+		SetFields:    FieldAssignments{values: []adif.Field{{Name: "OPERATOR", Value: "W1AW"}}, validate: ValidateAlphanumName},
+		SetIfEmpty:   FieldAssignments{values: []adif.Field{{Name: "NAME", Value: "Unknown"}}, validate: ValidateAlphanumName},
+		fs:           fakeFilesystem{map[string]string{"foo.csv": "CALL,MODE,NAME\nK1A,CW,\nK2B,SSB,\nK3C,CW,Cy\n"}},
+		CommandCtx:   &EditContext{Cond: cond}}
+	if err := Edit.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Fatalf("Edit.Run(ctx) got error %v", err)
+	}
+	want := "CALL,MODE,NAME,OPERATOR\nK1A,CW,Unknown,W1AW\nK2B,SSB,,\nK3C,CW,Cy,W1AW\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("edit --if MODE=CW --set OPERATOR=W1AW --set-if-empty NAME=Unknown got diff\n%s", diff)
+	}
+}
+
 func TestEditRemoveEmpty(t *testing.T) {
 	adi := adif.NewADIIO()
 	csv := adif.NewCSVIO()
@@ -286,6 +314,115 @@ foo3,bar3,
 	}
 }
 
+func TestEditReplaceRemoveBlank(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	file1 := `CALL,COMMENT
+K1A,tnx
+K2B,tnx fer QSO
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatADI,
+		Readers:      readers(adi, csv),
+		Writers:      writers(adi, csv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "edit test", "1.2.3"),
+		fs:           fakeFilesystem{map[string]string{"foo.csv": file1}},
+		CommandCtx:   &EditContext{ReplaceFields: FieldList{"COMMENT"}, ReplaceFrom: "tnx", ReplaceTo: "", RemoveBlank: true}}
+	if err := Edit.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Errorf("Edit.Run(ctx, foo.csv) got error %v", err)
+	} else {
+		got := out.String()
+		want := `My Comment
+<ADIF_VER:5>3.1.4 <PROGRAMID:9>edit test <PROGRAMVERSION:5>1.2.3 <EOH>
+<CALL:3>K1A <EOR>
+<CALL:3>K2B <COMMENT:8> fer QSO <EOR>
+`
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Edit.Run(ctx, foo.csv) unexpected output, diff:\n%s", diff)
+		}
+	}
+}
+
+func TestEditReplace(t *testing.T) {
+	file1 := `CALL,COMMENT,NOTES
+K1A,IOTA NA-001 tnx,IOTA NA-001
+K2B,no iota,IOTA EU-005
+K3C,,
+`
+	tests := []struct {
+		name string
+		cctx EditContext
+		want string
+	}{
+		{
+			name: "substring",
+			cctx: EditContext{ReplaceFields: FieldList{"COMMENT"}, ReplaceFrom: "IOTA ", ReplaceTo: ""},
+			want: `CALL,COMMENT,NOTES
+K1A,NA-001 tnx,IOTA NA-001
+K2B,no iota,IOTA EU-005
+K3C,,
+`,
+		},
+		{
+			name: "multiple fields",
+			cctx: EditContext{ReplaceFields: FieldList{"COMMENT", "NOTES"}, ReplaceFrom: "IOTA", ReplaceTo: "Islands"},
+			want: `CALL,COMMENT,NOTES
+K1A,Islands NA-001 tnx,Islands NA-001
+K2B,no iota,Islands EU-005
+K3C,,
+`,
+		},
+		{
+			name: "regex",
+			cctx: EditContext{ReplaceFields: FieldList{"COMMENT", "NOTES"}, ReplaceFrom: `(?i)iota ([A-Z]{2})-0*(\d+)`, ReplaceTo: "$1 ${2}", Regex: true},
+			want: `CALL,COMMENT,NOTES
+K1A,NA 1 tnx,NA 1
+K2B,no iota,EU 5
+K3C,,
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			csv := adif.NewCSVIO()
+			out := &bytes.Buffer{}
+			ctx := &Context{
+				OutputFormat: adif.FormatCSV,
+				Readers:      readers(csv),
+				Writers:      writers(csv),
+				Out:          out,
+				fs:           fakeFilesystem{map[string]string{"foo.csv": file1}},
+				CommandCtx:   &tc.cctx}
+			if err := Edit.Run(ctx, []string{"foo.csv"}); err != nil {
+				t.Fatalf("Edit.Run(ctx, foo.csv) got error %v", err)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("Edit.Run(ctx, foo.csv) unexpected output, diff:\n%s", diff)
+			}
+		})
+	}
+
+	for _, cctx := range []EditContext{
+		{ReplaceFrom: "foo"},
+		{ReplaceFields: FieldList{"COMMENT"}},
+		{ReplaceFields: FieldList{"COMMENT"}, ReplaceFrom: "(unclosed", Regex: true},
+	} {
+		csv := adif.NewCSVIO()
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          &bytes.Buffer{},
+			fs:           fakeFilesystem{map[string]string{"foo.csv": file1}},
+			CommandCtx:   &cctx}
+		if err := Edit.Run(ctx, []string{"foo.csv"}); err == nil {
+			t.Errorf("Edit.Run with %+v want error", cctx)
+		}
+	}
+}
+
 func TestAdjustTimeZone(t *testing.T) {
 	type state struct {
 		dateOn, dateOff, timeOn, timeOff string
@@ -362,3 +499,93 @@ func TestAdjustTimeZone(t *testing.T) {
 		}
 	}
 }
+
+func TestEditRecord(t *testing.T) {
+	start := "<CALL:3>K1A <BAND:3>20m <NAME:2>Al <EOR>\n<CALL:3>K2B <BAND:3>40m <NAME:3>Bea <EOR>\n<CALL:3>K3C <BAND:3>15m <NAME:2>Cy <EOR>\n"
+	var zipped bytes.Buffer
+	z := gzip.NewWriter(&zipped)
+	z.Write([]byte(start))
+	z.Close()
+	fs := fakeFilesystem{map[string]string{"log.adi": start, "zipped.adi": zipped.String(), "log.csv": "CALL\nK1A\n"}}
+	defer func(orig func(string) error) { launchEditor = orig }(launchEditor)
+	var edited string
+	launchEditor = func(file string) error {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		edited = string(b)
+		s := strings.Replace(string(b), "<NAME:3>Bea", "<NAME:4>Beth <QTH:6>Denver", 1)
+		return os.WriteFile(file, []byte(s), 0600)
+	}
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	cctx := &EditContext{}
+	if err := cctx.Record.Set("1"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &Context{Readers: readers(adi, csv), Writers: writers(adi, csv), Out: &bytes.Buffer{}, CommandCtx: cctx, fs: fs}
+	want := "<CALL:3>K1A <BAND:3>20m <NAME:2>Al <EOR>\n<CALL:3>K2B <BAND:3>40m <NAME:4>Beth <QTH:6>Denver <EOR>\n<CALL:3>K3C <BAND:3>15m <NAME:2>Cy <EOR>\n"
+	for _, fname := range []string{"log.adi", "zipped.adi"} {
+		if err := Edit.Run(ctx, []string{fname}); err != nil {
+			t.Fatalf("edit --record 1 %s got error %v", fname, err)
+		}
+		if !strings.Contains(edited, "<CALL:3>K2B") {
+			t.Errorf("edit --record 1 %s opened wrong record:\n%s", fname, edited)
+		}
+		got := fs.files[fname]
+		if fname == "zipped.adi" {
+			z, err := gzip.NewReader(strings.NewReader(got))
+			if err != nil {
+				t.Fatalf("edit --record 1 %s did not keep gzip compression: %v", fname, err)
+			}
+			b, err := io.ReadAll(z)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = string(b)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("edit --record 1 %s got diff\n%s", fname, diff)
+		}
+	}
+
+	if err := Edit.Run(ctx, []string{"log.csv"}); err == nil {
+		t.Errorf("edit --record 1 log.csv want error for CSV file")
+	}
+	if err := cctx.Record.Set("3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Edit.Run(ctx, []string{"log.adi"}); err == nil {
+		t.Errorf("edit --record 3 with 3 records in log.adi want error")
+	}
+}
+
+func TestOSFilesystemReplace(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "log.adi")
+	if err := os.WriteFile(fname, []byte("old"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	fs := osFilesystem{}
+	if err := fs.Replace(fname, func(w io.Writer) error { return fmt.Errorf("failed") }); err == nil {
+		t.Errorf("Replace(%s) with failed write want error", fname)
+	}
+	if err := fs.Replace(fname, func(w io.Writer) error { _, err := io.WriteString(w, "new"); return err }); err != nil {
+		t.Fatalf("Replace(%s) got error %v", fname, err)
+	}
+	if got, err := os.ReadFile(fname); err != nil {
+		t.Error(err)
+	} else if string(got) != "new" {
+		t.Errorf("Replace(%s) wrote %q, want %q", fname, got, "new")
+	}
+	if info, err := os.Stat(fname); err != nil {
+		t.Error(err)
+	} else if info.Mode().Perm() != 0640 {
+		t.Errorf("Replace(%s) changed file mode to %v", fname, info.Mode().Perm())
+	}
+	if ents, err := os.ReadDir(filepath.Dir(fname)); err != nil {
+		t.Error(err)
+	} else if len(ents) != 1 {
+		t.Errorf("Replace(%s) left temporary files: %v", fname, ents)
+	}
+}
@@ -66,6 +66,29 @@ func (fs fakeFilesystem) Create(name string) (io.WriteCloser, error) {
 	}}, nil
 }
 
+func (fs fakeFilesystem) Append(name string) (io.WriteCloser, error) {
+	prev, ok := fs.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s does not exist", name)
+	}
+	return &stringWriter{closeCB: func(w *stringWriter) error {
+		fs.files[name] = prev + w.String()
+		return nil
+	}}, nil
+}
+
+func (fs fakeFilesystem) Replace(name string, write func(io.Writer) error) error {
+	if _, ok := fs.files[name]; !ok {
+		return fmt.Errorf("%s does not exist", name)
+	}
+	var b strings.Builder
+	if err := write(&b); err != nil {
+		return err
+	}
+	fs.files[name] = b.String()
+	return nil
+}
+
 func (fs fakeFilesystem) MkdirAll(dir string) error {
 	// currently not worying about enforcing directories
 	return nil
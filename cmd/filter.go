@@ -0,0 +1,305 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var Filter = Command{Name: "filter", Run: runFilter, Help: helpFilter,
+	Description: "Include only records matching an expression like find, with AND/OR/NOT"}
+
+func helpFilter() string {
+	return `The first argument is an expression; remaining arguments are input files.
+Comparisons have the form field op value, where op is one of
+  =  !=  <  <=  >  >=
+Values may be "double quoted" (use \" for a quote) or a bare word without
+spaces, parentheses, or operator characters.  {field} compares to another field.
+Comparisons follow the field's type in the ADIF spec: numbers like FREQ compare
+numerically, dates and times chronologically, BAND by frequency, and strings
+without regard to case.  Comparisons can be combined with AND, OR, NOT, and
+parentheses; AND binds more tightly than OR.  Examples:
+  filter 'BAND = "20m" OR BAND = "40m"'
+  filter 'qso_date >= 20240101 AND NOT (mode = CW OR mode = FT8)'
+  filter 'tx_pwr <= 5 AND gridsquare != {my_gridsquare}'
+  filter 'operator = ""' : OPERATOR field not set
+Quote the whole expression so the shell doesn't interpret special characters.
+`
+}
+
+func runFilter(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("filter expects an expression, e.g. 'BAND = 20m OR BAND = 40m'")
+	}
+	cond, err := parseFilter(args[0])
+	if err != nil {
+		return err
+	}
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range filesOrStdin(args[1:]) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		for _, r := range l.Records {
+			if cond.Evaluate(recordEvalContext{record: r, lang: ctx.Locale}) {
+				acc.Out.AddRecord(r)
+			}
+		}
+	}
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
+
+// negation is true if Term is false.
+type negation struct{ Term Condition }
+
+func (n negation) String() string { return "NOT (" + n.Term.String() + ")" }
+
+func (n negation) Evaluate(e EvaluationContext) bool { return !n.Term.Evaluate(e) }
+
+type filterTokenType int
+
+const (
+	tokEOF filterTokenType = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type filterToken struct {
+	typ filterTokenType
+	val string
+	pos int // byte offset in the expression, for error messages
+}
+
+func (t filterToken) String() string {
+	if t.typ == tokEOF {
+		return "end of expression"
+	}
+	if t.typ == tokString {
+		return fmt.Sprintf("%q at position %d", t.val, t.pos+1)
+	}
+	return fmt.Sprintf("%s at position %d", t.val, t.pos+1)
+}
+
+const filterOpChars = "=!<>"
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var toks []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			toks = append(toks, filterToken{typ: tokLParen, val: "(", pos: i})
+			i++
+		case c == ')':
+			toks = append(toks, filterToken{typ: tokRParen, val: ")", pos: i})
+			i++
+		case strings.IndexByte(filterOpChars, c) >= 0:
+			start := i
+			for i < len(expr) && strings.IndexByte(filterOpChars, expr[i]) >= 0 {
+				i++
+			}
+			op := expr[start:i]
+			switch op {
+			case "=", "!=", "<", "<=", ">", ">=":
+				toks = append(toks, filterToken{typ: tokOp, val: op, pos: start})
+			default:
+				return nil, fmt.Errorf("unknown operator %q at position %d", op, start+1)
+			}
+		case c == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(expr) {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					sb.WriteByte(expr[i+1])
+					i += 2
+				} else if expr[i] == '"' {
+					i++
+					closed = true
+					break
+				} else {
+					sb.WriteByte(expr[i])
+					i++
+				}
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start+1)
+			}
+			toks = append(toks, filterToken{typ: tokString, val: sb.String(), pos: start})
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\r\n()\""+filterOpChars, rune(expr[i])) {
+				i++
+			}
+			toks = append(toks, filterToken{typ: tokWord, val: expr[start:i], pos: start})
+		}
+	}
+	return append(toks, filterToken{typ: tokEOF, pos: len(expr)}), nil
+}
+
+// filterParser is a recursive descent parser for filter expressions:
+//
+//	or         = and { "OR" and }
+//	and        = not { "AND" not }
+//	not        = "NOT" not | "(" or ")" | comparison
+//	comparison = field op value
+type filterParser struct {
+	toks []filterToken
+	pos  int
+}
+
+func parseFilter(expr string) (Condition, error) {
+	toks, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, fmt.Errorf("filter expression: %w", err)
+	}
+	p := &filterParser{toks: toks}
+	c, err := p.parseOr()
+	if err == nil && p.peek().typ != tokEOF {
+		err = fmt.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("filter expression: %w", err)
+	}
+	return c, nil
+}
+
+func (p *filterParser) peek() filterToken { return p.toks[p.pos] }
+
+func (p *filterParser) next() filterToken {
+	t := p.toks[p.pos]
+	if t.typ != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) keyword(k string) bool {
+	t := p.peek()
+	if t.typ == tokWord && strings.EqualFold(t.val, k) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (Condition, error) {
+	c, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	j := junction{Terms: []Condition{c}, Any: true}
+	for p.keyword("OR") {
+		c, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		j.Terms = append(j.Terms, c)
+	}
+	if len(j.Terms) == 1 {
+		return j.Terms[0], nil
+	}
+	return j, nil
+}
+
+func (p *filterParser) parseAnd() (Condition, error) {
+	c, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	j := junction{Terms: []Condition{c}}
+	for p.keyword("AND") {
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		j.Terms = append(j.Terms, c)
+	}
+	if len(j.Terms) == 1 {
+		return j.Terms[0], nil
+	}
+	return j, nil
+}
+
+func (p *filterParser) parseNot() (Condition, error) {
+	if p.keyword("NOT") {
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return negation{Term: c}, nil
+	}
+	if p.peek().typ == tokLParen {
+		p.next()
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.typ != tokRParen {
+			return nil, fmt.Errorf("expected ) but got %s", t)
+		}
+		return c, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (Condition, error) {
+	field := p.next()
+	if field.typ != tokWord || !isFilterFieldName(field.val) {
+		return nil, fmt.Errorf("expected field name but got %s", field)
+	}
+	op := p.next()
+	if op.typ != tokOp {
+		return nil, fmt.Errorf("expected comparison operator after %s but got %s", field.val, op)
+	}
+	val := p.next()
+	if val.typ != tokWord && val.typ != tokString {
+		return nil, fmt.Errorf("expected value after %s %s but got %s", field.val, op.val, val)
+	}
+	c := comparison{FieldName: field.val, Op: operator(op.val), Operands: []string{val.val}}
+	if op.val == "!=" {
+		c.Op, c.Negate = OpEqual, true
+	}
+	if val.val == "" && c.Op != OpEqual && c.Op != OpGreaterThan {
+		return nil, fmt.Errorf("cannot use %s with empty string at position %d", op.val, op.pos+1)
+	}
+	return c, nil
+}
+
+func isFilterFieldName(s string) bool {
+	for _, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
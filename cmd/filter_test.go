@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilter(t *testing.T) {
+	file1 := `QSO_DATE,CALL,BAND,FREQ,MODE,TX_PWR,GRIDSQUARE,MY_GRIDSQUARE,OPERATOR
+20190101,K1A,160m,1.810,SSB,10,FN31,FN31,
+20190202,N2B,80m,3.502,CW,5,FN20,FN31,W1AW
+20190303,W3C,40m,7.203,SSB,100,FM19,FN31,
+20240404,K4D,20m,14.074,FT8,50,EM73,FN31,W1AW
+20240505,N5E,2m,146.52,FM,9,EM12,FN31,
+`
+	tests := []struct {
+		expr  string
+		calls []string
+	}{
+		{expr: `BAND = "20m" OR BAND = "40m"`, calls: []string{"W3C", "K4D"}},
+		{expr: `band=20M`, calls: []string{"K4D"}},
+		{expr: `mode != ssb`, calls: []string{"N2B", "K4D", "N5E"}},
+		{expr: `tx_pwr < 10`, calls: []string{"N2B", "N5E"}},
+		{expr: `freq >= 7 AND freq < 30`, calls: []string{"W3C", "K4D"}},
+		{expr: `qso_date >= 20200101`, calls: []string{"K4D", "N5E"}},
+		{expr: `band <= 40m`, calls: []string{"K1A", "N2B", "W3C"}},
+		{expr: `NOT (mode = CW OR mode = FT8) AND tx_pwr <= 10`, calls: []string{"K1A", "N5E"}},
+		{expr: `mode = SSB AND tx_pwr = 10 OR mode = FM`, calls: []string{"K1A", "N5E"}},
+		{expr: `gridsquare = {my_gridsquare}`, calls: []string{"K1A"}},
+		{expr: `operator = ""`, calls: []string{"K1A", "W3C", "N5E"}},
+		{expr: `operator > "" and not not band = 20m`, calls: []string{"K4D"}},
+		{expr: `call = "K1A" or call = "n2b"`, calls: []string{"K1A", "N2B"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			csv := adif.NewCSVIO()
+			out := &bytes.Buffer{}
+			ctx := &Context{
+				OutputFormat: adif.FormatCSV,
+				Readers:      readers(csv),
+				Writers:      writers(csv),
+				Out:          out,
+				fs:           fakeFilesystem{map[string]string{"foo.csv": file1}}}
+			if err := Filter.Run(ctx, []string{tc.expr, "foo.csv"}); err != nil {
+				t.Fatalf("Filter.Run(ctx, %q, foo.csv) got error %v", tc.expr, err)
+			}
+			var got []string
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+				got = append(got, strings.Split(line, ",")[1])
+			}
+			if diff := cmp.Diff(tc.calls, got); diff != "" {
+				t.Errorf("Filter.Run(ctx, %q, foo.csv) unexpected calls, diff:\n%s", tc.expr, diff)
+			}
+		})
+	}
+}
+
+func TestFilterParseErrors(t *testing.T) {
+	tests := []struct{ expr, wantErr string }{
+		{expr: ``, wantErr: "expected field name but got end of expression"},
+		{expr: `band`, wantErr: "expected comparison operator after band but got end of expression"},
+		{expr: `band = `, wantErr: "expected value after band = but got end of expression"},
+		{expr: `band == 20m`, wantErr: `unknown operator "==" at position 6`},
+		{expr: `band = 20m or`, wantErr: "expected field name but got end of expression"},
+		{expr: `band = 20m mode = CW`, wantErr: "unexpected mode at position 12"},
+		{expr: `(band = 20m`, wantErr: "expected ) but got end of expression"},
+		{expr: `band = "20m`, wantErr: "unterminated string starting at position 8"},
+		{expr: `"band" = 20m`, wantErr: `expected field name but got "band" at position 1`},
+		{expr: `freq < ""`, wantErr: "cannot use < with empty string at position 6"},
+		{expr: `band = (20m)`, wantErr: "expected value after band = but got ( at position 8"},
+	}
+	for _, tc := range tests {
+		_, err := parseFilter(tc.expr)
+		if err == nil {
+			t.Errorf("parseFilter(%q) want error, got nil", tc.expr)
+		} else if !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("parseFilter(%q) got error %q, want %q", tc.expr, err, tc.wantErr)
+		}
+	}
+}
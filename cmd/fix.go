@@ -38,6 +38,8 @@ func helpFix() string {
   Time fields (no seconds): 15:04, 3:04 PM, 3:04pm
   Location fields: decimal degrees (GPS coordinates)
   Country fields: ISO 3166-1 alpha-2 and alpha-3 codes
+  Frequency fields: kilohertz converted to megahertz if not in a band as MHz
+    and in a band (matching BAND or BAND_RX if set) as kHz
 `
 }
 
@@ -99,10 +101,47 @@ func fixField(f adif.Field, r *adif.Record, l *adif.Logfile) adif.Field {
 			}
 		}
 		f.Value = fixCountry(f.Value, state)
+	} else if f.Name == spec.FreqField.Name || f.Name == spec.FreqRxField.Name {
+		bandname := spec.BandField.Name
+		if f.Name == spec.FreqRxField.Name {
+			bandname = spec.BandRxField.Name
+		}
+		var band string
+		if b, ok := r.Get(bandname); ok {
+			band = b.Value
+		}
+		f.Value = fixFreq(f.Value, band)
 	}
 	return f
 }
 
+// fixFreq converts a frequency in kilohertz to megahertz, as exported by some
+// older logging programs.  If band is set, the value is changed if it is in
+// that band as kilohertz but not as megahertz.  Otherwise, the value is only
+// changed if it is not in any amateur band as megahertz but is in one as
+// kilohertz.  (Several kHz HF and VHF frequencies are sub-millimeter bands if
+// interpreted as MHz.)
+func fixFreq(val, band string) string {
+	freq, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil {
+		return val
+	}
+	mhz := freq / 1000
+	asMhz, mhzOk := bandForFreq(freq)
+	asKhz, khzOk := bandForFreq(mhz)
+	if !khzOk {
+		return val
+	}
+	if band != "" {
+		if strings.EqualFold(band, asKhz.Band) && !strings.EqualFold(band, asMhz.Band) {
+			return strconv.FormatFloat(mhz, 'f', -1, 64)
+		}
+	} else if !mhzOk {
+		return strconv.FormatFloat(mhz, 'f', -1, 64)
+	}
+	return val
+}
+
 func fieldType(f adif.Field, l *adif.Logfile) spec.DataType {
 	if fs, ok := spec.FieldNamed(f.Name); ok {
 		return fs.Type
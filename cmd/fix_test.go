@@ -263,3 +263,55 @@ func TestFixCountry(t *testing.T) {
 		}
 	}
 }
+
+func TestFixFreq(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	header := "My Comment\n<ADIF_VER:5>3.1.4 <PROGRAMID:8>fix test <PROGRAMVERSION:5>1.2.3 <EOH>\n"
+	fields := []string{"FREQ", "FREQ_RX"}
+	tests := []struct{ source, band, want string }{
+		{source: "", want: ""},
+		{source: "14.074", want: "14.074"},
+		{source: "14074", want: "14.074"},
+		{source: "14074", band: "20m", want: "14.074"},
+		{source: "7030.5", band: "40M", want: "7.0305"},
+		{source: "3573", band: "20m", want: "3573"}, // 80m as kHz, doesn't match band
+		{source: "1840", want: "1.84"},
+		{source: "146520", want: "146520"}, // 2mm band in MHz
+		{source: "146520", band: "2m", want: "146.52"},
+		{source: "50313", want: "50.313"},
+		{source: "2304.1", want: "2304.1"},   // 13cm band in MHz
+		{source: "10368.1", want: "10368.1"}, // 3cm band in MHz
+		{source: "144000", want: "144000"},   // 2mm band in MHz, 2m band in kHz
+		{source: "144000", band: "2mm", want: "144000"},
+		{source: "432100", band: "70cm", want: "432.1"},
+		{source: "5000", want: "5000"}, // not in any band
+		{source: "twenty", want: "twenty"},
+	}
+	for _, tc := range tests {
+		for _, f := range fields {
+			bandf := "BAND"
+			if f == "FREQ_RX" {
+				bandf = "BAND_RX"
+			}
+			out := &bytes.Buffer{}
+			file1 := fmt.Sprintf("CALL,%s,%s\nK1A,%s,%s\n", f, bandf, tc.source, tc.band)
+			ctx := &Context{
+				OutputFormat: adif.FormatADI,
+				Readers:      readers(adi, csv),
+				Writers:      writers(adi, csv),
+				Out:          out,
+				Prepare:      testPrepare("My Comment", "3.1.4", "fix test", "1.2.3"),
+				fs:           fakeFilesystem{map[string]string{"foo.csv": file1}}}
+			if err := Fix.Run(ctx, []string{"foo.csv"}); err != nil {
+				t.Errorf("Fix.Run(ctx, foo.csv) got error %v", err)
+			} else {
+				got := out.String()
+				want := fmt.Sprintf("%s<CALL:3>K1A <%s:%d>%s <%s:%d>%s <EOR>\n", header, f, len(tc.want), tc.want, bandf, len(tc.band), tc.band)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("fix %s=%s want %s got diff %s", f, tc.source, tc.want, diff)
+				}
+			}
+		}
+	}
+}
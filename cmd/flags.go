@@ -154,6 +154,65 @@ func (f *FieldAssignments) Set(s string) error {
 	return nil
 }
 
+// ConditionalAssignments is a flag value with field assignments which only
+// apply to records where another field has a particular value, written as
+// field=value:when:other=match, e.g.
+// QRZCOM_QSO_UPLOAD_STATUS=Y:when:LOTW_QSL_SENT=Y
+type ConditionalAssignments struct {
+	values []conditionalAssignment
+}
+
+type conditionalAssignment struct {
+	set, when adif.Field
+}
+
+const whenSeparator = ":when:"
+
+// matches returns true if the condition field in r equals the condition value,
+// ignoring case.  An empty condition value matches a blank or missing field.
+func (c conditionalAssignment) matches(r *adif.Record) bool {
+	f, _ := r.Get(c.when.Name)
+	return strings.EqualFold(strings.TrimSpace(f.Value), c.when.Value)
+}
+
+func (a *ConditionalAssignments) String() string {
+	res := make([]string, len(a.values))
+	for i, c := range a.values {
+		res[i] = c.set.String() + whenSeparator + c.when.String()
+	}
+	return strings.Join(res, ";;")
+}
+
+func (a *ConditionalAssignments) Set(s string) error {
+	vals := make([]conditionalAssignment, 0)
+	for _, c := range strings.Split(s, ";;") {
+		c = strings.TrimSpace(c)
+		i := strings.LastIndex(c, whenSeparator)
+		if i < 0 {
+			return fmt.Errorf(`expected "name=value%sfield=value", got %q`, whenSeparator, c)
+		}
+		var ca conditionalAssignment
+		for _, x := range []struct {
+			s string
+			f *adif.Field
+		}{{c[:i], &ca.set}, {c[i+len(whenSeparator):], &ca.when}} {
+			key, val, found := strings.Cut(x.s, "=")
+			key = strings.ToUpper(strings.TrimSpace(key))
+			if !found || key == "" {
+				return fmt.Errorf(`expected "name=value%sfield=value", got %q`, whenSeparator, c)
+			}
+			if err := ValidateAlphanumName(key, val); err != nil {
+				return fmt.Errorf("validation error on %q: %v", c, err)
+			}
+			*x.f = adif.Field{Name: key, Value: val}
+		}
+		ca.when.Value = strings.TrimSpace(ca.when.Value)
+		vals = append(vals, ca)
+	}
+	a.values = append(a.values, vals...)
+	return nil
+}
+
 type FieldDelimiters map[string]string
 
 func (f FieldDelimiters) String() string {
@@ -211,3 +270,117 @@ func (z *TimeZone) Set(s string) error {
 	z.tz = l
 	return nil
 }
+
+// RecordIndex is an optional zero-based position of a record in a file.
+type RecordIndex struct {
+	idx int
+	set bool
+}
+
+func (r *RecordIndex) String() string {
+	if !r.set {
+		return ""
+	}
+	return strconv.Itoa(r.idx)
+}
+
+// Get returns the record position and whether the value was set.
+func (r *RecordIndex) Get() (int, bool) { return r.idx, r.set }
+
+func (r *RecordIndex) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return fmt.Errorf("invalid record number %q, must be 0 or greater", s)
+	}
+	r.idx, r.set = i, true
+	return nil
+}
+
+// Severity is the level of a validation problem: warning or error.
+type Severity int
+
+const (
+	SeverityUnset Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s *Severity) String() string {
+	switch *s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return ""
+}
+
+func (s *Severity) Get() Severity { return *s }
+
+func (s *Severity) Set(v string) error {
+	switch strings.ToLower(v) {
+	case "warning", "warn":
+		*s = SeverityWarning
+	case "error":
+		*s = SeverityError
+	default:
+		return fmt.Errorf("unknown severity %q, expected warning or error", v)
+	}
+	return nil
+}
+
+// TeeOutput is a file written by the tee command.
+type TeeOutput struct {
+	File   string
+	Format adif.Format
+	Preset Preset
+}
+
+func (o TeeOutput) String() string {
+	prefix := string(o.Format)
+	if o.Preset.Name != "" {
+		prefix = o.Preset.Name
+	}
+	return prefix + ":" + o.File
+}
+
+type TeeOutputList []TeeOutput
+
+func (l *TeeOutputList) String() string {
+	s := make([]string, len(*l))
+	for i, o := range *l {
+		s[i] = o.String()
+	}
+	return strings.Join(s, ",")
+}
+
+func (l *TeeOutputList) Get() TeeOutputList { return *l }
+
+func (l *TeeOutputList) Set(s string) error {
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return fmt.Errorf("empty output file in %q", s)
+		}
+		o := TeeOutput{File: v}
+		if prefix, file, ok := strings.Cut(v, ":"); ok {
+			if f, err := adif.ParseFormat(prefix); err == nil {
+				o = TeeOutput{File: file, Format: f}
+			} else if p, ok := Presets[strings.ToLower(prefix)]; ok {
+				o = TeeOutput{File: file, Preset: p}
+			}
+		}
+		if o.File == "" {
+			return fmt.Errorf("empty output file in %q", v)
+		}
+		if !o.Format.IsValid() {
+			f, err := adif.GuessFormatFromName(o.File)
+			if err != nil {
+				return fmt.Errorf("unknown format for %s, use format:%s: %w", o.File, o.File, err)
+			}
+			o.Format = f
+		}
+		*l = append(*l, o)
+	}
+	return nil
+}
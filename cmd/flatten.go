@@ -32,7 +32,14 @@ type FlattenContext struct {
 }
 
 func helpFlatten() string {
-	return "If multiple fields are given, a Cartesian combination will be output.\n"
+	return `If multiple fields are given, a Cartesian combination will be output.
+
+Default delimiters are comma for list types like AwardList, GridSquareList,
+and POTARefList, and colon for SecondarySubdivisionList.  SUBMODE is not
+usually a list, but splits on comma so names like "OLIVIA 16/500" stay intact.
+String fields such as CONTEST_ID, COMMENT, or STX_STRING have no default; set
+one with --delimiter, e.g. --delimiter 'CONTEST_ID= ' for space-separated values.
+`
 }
 
 func runFlatten(ctx *Context, args []string) error {
@@ -53,7 +60,10 @@ func runFlatten(ctx *Context, args []string) error {
 		if !ok {
 			return fmt.Errorf("unknown field %q", n)
 		}
-		d := typeDelims[f.Type]
+		d := fieldDelims[f.Name]
+		if d == "" {
+			d = typeDelims[f.Type]
+		}
 		if d == "" {
 			return fmt.Errorf("don't know delimiter for field %q of type %s", n, f.Type.Name)
 		}
@@ -110,3 +120,10 @@ var typeDelims = map[spec.DataType]string{
 	spec.SecondarySubdivisionListDataType: ":", // NV,Clark:UT,Washington
 	spec.SponsoredAwardListDataType:       ",",
 }
+
+// fieldDelims has default delimiters for fields whose data type doesn't imply
+// a list.
+var fieldDelims = map[string]string{
+	// submode names may contain a slash, e.g. OLIVIA 16/500
+	spec.SubmodeField.Name: ",",
+}
@@ -125,3 +125,71 @@ K3C	0303	JKL	QPO
 		}
 	}
 }
+
+func TestFlattenCreditList(t *testing.T) {
+	tsv := adif.NewTSVIO()
+	out := &bytes.Buffer{}
+	file1 := `CALL	CREDIT_SUBMITTED	CREDIT_GRANTED
+K1A	IOTA,WAS:LOTW&CARD,DXCC:CARD	WAS;DXCC
+K2B	WAZ	
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatTSV,
+		Readers:      readers(tsv),
+		Writers:      writers(tsv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "flatten test", "1.2.3"),
+		fs:           fakeFilesystem{map[string]string{"foo.tsv": file1}},
+		CommandCtx: &FlattenContext{
+			Fields:     FieldList{"CREDIT_SUBMITTED", "CREDIT_GRANTED"},
+			Delimiters: FieldDelimiters{"CREDIT_GRANTED": ";"},
+		}}
+	if err := Flatten.Run(ctx, []string{"foo.tsv"}); err != nil {
+		t.Errorf("Flatten.Run(ctx, foo.tsv) got error %v", err)
+	} else {
+		got := out.String()
+		want := `CALL	CREDIT_SUBMITTED	CREDIT_GRANTED
+K1A	IOTA	WAS
+K1A	IOTA	DXCC
+K1A	WAS:LOTW&CARD	WAS
+K1A	WAS:LOTW&CARD	DXCC
+K1A	DXCC:CARD	WAS
+K1A	DXCC:CARD	DXCC
+K2B	WAZ	
+`
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Flatten.Run(ctx, foo.tsv) unexpected output, diff:\n%s", diff)
+		}
+	}
+}
+
+func TestFlattenSubmode(t *testing.T) {
+	tsv := adif.NewTSVIO()
+	out := &bytes.Buffer{}
+	file1 := `CALL	MODE	SUBMODE
+K1A	MFSK	OLIVIA 16/500
+K2B	MFSK	FT8,FT4
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatTSV,
+		Readers:      readers(tsv),
+		Writers:      writers(tsv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "flatten test", "1.2.3"),
+		fs:           fakeFilesystem{map[string]string{"foo.tsv": file1}},
+		CommandCtx:   &FlattenContext{Fields: FieldList{"SUBMODE"}, Delimiters: make(FieldDelimiters)},
+	}
+	if err := Flatten.Run(ctx, []string{"foo.tsv"}); err != nil {
+		t.Errorf("Flatten.Run(ctx, foo.tsv) got error %v", err)
+	} else {
+		got := out.String()
+		want := `CALL	MODE	SUBMODE
+K1A	MFSK	OLIVIA 16/500
+K2B	MFSK	FT8
+K2B	MFSK	FT4
+`
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Flatten.Run(ctx, foo.tsv) unexpected output, diff:\n%s", diff)
+		}
+	}
+}
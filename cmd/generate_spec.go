@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+var GenerateSpec = Command{Name: "generate-spec", Run: runGenerateSpec, Help: helpGenerateSpec,
+	Description: "Print ADIF specification fields and enumerations as JSON"}
+
+// FieldSpec describes an ADIF field for tools which don't use the spec package.
+type FieldSpec struct {
+	Name        string
+	DataType    string
+	Enumeration string `json:",omitempty"`
+	EnumScope   string `json:",omitempty"`
+	Minimum     string `json:",omitempty"`
+	Maximum     string `json:",omitempty"`
+	Header      bool
+	ImportOnly  bool
+}
+
+// SpecSummary is the output of generate-spec.  Enumeration values are keyed
+// by enumeration name and have all properties from the specification.
+type SpecSummary struct {
+	ADIFVersion  string
+	ADIFSpecURL  string
+	Fields       []FieldSpec
+	Enumerations map[string][]spec.EnumValue
+}
+
+func helpGenerateSpec() string {
+	return `Prints a JSON object with the ADIF specification version built into this
+program, all fields with their data type, enumeration, and allowed range, and
+all enumerations with each value's properties.  Fields are sorted by name.
+Does not read any input files.  The only output format is json; --json-indent
+applies.
+`
+}
+
+func runGenerateSpec(ctx *Context, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("does not read input files, got %v", args)
+	}
+	if ctx.OutputFormat.IsValid() && ctx.OutputFormat != adif.FormatJSON {
+		return fmt.Errorf("only json output is supported, not %s", ctx.OutputFormat)
+	}
+	s := newSpecSummary()
+	e := json.NewEncoder(ctx.Out)
+	if j, ok := ctx.Writers[adif.FormatJSON].(*adif.JSONIO); ok {
+		e.SetIndent("", strings.Repeat(" ", j.Indent))
+		e.SetEscapeHTML(j.HTMLSafe)
+	}
+	if err := e.Encode(s); err != nil {
+		return fmt.Errorf("JSON encoding error: %w", err)
+	}
+	return nil
+}
+
+func newSpecSummary() SpecSummary {
+	s := SpecSummary{
+		ADIFVersion:  spec.ADIFVersion,
+		ADIFSpecURL:  spec.ADIFSpecURL,
+		Fields:       make([]FieldSpec, 0, len(spec.Fields)),
+		Enumerations: make(map[string][]spec.EnumValue, len(spec.Enumerations)),
+	}
+	for _, f := range spec.Fields {
+		s.Fields = append(s.Fields, FieldSpec{
+			Name:        f.Name,
+			DataType:    f.Type.Name,
+			Enumeration: f.EnumName,
+			EnumScope:   f.EnumScope,
+			Minimum:     f.Minimum,
+			Maximum:     f.Maximum,
+			Header:      f.Header,
+			ImportOnly:  f.ImportOnly,
+		})
+	}
+	sort.Slice(s.Fields, func(i, j int) bool { return s.Fields[i].Name < s.Fields[j].Name })
+	for name, e := range spec.Enumerations {
+		s.Enumerations[name] = e.Values
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateSpec(t *testing.T) {
+	out := &bytes.Buffer{}
+	ctx := &Context{OutputFormat: adif.FormatJSON, Writers: writers(adif.NewJSONIO()), Out: out}
+	if err := GenerateSpec.Run(ctx, []string{}); err != nil {
+		t.Fatalf("GenerateSpec.Run(ctx) got error %v", err)
+	}
+	var got struct {
+		ADIFVersion  string
+		Fields       []FieldSpec
+		Enumerations map[string][]map[string]string
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("GenerateSpec.Run(ctx) invalid JSON %v:\n%s", err, out)
+	}
+	if got.ADIFVersion != spec.ADIFVersion {
+		t.Errorf("GenerateSpec.Run(ctx) ADIFVersion got %q want %q", got.ADIFVersion, spec.ADIFVersion)
+	}
+	if len(got.Fields) != len(spec.Fields) {
+		t.Errorf("GenerateSpec.Run(ctx) got %d fields, want %d", len(got.Fields), len(spec.Fields))
+	}
+	wantFields := map[string]FieldSpec{
+		"AGE":      {Name: "AGE", DataType: "Number", Minimum: "0", Maximum: "120"},
+		"ADIF_VER": {Name: "ADIF_VER", DataType: "String", Header: true},
+		"SUBMODE":  {Name: "SUBMODE", DataType: "String", Enumeration: "Submode", EnumScope: "MODE"},
+	}
+	for i, f := range got.Fields {
+		if i > 0 && got.Fields[i-1].Name >= f.Name {
+			t.Errorf("GenerateSpec.Run(ctx) fields not sorted: %s before %s", got.Fields[i-1].Name, f.Name)
+		}
+		if want, ok := wantFields[f.Name]; ok {
+			if diff := cmp.Diff(want, f); diff != "" {
+				t.Errorf("GenerateSpec.Run(ctx) field %s diff:\n%s", f.Name, diff)
+			}
+		}
+	}
+	bands := got.Enumerations[spec.BandEnumeration.Name]
+	if len(bands) != len(spec.BandEnumeration.Values) {
+		t.Errorf("GenerateSpec.Run(ctx) got %d bands, want %d", len(bands), len(spec.BandEnumeration.Values))
+	} else if bands[0]["Band"] != "2190m" || bands[0]["LowerFreqMhz"] != ".1357" {
+		t.Errorf("GenerateSpec.Run(ctx) got first band %v, want 2190m", bands[0])
+	}
+
+	ctx.OutputFormat = adif.FormatCSV
+	if err := GenerateSpec.Run(ctx, []string{}); err == nil {
+		t.Errorf("GenerateSpec.Run(ctx) with CSV output want error")
+	}
+}
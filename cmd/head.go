@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "github.com/flwyd/adif-multitool/adif"
+
+var Head = Command{Name: "head", Run: runHead, Help: helpHead,
+	Description: "Print the first records from the input"}
+
+type HeadContext struct {
+	Count int
+}
+
+func helpHead() string {
+	return `Records from all input files are counted together, as if they were combined
+with cat.  If count is negative, prints all but the last -count records.
+Once count records have been read, the rest of the input is not read.
+`
+}
+
+func runHead(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*HeadContext)
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	recs := make([]*adif.Record, 0, maxInt(cctx.Count, 0))
+	for _, f := range filesOrStdin(args) {
+		if cctx.Count >= 0 && len(recs) >= cctx.Count {
+			break
+		}
+		// stop parsing the file once there are enough records
+		l, err := acc.readRecords(f, func(_ *adif.Logfile, r *adif.Record) bool {
+			recs = append(recs, r)
+			return cctx.Count < 0 || len(recs) < cctx.Count
+		})
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+	}
+	if cctx.Count >= 0 {
+		if len(recs) > cctx.Count {
+			recs = recs[:cctx.Count]
+		}
+	} else {
+		recs = recs[:maxInt(len(recs)+cctx.Count, 0)]
+	}
+	acc.Out.Records = append(acc.Out.Records, recs...)
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
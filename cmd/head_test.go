@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHead(t *testing.T) {
+	csv := adif.NewCSVIO()
+	file1 := `CALL,BAND
+K1A,20m
+K2B,40m
+`
+	file2 := `CALL,MODE
+K3C,CW
+K4D,SSB
+`
+	tests := []struct {
+		count int
+		want  string
+	}{
+		// later files aren't read once count is reached, so their fields are absent
+		{count: 0, want: ""},
+		{count: 1, want: "CALL,BAND\nK1A,20m\n"},
+		{count: 2, want: "CALL,BAND\nK1A,20m\nK2B,40m\n"},
+		{count: 3, want: "CALL,BAND,MODE\nK1A,20m,\nK2B,40m,\nK3C,,CW\n"},
+		{count: 10, want: "CALL,BAND,MODE\nK1A,20m,\nK2B,40m,\nK3C,,CW\nK4D,,SSB\n"},
+		{count: -1, want: "CALL,BAND,MODE\nK1A,20m,\nK2B,40m,\nK3C,,CW\n"},
+		{count: -3, want: "CALL,BAND,MODE\nK1A,20m,\n"},
+		{count: -5, want: "CALL,BAND,MODE\n"},
+	}
+	for _, tc := range tests {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          out,
+			Prepare:      testPrepare("My Comment", "3.1.4", "head test", "1.2.3"),
+			CommandCtx:   &HeadContext{Count: tc.count},
+			fs:           fakeFilesystem{map[string]string{"foo.csv": file1, "bar.csv": file2}}}
+		if err := Head.Run(ctx, []string{"foo.csv", "bar.csv"}); err != nil {
+			t.Errorf("Head.Run(ctx) count %d got error %v", tc.count, err)
+		} else if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+			t.Errorf("Head.Run(ctx) count %d unexpected output, diff:\n%s", tc.count, diff)
+		}
+	}
+}
+
+func TestHeadSkipsRemainingFiles(t *testing.T) {
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "head test", "1.2.3"),
+		CommandCtx:   &HeadContext{Count: 1},
+		fs:           fakeFilesystem{map[string]string{"foo.csv": "CALL\nK1A\n"}}}
+	// missing.csv would be an error if it were read
+	if err := Head.Run(ctx, []string{"foo.csv", "missing.csv"}); err != nil {
+		t.Errorf("Head.Run(ctx) got error %v", err)
+	} else if diff := cmp.Diff("CALL\nK1A\n", out.String()); diff != "" {
+		t.Errorf("Head.Run(ctx) unexpected output, diff:\n%s", diff)
+	}
+}
+
+func TestHeadStopsReading(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	// the unfinished tag at the end would be an error if it were parsed
+	file := "<EOH>\n<CALL:3>K1A <EOR>\n<CALL:3>K2B <EOR>\n<CALL:3>K3C <EOR>\n<CALL:3"
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(adi, csv),
+		Writers:      writers(adi, csv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "head test", "1.2.3"),
+		CommandCtx:   &HeadContext{Count: 2},
+		fs:           fakeFilesystem{map[string]string{"foo.adi": file}}}
+	if err := Head.Run(ctx, []string{"foo.adi"}); err != nil {
+		t.Errorf("Head.Run(ctx) got error %v", err)
+	} else if diff := cmp.Diff("CALL\nK1A\nK2B\n", out.String()); diff != "" {
+		t.Errorf("Head.Run(ctx) unexpected output, diff:\n%s", diff)
+	}
+	ctx.CommandCtx = &HeadContext{Count: 4}
+	if err := Head.Run(ctx, []string{"foo.adi"}); err == nil {
+		t.Errorf("Head.Run(ctx) with count 4 want error reading unfinished tag, got %s", out)
+	}
+}
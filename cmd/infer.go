@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"regexp"
@@ -24,6 +23,7 @@ import (
 
 	"github.com/flwyd/adif-multitool/adif"
 	"github.com/flwyd/adif-multitool/adif/spec"
+	"golang.org/x/exp/slices"
 )
 
 var Infer = Command{Name: "infer", Run: runInfer, Help: helpInfer,
@@ -32,8 +32,20 @@ var Infer = Command{Name: "infer", Run: runInfer, Help: helpInfer,
 type InferContext struct {
 	Fields     FieldList
 	CommentLog bool
+	// InferFrom is the list of free-text fields to search for heuristic
+	// inference such as CW speed, defaults to COMMENT and NOTES.
+	InferFrom FieldList
 }
 
+// speedField holds CW keying speed in words per minute.  It is not part of the
+// ADIF specification, but is exported by several logging programs.
+const speedField = "SPEED"
+
+var (
+	defaultInferFrom = FieldList{spec.CommentField.Name, spec.NotesField.Name}
+	wpmPat           = regexp.MustCompile(`(?i)\b(\d{1,3})\s*(?:wpm|words\s+per\s+minute)\b`)
+)
+
 type inferrer func(*adif.Record, string) bool
 
 var inferrers = map[string]inferrer{
@@ -74,6 +86,7 @@ var inferrers = map[string]inferrer{
 	spec.MySotaRefField.Name:       inferProgramRef("SOTA"),
 	spec.WwffRefField.Name:         inferProgramRef("WWFF"),
 	spec.MyWwffRefField.Name:       inferProgramRef("WWFF"),
+	speedField:                     inferSpeed(defaultInferFrom),
 }
 
 func helpInfer() string {
@@ -129,15 +142,24 @@ func helpInfer() string {
 		fmt.Fprintf(res, progfmt, p.field.Name, spec.SigInfoField.Name, spec.SigField.Name, p.prog)
 		fmt.Fprintf(res, progfmt, "MY_"+p.field.Name, spec.MySigInfoField.Name, spec.MySigField.Name, p.prog)
 	}
+	fmt.Fprintf(res, "  %s (CW words per minute, not an ADIF field) from text like \"25 wpm\" in --infer-from fields (default %s)\n", speedField, strings.Join(defaultInferFrom, ","))
 	return res.String()
 }
 
 func runInfer(ctx *Context, args []string) error {
 	cctx := ctx.CommandCtx.(*InferContext)
+	funcs := inferrers
+	if len(cctx.InferFrom) > 0 {
+		funcs = make(map[string]inferrer, len(inferrers))
+		for k, v := range inferrers {
+			funcs[k] = v
+		}
+		funcs[speedField] = inferSpeed(cctx.InferFrom)
+	}
 	todo := make([]string, len(cctx.Fields))
 	for i, f := range cctx.Fields {
 		todo[i] = strings.ToUpper(f)
-		if inferrers[todo[i]] == nil {
+		if funcs[todo[i]] == nil {
 			return fmt.Errorf("don't know how to infer field %s\n%s", todo[i], helpInfer())
 		}
 	}
@@ -154,9 +176,9 @@ func runInfer(ctx *Context, args []string) error {
 		for _, r := range l.Records {
 			did := make([]string, 0, len(todo))
 			for _, t := range todo {
-				if inferrers[t] != nil {
+				if funcs[t] != nil {
 					if f, ok := r.Get(t); !ok || f.Value == "" {
-						if inferrers[t](r, t) {
+						if funcs[t](r, t) {
 							did = append(did, t)
 						}
 					}
@@ -199,22 +221,30 @@ func inferBand(r *adif.Record, name string) bool {
 	if err != nil {
 		return false
 	}
+	if b, ok := bandForFreq(freq); ok {
+		r.Set(adif.Field{Name: name, Value: b.Band})
+		return true
+	}
+	return false
+}
+
+// bandForFreq returns the amateur band containing freq in megahertz.
+func bandForFreq(freq float64) (spec.BandEnum, bool) {
 	for _, b := range spec.BandEnumeration.Values {
 		bb := b.(spec.BandEnum)
 		min, err := strconv.ParseFloat(bb.LowerFreqMhz, 64)
 		if err != nil {
-			return false
+			return spec.BandEnum{}, false
 		}
 		max, err := strconv.ParseFloat(bb.UpperFreqMhz, 64)
 		if err != nil {
-			return false
+			return spec.BandEnum{}, false
 		}
 		if min <= freq && freq <= max {
-			r.Set(adif.Field{Name: name, Value: bb.Band})
-			return true
+			return bb, true
 		}
 	}
-	return false
+	return spec.BandEnum{}, false
 }
 
 func inferCountry(r *adif.Record, name string) bool {
@@ -264,6 +294,26 @@ func inferMode(r *adif.Record, name string) bool {
 	return false
 }
 
+// inferSpeed returns an inferrer which sets CW speed from the first field in
+// from with a value like "25wpm" or "18 words per minute".
+func inferSpeed(from FieldList) inferrer {
+	return func(r *adif.Record, name string) bool {
+		for _, n := range from {
+			f, ok := r.Get(n)
+			if !ok {
+				continue
+			}
+			if m := wpmPat.FindStringSubmatch(f.Value); m != nil {
+				if wpm, err := strconv.Atoi(m[1]); err == nil && wpm > 0 {
+					r.Set(adif.Field{Name: name, Value: strconv.Itoa(wpm), Type: adif.TypeNumber})
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
 func inferSigInfo(r *adif.Record, name string) bool {
 	my := myPrefix(name)
 	islota, iotaok := r.Get(my(spec.IotaField.Name))
@@ -432,16 +482,17 @@ func inferZone(r *adif.Record, name string) bool {
 	}
 	// Multiple zones for some countries, check zone of subdivision
 	if st, ok := r.Get(my(spec.StateField.Name)); ok && st.Value != "" {
-		vs := spec.PrimaryAdministrativeSubdivisionEnumeration.Value(st.Value)
-		for _, v := range vs {
-			a := v.(spec.PrimaryAdministrativeSubdivisionEnum)
+		for _, a := range spec.PrimaryAdminSubdivisionFor(dxcc) {
+			if !strings.EqualFold(a.Code, st.Value) {
+				continue
+			}
 			var zone string
 			if iscq {
 				zone = a.CqZone
 			} else if isitu {
 				zone = a.ItuZone
 			}
-			if a.DxccEntityCode == dxcc && zone != "" {
+			if zone != "" {
 				// Some Canadian provinces/territories have comma-separated zones
 				if z, err := strconv.Atoi(zone); err == nil {
 					r.Set(adif.Field{Name: name, Value: strconv.Itoa(z)})
@@ -450,6 +501,18 @@ func inferZone(r *adif.Record, name string) bool {
 			}
 		}
 	}
+	// Otherwise check grid square for countries with known CQ zone boundaries
+	if g, ok := r.Get(my(spec.GridsquareField.Name)); ok && g.Value != "" && iscq {
+		for _, v := range spec.CountryEnumeration.Values {
+			if c := v.(spec.CountryEnum); c.EntityCode == strings.TrimLeft(dxcc, "0") {
+				if z := c.CQZoneForGrid(g.Value); z != 0 && slices.Contains(zs, z) {
+					r.Set(adif.Field{Name: name, Value: strconv.Itoa(z)})
+					return true
+				}
+				break
+			}
+		}
+	}
 	return false
 }
 
@@ -507,27 +570,28 @@ func inferLatLon(r *adif.Record, name string) bool {
 	return false
 }
 
+// parseLatLon parses a latitude field like LAT or MY_LAT and the matching
+// longitude field into decimal degrees.
+func parseLatLon(r *adif.Record, latName string) (lat, lon float64, err error) {
+	la, _ := r.Get(latName)
+	lo, _ := r.Get(strings.TrimSuffix(latName, "LAT") + "LON")
+	return spec.ParseLatLon(la.Value, lo.Value)
+}
+
 func inferGridsquare(r *adif.Record, name string) bool {
 	my := myPrefix(name)
-	var latf, lonf string
-	if f, ok := r.Get(my(spec.LatField.Name)); ok && f.Value != "" {
-		latf = f.Value
-	} else {
-		return false
-	}
-	if f, ok := r.Get(my(spec.LonField.Name)); ok && f.Value != "" {
-		lonf = f.Value
-	} else {
-		return false
-	}
-	lat, lon, err := parseADIFCoordinates(latf, lonf)
+	lat, lon, err := parseLatLon(r, my(spec.LatField.Name))
 	if err != nil {
-		fmt.Println(err)
 		return false
 	}
 	// Maidenhead locator uses positive values from south pole and antiprime meridian
 	lat += 90
 	lon += 180
+	// E180 is the same meridian as W180 and the north pole is in the top row
+	lon = math.Mod(lon, 360)
+	if lat >= 180 {
+		lat = math.Nextafter(180, 0)
+	}
 	lons := maidenheadSlice{rem: lon, scale: 360}
 	lats := maidenheadSlice{rem: lat, scale: 180}
 	var gs strings.Builder
@@ -567,93 +631,11 @@ func (s *maidenheadSlice) split(num int) int {
 	return d
 }
 
-func parseADIFCoordinates(lat, lon string) (float64, float64, error) {
-	if lat == "" || lon == "" {
-		return 0, 0, fmt.Errorf("empty coordinate %q, %q", lat, lon)
-	}
-	pat := "%c%03d %6f"
-	var latdir, londir rune
-	var latdeg, londeg int
-	var latmin, lonmin float64
-	if n, err := fmt.Sscanf(lat, pat, &latdir, &latdeg, &latmin); err != nil || n != 3 {
-		return 0, 0, fmt.Errorf("could not parse latitude %q: %w", lat, err)
-	}
-	if n, err := fmt.Sscanf(lon, pat, &londir, &londeg, &lonmin); err != nil || n != 3 {
-		return 0, 0, fmt.Errorf("could not parse longitude %q: %w", lon, err)
-	}
-	var latsign, lonsign float64
-	switch latdir {
-	case 'N', 'n':
-		latsign = 1
-	case 'S', 's':
-		latsign = -1
-	default:
-		return 0, 0, fmt.Errorf("invalid latitude direction %c", latdir)
-	}
-	switch londir {
-	case 'E', 'e':
-		lonsign = 1
-	case 'W', 'w':
-		lonsign = -1
-	default:
-		return 0, 0, fmt.Errorf("invalid longitude direction %c", londir)
-	}
-	if latmin > 60 || lonmin > 60 || latmin < 0 || lonmin < 0 {
-		return 0, 0, fmt.Errorf("minutes out of range: %s, %s", lat, lon)
-	}
-	rlat := latsign * (float64(latdeg) + latmin/60.0)
-	rlon := lonsign * (float64(londeg) + lonmin/60.0)
-	if math.Abs(rlat) > 90 || math.Abs(rlon) > 180 {
-		return 0, 0, fmt.Errorf("coordinates out of range %s, %s", lat, lon)
-	}
-	return rlat, rlon, nil
-}
-
 func parseMaidenhead(gs string) (lat float64, lon float64, err error) {
-	if len(gs) < 2 {
-		err = errors.New("empty string")
-		return
-	}
-	invalid := fmt.Errorf("invalid format %q", gs)
-	if len(gs)%2 != 0 {
-		err = invalid
-		return
-	}
-	gs = strings.ToUpper(gs)
-	lonscale := 360.0
-	latscale := 180.0
-	sizes := []float64{18, 10, 24, 10, 24, 10}
-	for i, size := range sizes {
-		if len(gs) <= i*2 {
-			break
-		}
-		lonr := rune(gs[i*2])
-		latr := rune(gs[i*2+1])
-		var lonval, latval int
-		if size == 10 {
-			if lonr < '0' || latr < '0' || lonr > '9' || latr > '9' {
-				err = invalid
-				return
-			}
-			lonval = int(lonr - '0')
-			latval = int(latr - '0')
-		} else {
-			if lonr < 'A' || latr < 'A' || lonr > 'A'+rune(size) || latr > 'A'+rune(size) {
-				err = invalid
-				return
-			}
-			lonval = int(lonr - 'A')
-			latval = int(latr - 'A')
-		}
-		lonscale /= size
-		latscale /= size
-		lon += lonscale * float64(lonval)
-		lat += latscale * float64(latval)
-	}
-	// center result in remaining square, then shift to negative/positive coords
-	lon += lonscale / 2
-	lat += latscale / 2
-	lon -= 180
-	lat -= 90
-	return
+	minLat, maxLat, minLon, maxLon, err := spec.GridsquareBoundingBox(gs)
+	if err != nil {
+		return 0, 0, err
+	}
+	// center of the square
+	return (minLat + maxLat) / 2, (minLon + maxLon) / 2, nil
 }
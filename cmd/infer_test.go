@@ -41,6 +41,7 @@ func TestInfer(t *testing.T) {
 	tests := []struct {
 		name        string
 		infer       FieldList
+		inferFrom   FieldList
 		start, want []adif.Field
 	}{
 		{
@@ -148,6 +149,30 @@ func TestInfer(t *testing.T) {
 			start: []adif.Field{{Name: "DXCC", Value: spec.CountryCanada.EntityCode}, {Name: "STATE", Value: "NL"}, {Name: "MY_DXCC", Value: "230"}, {Name: "STATE", Value: "BY"}},
 			want:  []adif.Field{{Name: "DXCC", Value: spec.CountryCanada.EntityCode}, {Name: "STATE", Value: "NL"}, {Name: "MY_DXCC", Value: "230"}, {Name: "STATE", Value: "BY"}},
 		},
+		{
+			name:  "cqz + my_cq_zone from grid square",
+			infer: FieldList{"CQZ", "MY_CQ_ZONE"},
+			start: []adif.Field{{Name: "DXCC", Value: spec.CountryCanada.EntityCode}, {Name: "GRIDSQUARE", Value: "FN03hp"}, {Name: "MY_DXCC", Value: spec.CountryUnitedStatesOfAmerica.EntityCode}, {Name: "MY_GRIDSQUARE", Value: "DN70"}},
+			want:  []adif.Field{{Name: "DXCC", Value: spec.CountryCanada.EntityCode}, {Name: "GRIDSQUARE", Value: "FN03hp"}, {Name: "MY_DXCC", Value: spec.CountryUnitedStatesOfAmerica.EntityCode}, {Name: "MY_GRIDSQUARE", Value: "DN70"}, {Name: "CQZ", Value: "4"}, {Name: "MY_CQ_ZONE", Value: "4"}},
+		},
+		{
+			name:  "cqz from state before grid square",
+			infer: FieldList{"CQZ"},
+			start: []adif.Field{{Name: "DXCC", Value: spec.CountryUnitedStatesOfAmerica.EntityCode}, {Name: "STATE", Value: "CA"}, {Name: "GRIDSQUARE", Value: "FN31"}},
+			want:  []adif.Field{{Name: "DXCC", Value: spec.CountryUnitedStatesOfAmerica.EntityCode}, {Name: "STATE", Value: "CA"}, {Name: "GRIDSQUARE", Value: "FN31"}, {Name: "CQZ", Value: "3"}},
+		},
+		{
+			name:  "cqz Australia from grid square",
+			infer: FieldList{"CQZ"},
+			start: []adif.Field{{Name: "COUNTRY", Value: "Australia"}, {Name: "GRIDSQUARE", Value: "OF78"}},
+			want:  []adif.Field{{Name: "COUNTRY", Value: "Australia"}, {Name: "GRIDSQUARE", Value: "OF78"}, {Name: "CQZ", Value: "29"}},
+		},
+		{
+			name:  "can't infer cqz from grid square in another country",
+			infer: FieldList{"CQZ"},
+			start: []adif.Field{{Name: "DXCC", Value: spec.CountryCanada.EntityCode}, {Name: "GRIDSQUARE", Value: "CN87"}},
+			want:  []adif.Field{{Name: "DXCC", Value: spec.CountryCanada.EntityCode}, {Name: "GRIDSQUARE", Value: "CN87"}},
+		},
 
 		{
 			name:  "ituz + my_itu_zone India + Armenia",
@@ -255,6 +280,30 @@ func TestInfer(t *testing.T) {
 			start: []adif.Field{{Name: "MY_LAT", Value: "N089 59.999"}, {Name: "MY_LON", Value: "E179 59.999"}},
 			want:  []adif.Field{{Name: "MY_LAT", Value: "N089 59.999"}, {Name: "MY_LON", Value: "E179 59.999"}, {Name: "MY_GRIDSQUARE", Value: "RR99xx99"}, {Name: "MY_GRIDSQUARE_EXT", Value: "xx99"}},
 		},
+		{
+			name:  "my_gridsquare south pole",
+			infer: FieldList{"MY_GRIDSQUARE"},
+			start: []adif.Field{{Name: "MY_LAT", Value: "S090 00.000"}, {Name: "MY_LON", Value: "E000 00.000"}},
+			want:  []adif.Field{{Name: "MY_LAT", Value: "S090 00.000"}, {Name: "MY_LON", Value: "E000 00.000"}, {Name: "MY_GRIDSQUARE", Value: "JA00aa00"}},
+		},
+		{
+			name:  "my_gridsquare exactly north pole",
+			infer: FieldList{"MY_GRIDSQUARE"},
+			start: []adif.Field{{Name: "MY_LAT", Value: "N090 00.000"}, {Name: "MY_LON", Value: "W000 00.000"}},
+			want:  []adif.Field{{Name: "MY_LAT", Value: "N090 00.000"}, {Name: "MY_LON", Value: "W000 00.000"}, {Name: "MY_GRIDSQUARE", Value: "JR09ax09"}},
+		},
+		{
+			name:  "my_gridsquare date line west",
+			infer: FieldList{"MY_GRIDSQUARE"},
+			start: []adif.Field{{Name: "MY_LAT", Value: "S016 30.000"}, {Name: "MY_LON", Value: "W180 00.000"}},
+			want:  []adif.Field{{Name: "MY_LAT", Value: "S016 30.000"}, {Name: "MY_LON", Value: "W180 00.000"}, {Name: "MY_GRIDSQUARE", Value: "AH03am00"}},
+		},
+		{
+			name:  "my_gridsquare date line east",
+			infer: FieldList{"MY_GRIDSQUARE"},
+			start: []adif.Field{{Name: "MY_LAT", Value: "S016 30.000"}, {Name: "MY_LON", Value: "E180 00.000"}},
+			want:  []adif.Field{{Name: "MY_LAT", Value: "S016 30.000"}, {Name: "MY_LON", Value: "E180 00.000"}, {Name: "MY_GRIDSQUARE", Value: "AH03am00"}},
+		},
 		{
 			name:  "lat lon null island",
 			infer: FieldList{"lat", "lon"},
@@ -453,6 +502,31 @@ func TestInfer(t *testing.T) {
 			want:  []adif.Field{{Name: "MY_WWFF_REF", Value: "P29FF-123"}, {Name: "MY_SIG_INFO", Value: "P29FF-123"}, {Name: "MY_SIG", Value: "WWFF"}},
 		},
 
+		{
+			name:  "speed from comment",
+			infer: FieldList{"SPEED"},
+			start: []adif.Field{{Name: "COMMENT", Value: "nice fist, 25wpm"}, {Name: "NOTES", Value: "18 WPM"}},
+			want:  []adif.Field{{Name: "COMMENT", Value: "nice fist, 25wpm"}, {Name: "NOTES", Value: "18 WPM"}, {Name: "SPEED", Value: "25", Type: adif.TypeNumber}},
+		},
+		{
+			name:  "speed from notes",
+			infer: FieldList{"speed"},
+			start: []adif.Field{{Name: "COMMENT", Value: "QRS please"}, {Name: "NOTES", Value: "sent at 12 words per minute"}},
+			want:  []adif.Field{{Name: "COMMENT", Value: "QRS please"}, {Name: "NOTES", Value: "sent at 12 words per minute"}, {Name: "SPEED", Value: "12", Type: adif.TypeNumber}},
+		},
+		{
+			name:      "speed infer from",
+			infer:     FieldList{"SPEED"},
+			inferFrom: FieldList{"APP_MYLOG_MEMO"},
+			start:     []adif.Field{{Name: "COMMENT", Value: "25wpm"}, {Name: "APP_MYLOG_MEMO", Value: "QSB, 030 Wpm"}},
+			want:      []adif.Field{{Name: "COMMENT", Value: "25wpm"}, {Name: "APP_MYLOG_MEMO", Value: "QSB, 030 Wpm"}, {Name: "SPEED", Value: "30", Type: adif.TypeNumber}},
+		},
+		{
+			name:  "speed not found",
+			infer: FieldList{"SPEED"},
+			start: []adif.Field{{Name: "COMMENT", Value: "73 from Maine"}, {Name: "NOTES", Value: "rig 100wpmx"}},
+			want:  []adif.Field{{Name: "COMMENT", Value: "73 from Maine"}, {Name: "NOTES", Value: "rig 100wpmx"}},
+		},
 		{
 			name: "no inference if missing",
 			infer: FieldList{
@@ -485,7 +559,7 @@ func TestInfer(t *testing.T) {
 				Writers:      writers(adi),
 				Out:          out,
 				fs:           fakeFilesystem{map[string]string{"foo.adi": in.String()}},
-				CommandCtx:   &InferContext{Fields: tc.infer}}
+				CommandCtx:   &InferContext{Fields: tc.infer, InferFrom: tc.inferFrom}}
 			if err := Infer.Run(ctx, []string{"foo.adi"}); err != nil {
 				t.Fatalf("Infer(%s) got error %v", in.String(), err)
 			}
@@ -16,6 +16,10 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -30,6 +34,9 @@ func write(ctx *Context, l *adif.Logfile) error {
 	if ctx.Prepare != nil {
 		ctx.Prepare(l)
 	}
+	if err := setAllFields(ctx, l); err != nil {
+		return err
+	}
 	format := ctx.OutputFormat
 	if !format.IsValid() {
 		format = adif.FormatADI
@@ -50,7 +57,73 @@ func write(ctx *Context, l *adif.Logfile) error {
 			l.Header = h
 		}
 	}
-	return w.Write(l, ctx.Out)
+	ctx.Preset.apply(l)
+	if ctx.OmitEmpty {
+		// CSV and TSV have fixed columns and Cabrillo never writes empty fields,
+		// so only ADI and ADX can leave empty fields out
+		switch o := w.(type) {
+		case *adif.ADIIO:
+			o.OmitEmpty = true
+		case *adif.ADXIO:
+			o.OmitEmpty = true
+		case *adif.ADIZIO:
+			o.ADI.OmitEmpty = true
+			o.ADX.OmitEmpty = true
+		}
+	}
+	if ctx.ShowProgress {
+		fmt.Fprintf(ctx.progressOut(), "writing %d records\n", len(l.Records))
+	}
+	switch ctx.Compress {
+	case "":
+		return w.Write(l, ctx.Out)
+	case "gzip":
+		z := gzip.NewWriter(ctx.Out)
+		if err := w.Write(l, z); err != nil {
+			return err
+		}
+		return z.Close()
+	default:
+		return fmt.Errorf("unknown compression %q", ctx.Compress)
+	}
+}
+
+// setAllFields applies the --set and --set-if-empty options to every record in
+// l, after the command has made its changes.
+func setAllFields(ctx *Context, l *adif.Logfile) error {
+	if len(ctx.SetFields.values) == 0 && len(ctx.SetIfEmpty.values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, f := range ctx.SetFields.values {
+		set[f.Name] = true
+	}
+	for _, f := range ctx.SetIfEmpty.values {
+		if set[f.Name] {
+			return fmt.Errorf("%q in both --set and --set-if-empty", f.Name)
+		}
+	}
+	for _, r := range l.Records {
+		if err := setFields(r, ctx.SetFields.values, false); err != nil {
+			return err
+		}
+		if err := setFields(r, ctx.SetIfEmpty.values, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gzipMagic is the start of a gzip stream, see RFC 1952.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// compressionFor returns gzip if output file name ends in .gz, so that its
+// contents match the name, and compress otherwise.
+func compressionFor(name, compress string) string {
+	if strings.EqualFold(filepath.Ext(name), ".gz") {
+		return "gzip"
+	}
+	return compress
 }
 
 func filesOrStdin(args []string) []string {
@@ -61,6 +134,17 @@ func filesOrStdin(args []string) []string {
 }
 
 func readFile(ctx *Context, filename string) (*adif.Logfile, error) {
+	return readRecords(ctx, filename, func(l *adif.Logfile, r *adif.Record) bool {
+		l.AddRecord(r)
+		return true
+	})
+}
+
+// readRecords reads filename like readFile, but passes each record to fn
+// rather than adding it to the logfile, see adif.RecordReader.  Formats which
+// don't implement RecordReader are read completely before calling fn.  If fn
+// returns false, the rest of the file is not read.
+func readRecords(ctx *Context, filename string, fn func(l *adif.Logfile, r *adif.Record) bool) (*adif.Logfile, error) {
 	fs := ctx.fs
 	if fs == nil {
 		fs = osFilesystem{}
@@ -70,7 +154,19 @@ func readFile(ctx *Context, filename string) (*adif.Logfile, error) {
 		return nil, err
 	}
 	defer f.Close()
-	ior := bufio.NewReader(f)
+	var in io.Reader = f
+	if ctx.ShowProgress {
+		in = newProgressReader(f, ctx.progressOut())
+	}
+	ior := bufio.NewReader(in)
+	if b, err := ior.Peek(len(gzipMagic)); err == nil && bytes.Equal(b, gzipMagic) {
+		z, err := gzip.NewReader(ior)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", f.Name(), err)
+		}
+		defer z.Close()
+		ior = bufio.NewReader(z)
+	}
 	format := ctx.InputFormat
 	if !format.IsValid() {
 		format, err = adif.GuessFormatFromName(f.Name())
@@ -81,15 +177,130 @@ func readFile(ctx *Context, filename string) (*adif.Logfile, error) {
 			}
 		}
 	}
-	r := ctx.Readers[format]
-	l, err := r.Read(ior)
+	r, ok := ctx.Readers[format]
+	if !ok {
+		return nil, fmt.Errorf("cannot read %s: %s is not an input format", f.Name(), format)
+	}
+	var count int
+	each := func(l *adif.Logfile, rec *adif.Record) bool {
+		count++
+		if ctx.ShowProgress && count%progressRecords == 0 {
+			fmt.Fprintf(ctx.progressOut(), "processing record %d from %s\n", count, f.Name())
+		}
+		if ctx.Preset.Read != nil {
+			rec = ctx.Preset.Read(rec)
+		}
+		return fn(l, rec)
+	}
+	var l *adif.Logfile
+	if rr, ok := r.(adif.RecordReader); ok {
+		l, err = rr.ReadRecords(ior, each)
+	} else if l, err = r.Read(ior); err == nil {
+		recs := l.Records
+		l.Records = nil
+		for _, rec := range recs {
+			if !each(l, rec) {
+				break
+			}
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", f.Name(), err)
 	}
 	l.Filename = f.Name()
+	if ctx.ShowProgress {
+		fmt.Fprintf(ctx.progressOut(), "read %d records from %s\n", count, f.Name())
+	}
 	return l, nil
 }
 
+// readCSVColumn returns the upper-case values of column in a reference list
+// CSV file such as a SOTA summits list, ignoring any lines before the header.
+// desc describes the file in error messages.
+func readCSVColumn(ctx *Context, filename, column, desc string) (map[string]bool, error) {
+	fs := ctx.fs
+	if fs == nil {
+		fs = osFilesystem{}
+	}
+	f, err := fs.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	col := -1
+	res := make(map[string]bool)
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s %s: %w", desc, filename, err)
+		}
+		if col < 0 {
+			for i, h := range row {
+				if strings.EqualFold(strings.TrimSpace(h), column) {
+					col = i
+				}
+			}
+			continue
+		}
+		if col < len(row) {
+			if s := strings.ToUpper(strings.TrimSpace(row[col])); s != "" {
+				res[s] = true
+			}
+		}
+	}
+	if col < 0 {
+		return nil, fmt.Errorf("no %s column in %s %s", column, desc, filename)
+	}
+	return res, nil
+}
+
+// progressReader prints the percent of a file which has been read to stderr,
+// or the number of megabytes read if the file size is not known, e.g. stdin.
+// Small files don't print progress, just the count after reading.
+type progressReader struct {
+	r                NamedReader
+	out              io.Writer
+	size, read, next int64
+}
+
+const (
+	progressMinSize  = 1 << 20
+	progressInterval = 10 << 20
+	progressRecords  = 1000 // print a message after this many records
+)
+
+func newProgressReader(r NamedReader, out io.Writer) *progressReader {
+	p := &progressReader{r: r, out: out, next: progressInterval}
+	if s, ok := r.(interface{ Stat() (os.FileInfo, error) }); ok {
+		if info, err := s.Stat(); err == nil && info.Mode().IsRegular() && info.Size() >= progressMinSize {
+			p.size = info.Size()
+			p.next = p.size / 10
+		}
+	}
+	return p
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	for p.read >= p.next && (p.size == 0 || p.next < p.size) {
+		if p.size > 0 {
+			fmt.Fprintf(p.out, "reading %s: %d%%\n", p.r.Name(), p.next*100/p.size)
+			p.next += p.size / 10
+		} else {
+			fmt.Fprintf(p.out, "reading %s: %d MB\n", p.r.Name(), p.next>>20)
+			p.next += progressInterval
+		}
+	}
+	return n, err
+}
+
 // NamedReader is an io.Reader with a name.  os.File implements this interface
 // and stringReader is provided for testing.
 type NamedReader interface {
@@ -105,8 +316,13 @@ type filesystem interface {
 	// Create creates a file and opens it for writing, truncating the file if it
 	// alrready exists.  See os.Create for more details.
 	Create(name string) (io.WriteCloser, error)
+	// Append opens an existing file for writing at the end of the file.
+	Append(name string) (io.WriteCloser, error)
 	// MkdirAll creates a directory for path and any needed parents
 	MkdirAll(dir string) error
+	// Replace overwrites an existing file with the output of write, leaving the
+	// file unchanged if write returns an error.
+	Replace(name string, write func(io.Writer) error) error
 }
 
 type osFilesystem struct{}
@@ -125,8 +341,60 @@ func (_ osFilesystem) Open(name string) (NamedReader, error) {
 
 func (_ osFilesystem) Create(name string) (io.WriteCloser, error) { return os.Create(name) }
 
+func (_ osFilesystem) Append(name string) (io.WriteCloser, error) {
+	return os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+}
+
 func (_ osFilesystem) MkdirAll(dir string) error { return os.MkdirAll(dir, 0777) }
 
+// Replace writes to a temporary file in the same directory as name and then
+// renames it, so name is never left partially written.
+func (_ osFilesystem) Replace(name string, write func(io.Writer) error) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	err = write(tmp)
+	if err == nil {
+		err = tmp.Chmod(info.Mode().Perm())
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("could not write %s: %w", name, err)
+	}
+	return nil
+}
+
+// isGzipped returns true if the named file starts with the gzip magic number,
+// i.e. readFile will decompress it.
+func isGzipped(ctx *Context, name string) (bool, error) {
+	fs := ctx.fs
+	if fs == nil {
+		fs = osFilesystem{}
+	}
+	f, err := fs.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	b := make([]byte, len(gzipMagic))
+	n, err := io.ReadFull(f, b)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, err
+	}
+	return bytes.Equal(b[:n], gzipMagic), nil
+}
+
 func updateFieldOrder(l *adif.Logfile, fields []string) {
 	seen := make(map[string]bool)
 	for _, f := range l.FieldOrder {
@@ -145,6 +413,7 @@ type accumulator struct {
 	Out      *adif.Logfile
 	Ctx      *Context
 	comments []string
+	written  bool
 }
 
 func newAccumulator(c *Context) (*accumulator, error) {
@@ -159,10 +428,33 @@ func newAccumulator(c *Context) (*accumulator, error) {
 }
 
 func (a *accumulator) read(filename string) (*adif.Logfile, error) {
-	l, err := readFile(a.Ctx, filename)
+	return a.readRecords(filename, func(l *adif.Logfile, r *adif.Record) bool {
+		l.AddRecord(r)
+		return true
+	})
+}
+
+// readRecords reads filename like read, but passes each record to fn like the
+// readRecords function.
+func (a *accumulator) readRecords(filename string, fn func(l *adif.Logfile, r *adif.Record) bool) (*adif.Logfile, error) {
+	l, err := readRecords(a.Ctx, filename, fn)
 	if err != nil {
 		return l, err
 	}
+	a.merge(filename, l)
+	return l, nil
+}
+
+// merge adds userdefs, app-defined header fields, and the comment from l to
+// the output logfile.
+func (a *accumulator) merge(filename string, l *adif.Logfile) {
+	a.mergeHeader(l)
+	a.mergeComment(filename, l)
+}
+
+// mergeHeader adds userdefs and app-defined header fields from l to the output
+// logfile.
+func (a *accumulator) mergeHeader(l *adif.Logfile) {
 	for _, u := range l.Userdef {
 		a.Out.AddUserdef(u)
 	}
@@ -175,6 +467,10 @@ func (a *accumulator) read(filename string) (*adif.Logfile, error) {
 			}
 		}
 	}
+}
+
+// mergeComment saves the comment from l to be added to the output logfile.
+func (a *accumulator) mergeComment(filename string, l *adif.Logfile) {
 	if c := l.Comment; c != "" {
 		prefix := "adif-multitool: original comment"
 		if !strings.HasPrefix(c, prefix) {
@@ -185,7 +481,6 @@ func (a *accumulator) read(filename string) (*adif.Logfile, error) {
 		}
 		a.comments = append(a.comments, c)
 	}
-	return l, err
 }
 
 func (a *accumulator) prepare() error {
@@ -199,6 +494,155 @@ func (a *accumulator) prepare() error {
 			a.Out.Comment += "\n\n"
 		}
 		a.Out.Comment += strings.Join(a.comments, "\n\n")
+		a.comments = nil
 	}
 	return nil
 }
+
+// ProcessBatch handles records read from in, adding records to be written to
+// the accumulator's Out logfile.  in.Records[0] is record number first
+// (counting from zero) in the file.
+type ProcessBatch func(in *adif.Logfile, first int) error
+
+// processBatches reads each file and calls fn with at most Context.BatchSize
+// records at a time, writing Out after each batch so the whole log isn't held
+// in memory.  If BatchSize is not set, fn is called once with all records in
+// each file.  Either way, write must be called to output any remaining records
+// and file comments.  When batching, the headers of all files except standard
+// input are read before the first batch so the output header includes their
+// userdefs and app-defined fields.
+func (a *accumulator) processBatches(filenames []string, fn ProcessBatch) error {
+	size := a.Ctx.BatchSize
+	if size > 0 && a.Ctx.OutputFormat.IsValid() && a.Ctx.OutputFormat != adif.FormatADI {
+		fmt.Fprintf(os.Stderr, "Warning: --batch-size only works with ADI output, reading all %s records at once\n", a.Ctx.OutputFormat)
+		size = 0
+	}
+	if size <= 0 {
+		for _, f := range filenames {
+			l, err := a.read(f)
+			if err != nil {
+				return err
+			}
+			if err := fn(l, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	headers := make([]bool, len(filenames))
+	hctx := *a.Ctx
+	hctx.ShowProgress = false
+	for i, f := range filenames {
+		if f == "-" {
+			continue // can't read standard input twice
+		}
+		l, err := readRecords(&hctx, f, func(l *adif.Logfile, r *adif.Record) bool { return false })
+		if err != nil {
+			return err
+		}
+		a.mergeHeader(l)
+		headers[i] = true
+	}
+	for i, f := range filenames {
+		var first int
+		merged := headers[i]
+		var ferr error
+		batch := func(l *adif.Logfile) bool {
+			if l.Filename == "" {
+				l.Filename = f
+				if f == "-" {
+					l.Filename = os.Stdin.Name()
+				}
+			}
+			if !merged {
+				if a.written && hasHeaderData(l) {
+					fmt.Fprintf(os.Stderr, "Warning: output header already written, discarding userdefs and app-defined header fields from %s\n", l.Filename)
+				}
+				a.mergeHeader(l)
+				merged = true
+			}
+			if ferr = fn(l, first); ferr == nil && len(a.Out.Records) > 0 {
+				ferr = a.writeBatch(false)
+			}
+			first += len(l.Records)
+			l.Records = nil
+			return ferr == nil
+		}
+		l, err := readRecords(a.Ctx, f, func(l *adif.Logfile, r *adif.Record) bool {
+			l.AddRecord(r)
+			return len(l.Records) < size || batch(l)
+		})
+		if ferr != nil {
+			return ferr
+		}
+		if err != nil {
+			return err
+		}
+		if len(l.Records) > 0 || !merged {
+			if batch(l); ferr != nil {
+				return ferr
+			}
+		}
+		a.mergeComment(f, l)
+	}
+	return nil
+}
+
+// hasHeaderData returns true if l has userdefs or app-defined header fields
+// which mergeHeader would add to the output.
+func hasHeaderData(l *adif.Logfile) bool {
+	if len(l.Userdef) > 0 {
+		return true
+	}
+	for _, f := range l.Header.Fields() {
+		if f.IsAppDefined() {
+			return true
+		}
+	}
+	return false
+}
+
+// write prints the records in Out, followed by file comments, and then
+// removes them.  write can be called after processBatches has written some
+// batches; only the first write of an accumulator includes a header.
+func (a *accumulator) write() error { return a.writeBatch(true) }
+
+// writeBatch prints and removes the records in Out.  Comments are only written
+// if last is true, since ADI puts them at the end of the file.
+func (a *accumulator) writeBatch(last bool) error {
+	if !a.written {
+		var comments []string
+		if !last {
+			comments, a.comments = a.comments, nil
+		}
+		if err := a.prepare(); err != nil {
+			return err
+		}
+		a.comments = comments
+		a.written = true
+		err := write(a.Ctx, a.Out)
+		a.Out.Records = nil
+		a.Out.Comment = ""
+		return err
+	}
+	if last && len(a.comments) > 0 {
+		a.Out.Comment = strings.Join(a.comments, "\n\n")
+		a.comments = nil
+	}
+	if len(a.Out.Records) == 0 && a.Out.Comment == "" {
+		return nil
+	}
+	adi, ok := a.Ctx.Writers[adif.FormatADI].(*adif.ADIIO)
+	if !ok {
+		adi = adif.NewADIIO()
+	}
+	w := *adi
+	w.AppendRecords = true
+	ctx := *a.Ctx
+	ctx.OutputFormat = adif.FormatADI
+	ctx.Writers = map[adif.Format]adif.Writer{adif.FormatADI: &w}
+	err := write(&ctx, a.Out)
+	a.Out.Records = nil
+	a.Out.Comment = ""
+	return err
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+var Lookup = Command{Name: "lookup", Run: runLookup, Help: helpLookup,
+	Description: "Print zone, DXCC, and country code data without reading a log"}
+
+type LookupContext struct {
+	// CQZone is a DXCC entity code or name to look up CQ zones for.
+	CQZone string
+	// ITUZone is a DXCC entity code or name to look up ITU zones for.
+	ITUZone string
+	// DXCCForCallsign is a callsign to look up possible DXCC entities for.
+	DXCCForCallsign string
+	// ISOFromDXCC is a DXCC entity code or name to look up ISO 3166-1 country
+	// codes for.
+	ISOFromDXCC string
+}
+
+const (
+	isoAlpha2Field  = "ISO_3166_ALPHA_2"
+	isoAlpha3Field  = "ISO_3166_ALPHA_3"
+	isoNumericField = "ISO_3166_NUMERIC"
+	isoNameField    = "ISO_3166_NAME"
+)
+
+func helpLookup() string {
+	return `Prints one record for each result, so --output csv or --output json can be
+used in shell scripts.  DXCC entities can be given as an entity code like 291
+or DXCC:291 or as an ADIF entity name like "Trinidad & Tobago".
+Several lookup options can be combined in one command.
+`
+}
+
+func runLookup(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*LookupContext)
+	if len(args) > 0 {
+		return fmt.Errorf("lookup does not read files, got %v", args)
+	}
+	if cctx.CQZone == "" && cctx.ITUZone == "" && cctx.DXCCForCallsign == "" && cctx.ISOFromDXCC == "" {
+		return errors.New("lookup requires at least one of --cq-zone, --itu-zone, --dxcc-for-callsign, or --iso-from-dxcc")
+	}
+	out := adif.NewLogfile()
+	add := func(fs ...adif.Field) {
+		r := adif.NewRecord(fs...)
+		names := make([]string, len(fs))
+		for i, f := range fs {
+			names[i] = f.Name
+		}
+		updateFieldOrder(out, names)
+		out.AddRecord(r)
+	}
+	entityFields := func(c spec.CountryEnum) []adif.Field {
+		return []adif.Field{
+			{Name: spec.DxccField.Name, Value: c.EntityCode},
+			{Name: spec.CountryField.Name, Value: c.EntityName},
+		}
+	}
+	zones := []struct {
+		query string
+		field spec.Field
+		zones func(string) []int
+	}{
+		{query: cctx.CQZone, field: spec.CqzField, zones: spec.CQZoneFor},
+		{query: cctx.ITUZone, field: spec.ItuzField, zones: spec.ITUZoneFor},
+	}
+	for _, z := range zones {
+		if z.query == "" {
+			continue
+		}
+		c, err := lookupCountry(z.query)
+		if err != nil {
+			return err
+		}
+		zs := z.zones(c.EntityCode)
+		if len(zs) == 0 {
+			return fmt.Errorf("no %s data for %s %s", z.field.Name, c.EntityCode, c.EntityName)
+		}
+		for _, n := range zs {
+			add(append(entityFields(c), adif.Field{Name: z.field.Name, Value: strconv.Itoa(n)})...)
+		}
+	}
+	if call := cctx.DXCCForCallsign; call != "" {
+		cs := spec.DXCCFromCallsign(call)
+		if len(cs) == 0 {
+			return fmt.Errorf("no DXCC entity known for callsign %q", call)
+		}
+		for _, c := range cs {
+			add(append([]adif.Field{{Name: spec.CallField.Name, Value: strings.ToUpper(call)}}, entityFields(c)...)...)
+		}
+	}
+	if cctx.ISOFromDXCC != "" {
+		c, err := lookupCountry(cctx.ISOFromDXCC)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, iso := range spec.ISO3166Countries {
+			if iso.IncludesDXCC(c.EntityCode) {
+				found = true
+				add(append(entityFields(c),
+					adif.Field{Name: isoAlpha2Field, Value: iso.Alpha2},
+					adif.Field{Name: isoAlpha3Field, Value: iso.Alpha3},
+					adif.Field{Name: isoNumericField, Value: iso.Numeric},
+					adif.Field{Name: isoNameField, Value: iso.EnglishName})...)
+			}
+		}
+		if !found {
+			return fmt.Errorf("no ISO 3166 country code for %s %s", c.EntityCode, c.EntityName)
+		}
+	}
+	return write(ctx, out)
+}
+
+// lookupCountry finds a DXCC entity by code (291 or DXCC:291) or name.
+func lookupCountry(s string) (spec.CountryEnum, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > 5 && strings.EqualFold(s[:5], "DXCC:") {
+		s = strings.TrimSpace(s[5:])
+	}
+	for _, v := range spec.CountryEnumeration.Values {
+		c := v.(spec.CountryEnum)
+		if c == spec.CountryNone {
+			continue
+		}
+		if c.EntityCode == strings.TrimLeft(s, "0") || strings.EqualFold(c.EntityName, s) {
+			return c, nil
+		}
+	}
+	return spec.CountryEnum{}, fmt.Errorf("unknown DXCC entity %q", s)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		cctx LookupContext
+		want string
+	}{
+		{
+			name: "CQ zone by name",
+			cctx: LookupContext{CQZone: "Trinidad & Tobago"},
+			want: "DXCC,COUNTRY,CQZ\n90,TRINIDAD & TOBAGO,9\n",
+		},
+		{
+			name: "CQ zone multiple",
+			cctx: LookupContext{CQZone: "dxcc:1"},
+			want: "DXCC,COUNTRY,CQZ\n1,CANADA,1\n1,CANADA,2\n1,CANADA,3\n1,CANADA,4\n1,CANADA,5\n",
+		},
+		{
+			name: "ITU zone by code",
+			cctx: LookupContext{ITUZone: "DXCC:339"},
+			want: "DXCC,COUNTRY,ITUZ\n339,JAPAN,45\n",
+		},
+		{
+			name: "DXCC for callsign",
+			cctx: LookupContext{DXCCForCallsign: "ve3xyz"},
+			want: "CALL,DXCC,COUNTRY\nVE3XYZ,1,CANADA\n",
+		},
+		{
+			name: "ISO from DXCC",
+			cctx: LookupContext{ISOFromDXCC: "291"},
+			want: "DXCC,COUNTRY,ISO_3166_ALPHA_2,ISO_3166_ALPHA_3,ISO_3166_NUMERIC,ISO_3166_NAME\n291,UNITED STATES OF AMERICA,US,USA,840,United States of America (the)\n",
+		},
+		{
+			name: "combined",
+			cctx: LookupContext{CQZone: "90", DXCCForCallsign: "9Y4AA"},
+			want: "DXCC,COUNTRY,CQZ,CALL\n90,TRINIDAD & TOBAGO,9,\n90,TRINIDAD & TOBAGO,,9Y4AA\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			csv := adif.NewCSVIO()
+			out := &bytes.Buffer{}
+			ctx := &Context{
+				OutputFormat: adif.FormatCSV,
+				Readers:      readers(csv),
+				Writers:      writers(csv),
+				Out:          out,
+				CommandCtx:   &tc.cctx,
+				fs:           fakeFilesystem{map[string]string{}}}
+			if err := Lookup.Run(ctx, []string{}); err != nil {
+				t.Fatalf("Lookup.Run(ctx) with %+v got error %v", tc.cctx, err)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("Lookup.Run(ctx) with %+v unexpected output, diff:\n%s", tc.cctx, diff)
+			}
+		})
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	tests := []LookupContext{
+		{},
+		{CQZone: "Atlantis"},
+		{ITUZone: "DXCC:9999"},
+		{DXCCForCallsign: "MM"},
+		{ISOFromDXCC: "ITU HQ"},
+	}
+	for _, cctx := range tests {
+		csv := adif.NewCSVIO()
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          out,
+			CommandCtx:   &cctx,
+			fs:           fakeFilesystem{map[string]string{}}}
+		if err := Lookup.Run(ctx, []string{}); err == nil {
+			t.Errorf("Lookup.Run(ctx) with %+v want error, got output:\n%s", cctx, out)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+var Normalize = Command{Name: "normalize", Run: runNormalize, Help: helpNormalize,
+	Description: "Standardize the case and format of valid field values"}
+
+func helpNormalize() string {
+	return `Normalized values:
+  Enumeration fields: canonical case, e.g. 2M to 2m, cw to CW, na to NA
+  Country fields: official DXCC entity name, e.g. Canada to CANADA
+  Boolean fields: Y or N
+  Date fields: YYYYMMDD, e.g. 2006-01-02 to 20060102
+Values which don't match a known format are left unchanged; see also fix.
+`
+}
+
+func runNormalize(ctx *Context, args []string) error {
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		for _, r := range l.Records {
+			for _, f := range r.Fields() {
+				if v := normalizeField(f, r, l); v != f.Value {
+					f.Value = v
+					r.Set(f)
+				}
+			}
+			acc.Out.AddRecord(r)
+		}
+	}
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
+
+// normalizeField returns the canonical form of f's value, or the value itself
+// if it is not valid in canonical form.
+func normalizeField(f adif.Field, r *adif.Record, l *adif.Logfile) string {
+	if f.Value == "" {
+		return f.Value
+	}
+	fs, ok := spec.FieldNamed(f.Name)
+	if !ok {
+		fs = spec.Field{Name: f.Name, Type: fieldType(f, l)}
+	}
+	e := fs.Enum()
+	if fs.Name == spec.CountryField.Name {
+		e = spec.CountryEnumeration
+	}
+	var v string
+	switch {
+	case e.Name != "":
+		if vals := e.Value(strings.TrimSpace(f.Value)); len(vals) > 0 {
+			v = vals[0].String()
+		}
+	case fs.Type == spec.BooleanDataType:
+		v = strings.ToUpper(strings.TrimSpace(f.Value))
+	case fs.Type == spec.DateDataType:
+		v = fixDate(f.Value)
+	}
+	if v == "" || v == f.Value {
+		return f.Value
+	}
+	if validate := spec.TypeValidators[fs.Type.Name]; validate != nil {
+		vctx := spec.ValidationContext{FieldValue: func(name string) string {
+			f, _ := r.Get(name)
+			return f.Value
+		}}
+		if res := validate(v, fs, vctx); res.Validity == spec.InvalidError {
+			return f.Value
+		}
+	}
+	return v
+}
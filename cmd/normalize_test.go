@@ -0,0 +1,48 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalize(t *testing.T) {
+	csv := adif.NewCSVIO()
+	in := `CALL,BAND,MODE,SUBMODE,CONT,COUNTRY,MY_COUNTRY,STATE,QSL_RCVD,SWL,QSO_DATE,APP_X_FLAG
+k1a,2M,cw,,na,united states of america,Canada,co,y,n,2024-01-02,y
+K2B,70CM,Ssb,usb,Eu,Nowhere,,xx,Q,maybe,01/02/2024,n
+`
+	want := `CALL,BAND,MODE,SUBMODE,CONT,COUNTRY,MY_COUNTRY,STATE,QSL_RCVD,SWL,QSO_DATE,APP_X_FLAG
+k1a,2m,CW,,NA,UNITED STATES OF AMERICA,CANADA,CO,Y,N,20240102,y
+K2B,70cm,SSB,USB,EU,Nowhere,,xx,Q,maybe,01/02/2024,n
+`
+	out := &bytes.Buffer{}
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          out,
+		Prepare:      testPrepare("My Comment", "3.1.4", "normalize test", "1.2.3"),
+		fs:           fakeFilesystem{map[string]string{"foo.csv": in}}}
+	if err := Normalize.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Errorf("Normalize.Run(ctx) got error %v", err)
+	} else if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Normalize.Run(ctx) unexpected output, diff:\n%s", diff)
+	}
+}
@@ -34,3 +34,11 @@ func errorsJoin(errs ...error) error {
 	}
 	return fmt.Errorf(strings.Join(f, "\n"), e...)
 }
+
+// maxInt is a polyfill for Go's max builtin (added in 1.21).
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
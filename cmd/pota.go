@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+const potaParkAPIURL = "https://api.pota.app/park/"
+
+// potaParkChecker looks up Parks on the Air references with the POTA API.
+// Results are cached so each park is requested at most once, and if the API
+// is unavailable no further requests are made.
+type potaParkChecker struct {
+	baseURL     string
+	client      *http.Client
+	cache       map[string]spec.Validation
+	unavailable error
+}
+
+func newPOTAParkChecker(baseURL string) *potaParkChecker {
+	if baseURL == "" {
+		baseURL = potaParkAPIURL
+	}
+	return &potaParkChecker{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		cache:   make(map[string]spec.Validation),
+	}
+}
+
+// Validate is a spec.FieldValidator for POTARefList fields.  References which
+// don't have a valid format are skipped, since the spec validator reports them.
+func (c *potaParkChecker) Validate(val string, f spec.Field, ctx spec.ValidationContext) spec.Validation {
+	refv := spec.TypeValidators[spec.POTARefDataType.Name]
+	for _, ref := range strings.Split(val, ",") {
+		if refv(ref, f, ctx).Validity != spec.Valid {
+			continue
+		}
+		park, _, _ := strings.Cut(strings.ToUpper(ref), "@") // strip location
+		res, ok := c.cache[park]
+		if !ok {
+			res = c.lookup(park)
+			c.cache[park] = res
+		}
+		if res.Validity != spec.Valid {
+			res.Message = fmt.Sprintf("%s %s", f.Name, res.Message)
+			return res
+		}
+	}
+	return spec.Validation{Validity: spec.Valid}
+}
+
+func (c *potaParkChecker) lookup(park string) spec.Validation {
+	fail := func(err error) spec.Validation {
+		c.unavailable = err
+		return spec.Validation{Validity: spec.InvalidWarning,
+			Message: fmt.Sprintf("could not check park %s: %v", park, err)}
+	}
+	if c.unavailable != nil {
+		return fail(c.unavailable)
+	}
+	unknown := spec.Validation{Validity: spec.InvalidError,
+		Message: fmt.Sprintf("unknown park %s in POTA database", park)}
+	resp, err := c.client.Get(c.baseURL + url.PathEscape(park))
+	if err != nil {
+		return fail(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return unknown
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fail(fmt.Errorf("POTA API status %s", resp.Status))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fail(err)
+	}
+	// the API responds with null for parks which don't exist
+	var p struct{ Reference string }
+	if err := json.Unmarshal(body, &p); err != nil {
+		return fail(fmt.Errorf("invalid POTA API response: %w", err))
+	}
+	if !strings.EqualFold(p.Reference, park) {
+		return unknown
+	}
+	return spec.Validation{Validity: spec.Valid}
+}
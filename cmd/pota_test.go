@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+func TestPOTAParkChecker(t *testing.T) {
+	requests := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		park := strings.TrimPrefix(r.URL.Path, "/park/")
+		requests[park]++
+		switch park {
+		case "K-0001", "VE-5082":
+			w.Write([]byte(`{"reference":"` + park + `","name":"Somewhere"}`))
+		case "K-4562":
+			w.WriteHeader(http.StatusNotFound)
+		case "K-5000":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte("null"))
+		}
+	}))
+	defer srv.Close()
+	tests := []struct {
+		value string
+		want  spec.Validity
+	}{
+		{value: "K-0001", want: spec.Valid},
+		{value: "k-0001", want: spec.Valid},
+		{value: "VE-5082@CA-AB", want: spec.Valid},
+		{value: "K-0001,VE-5082", want: spec.Valid},
+		{value: "not a park", want: spec.Valid}, // format errors come from spec
+		{value: "K-9999", want: spec.InvalidError},
+		{value: "K-0001,K-9999", want: spec.InvalidError},
+		{value: "K-4562@US-CA", want: spec.InvalidError},
+	}
+	c := newPOTAParkChecker(srv.URL + "/park/")
+	for _, tc := range tests {
+		got := c.Validate(tc.value, spec.PotaRefField, spec.ValidationContext{})
+		if got.Validity != tc.want {
+			t.Errorf("Validate(%q) got %v %s, want %v", tc.value, got.Validity, got.Message, tc.want)
+		}
+	}
+	for park, n := range requests {
+		if n != 1 {
+			t.Errorf("got %d requests for %s, want 1", n, park)
+		}
+	}
+
+	if got := c.Validate("K-5000", spec.PotaRefField, spec.ValidationContext{}); got.Validity != spec.InvalidWarning {
+		t.Errorf("Validate(K-5000) with server error got %v %s, want %v", got.Validity, got.Message, spec.InvalidWarning)
+	}
+	if got := c.Validate("K-0002", spec.PotaRefField, spec.ValidationContext{}); got.Validity != spec.InvalidWarning {
+		t.Errorf("Validate(K-0002) after server error got %v %s, want %v", got.Validity, got.Message, spec.InvalidWarning)
+	}
+	if n := requests["K-0002"]; n != 0 {
+		t.Errorf("got %d requests for K-0002 after server error, want 0", n)
+	}
+}
+
+func TestValidatePOTAAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/park/K-0001" {
+			w.Write([]byte(`{"reference":"K-0001"}`))
+		} else {
+			w.Write([]byte("null"))
+		}
+	}))
+	defer srv.Close()
+	for _, tc := range []struct {
+		file    string
+		wantErr bool
+	}{
+		{file: "<CALL:4>W1AW <POTA_REF:6>K-0001 <EOR>\n"},
+		{file: "<CALL:4>W1AW <MY_POTA_REF:6>K-0001 <POTA_REF:6>K-9999 <EOR>\n", wantErr: true},
+	} {
+		adi := adif.NewADIIO()
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatADI,
+			Readers:      readers(adi),
+			Writers:      writers(adi),
+			Out:          out,
+			CommandCtx:   &ValidateContext{POTAAPI: true, potaAPIURL: srv.URL + "/park/"},
+			fs:           fakeFilesystem{map[string]string{"foo.adi": tc.file}}}
+		err := Validate.Run(ctx, []string{"foo.adi"})
+		if tc.wantErr && err == nil {
+			t.Errorf("Validate.Run(%q) with --pota-api want error, got output:\n%s", tc.file, out)
+		} else if !tc.wantErr && err != nil {
+			t.Errorf("Validate.Run(%q) with --pota-api got error %v", tc.file, err)
+		}
+	}
+}
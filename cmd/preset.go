@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+	"golang.org/x/exp/maps"
+)
+
+// Preset adjusts input and output for the ADIF conventions of a service like
+// Logbook of the World.  The zero value makes no changes.
+type Preset struct {
+	Name        string
+	Description string
+	// Fields, if not empty, are the only record fields which are written, in
+	// this order.
+	Fields []string
+	// Read, if not nil, is called on each record after it is read and returns
+	// a replacement record.
+	Read func(r *adif.Record) *adif.Record
+}
+
+func (p *Preset) String() string { return p.Name }
+
+func (p *Preset) Set(s string) error {
+	v, ok := Presets[strings.ToLower(s)]
+	if !ok {
+		return fmt.Errorf("unknown preset %q, options: %s", s, strings.Join(PresetNames(), ", "))
+	}
+	*p = v
+	return nil
+}
+
+// PresetNames returns the sorted names of all Presets.
+func PresetNames() []string {
+	names := maps.Keys(Presets)
+	sort.Strings(names)
+	return names
+}
+
+// apply removes fields not in p.Fields from each record.
+func (p *Preset) apply(l *adif.Logfile) {
+	if len(p.Fields) == 0 {
+		return
+	}
+	for i, r := range l.Records {
+		fields := make([]adif.Field, 0, len(p.Fields))
+		for _, n := range p.Fields {
+			if f, ok := r.Get(n); ok && f.Value != "" {
+				fields = append(fields, f)
+			}
+		}
+		rec := adif.NewRecord(fields...)
+		rec.SetComment(r.GetComment())
+		l.Records[i] = rec
+	}
+	l.FieldOrder = p.Fields
+}
+
+var Presets = map[string]Preset{
+	"lotw-upload": {
+		Name:        "lotw-upload",
+		Description: "only write QSO fields accepted by TQSL for Logbook of the World",
+		Fields: []string{
+			spec.CallField.Name,
+			spec.QsoDateField.Name,
+			spec.TimeOnField.Name,
+			spec.BandField.Name,
+			spec.BandRxField.Name,
+			spec.FreqField.Name,
+			spec.FreqRxField.Name,
+			spec.ModeField.Name,
+			spec.SubmodeField.Name,
+			spec.PropModeField.Name,
+			spec.SatNameField.Name,
+			spec.StationCallsignField.Name,
+			spec.MyGridsquareField.Name,
+			spec.MyVuccGridsField.Name,
+			spec.MyStateField.Name,
+			spec.MyCntyField.Name,
+			spec.MyCqZoneField.Name,
+			spec.MyItuZoneField.Name,
+			spec.MyDxccField.Name,
+		},
+	},
+	"fldigi": {
+		Name:        "fldigi",
+		Description: "read FLdigi logs, converting BAND frequency ranges and removing conflicting APP_FLDIGI fields",
+		Read:        readFldigi,
+	},
+	"lotw-download": {
+		Name:        "lotw-download",
+		Description: "read Logbook of the World QSL reports, where QSL_RCVD means confirmed on LoTW",
+		Read:        readLotwReport,
+	},
+	"eqsl-upload": {
+		Name:        "eqsl-upload",
+		Description: "only write QSO fields accepted by eQSL.cc uploads",
+		Fields: []string{
+			spec.CallField.Name,
+			spec.QsoDateField.Name,
+			spec.TimeOnField.Name,
+			spec.BandField.Name,
+			spec.FreqField.Name,
+			spec.ModeField.Name,
+			spec.SubmodeField.Name,
+			spec.RstSentField.Name,
+			spec.PropModeField.Name,
+			spec.SatNameField.Name,
+			spec.SatModeField.Name,
+			spec.QslmsgField.Name,
+			"APP_EQSL_QTH_NICKNAME",
+		},
+	},
+	"eqsl-download": {
+		Name:        "eqsl-download",
+		Description: "read eQSL.cc downloads, converting APP_EQSL_ status fields to EQSL_ fields",
+		Read:        readEqslReport,
+	},
+}
+
+// readLotwReport renames confirmation fields in an LoTW report to LOTW_ fields,
+// since LoTW uses QSL_RCVD and QSLRDATE for its own confirmation status.
+func readLotwReport(r *adif.Record) *adif.Record {
+	return renameFields(r, map[string]string{
+		"APP_LOTW_QSL_RCVD":     spec.LotwQslRcvdField.Name,
+		spec.QslRcvdField.Name:  spec.LotwQslRcvdField.Name,
+		spec.QslrdateField.Name: spec.LotwQslrdateField.Name,
+	})
+}
+
+// readEqslReport renames eQSL.cc app-defined confirmation fields to the
+// standard EQSL_ fields.
+func readEqslReport(r *adif.Record) *adif.Record {
+	return renameFields(r, map[string]string{
+		"APP_EQSL_RCVD_STATUS": spec.EqslQslRcvdField.Name,
+		"APP_EQSL_QSL_RCVD":    spec.EqslQslRcvdField.Name,
+		"APP_EQSL_QSL_SENT":    spec.EqslQslSentField.Name,
+		"APP_EQSL_QSLRDATE":    spec.EqslQslrdateField.Name,
+		"APP_EQSL_QSLSDATE":    spec.EqslQslsdateField.Name,
+	})
+}
+
+// renameFields returns a copy of r with fields renamed according to rename,
+// which has upper-case keys.  A renamed field does not replace a non-empty
+// field which is already set.
+func renameFields(r *adif.Record, rename map[string]string) *adif.Record {
+	res := adif.NewRecord()
+	res.SetComment(r.GetComment())
+	for _, f := range r.Fields() {
+		if to, ok := rename[strings.ToUpper(f.Name)]; ok {
+			if t, ok := r.Get(to); ok && t.Value != "" {
+				continue // already set, e.g. by an earlier conversion
+			}
+			f.Name = to
+		}
+		if e, ok := res.Get(f.Name); ok && e.Value != "" {
+			continue
+		}
+		res.Set(f)
+	}
+	return res
+}
+
+// fldigiBandPat matches FLdigi BAND values like 14000000-14350000, the range
+// of the band in hertz.
+var fldigiBandPat = regexp.MustCompile(`^\s*(\d+)\s*-\s*(\d+)\s*$`)
+
+// readFldigi converts FLdigi ADIF conventions to standard fields.  BAND
+// frequency ranges become band names like 20m and APP_FLDIGI_ fields are
+// removed if they duplicate a standard field which is present in the record.
+func readFldigi(r *adif.Record) *adif.Record {
+	res := adif.NewRecord()
+	res.SetComment(r.GetComment())
+	for _, f := range r.Fields() {
+		name := strings.ToUpper(f.Name)
+		if name == spec.BandField.Name || name == spec.BandRxField.Name {
+			if m := fldigiBandPat.FindStringSubmatch(f.Value); m != nil {
+				if hz, err := strconv.ParseFloat(m[1], 64); err == nil {
+					if b, ok := bandForFreq(hz / 1e6); ok {
+						f.Value = b.Band
+					}
+				}
+			}
+		}
+		if strings.HasPrefix(name, "APP_FLDIGI_") {
+			std := strings.TrimPrefix(name, "APP_FLDIGI_")
+			if _, ok := spec.FieldNamed(std); ok {
+				if e, ok := r.Get(std); ok && e.Value != "" {
+					continue
+				}
+			}
+		}
+		res.Set(f)
+	}
+	return res
+}
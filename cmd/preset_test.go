@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPresetLotwUpload(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	file1 := `MODE,CALL,QSO_DATE,TIME_ON,BAND,NAME,APP_LOTW_OWNCALL,STATION_CALLSIGN,MY_GRIDSQUARE,COMMENT
+FT8,K1A,20240101,1234,20m,Al,W1AW,W1AW,FN31,hi
+SSB,K2B,20240102,0123,40m,Bea,W1AW,W1AW,,
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatADI,
+		Readers:      readers(adi, csv),
+		Writers:      writers(adi, csv),
+		Out:          out,
+		CommandCtx:   &CatContext{},
+		fs:           fakeFilesystem{map[string]string{"foo.csv": file1}}}
+	if err := ctx.Preset.Set("LOTW-UPLOAD"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cat.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Fatalf("Cat.Run(ctx, foo.csv) got error %v", err)
+	}
+	want := `<CALL:3>K1A <QSO_DATE:8>20240101 <TIME_ON:4>1234 <BAND:3>20m <MODE:3>FT8 <STATION_CALLSIGN:4>W1AW <MY_GRIDSQUARE:4>FN31 <EOR>
+<CALL:3>K2B <QSO_DATE:8>20240102 <TIME_ON:4>0123 <BAND:3>40m <MODE:3>SSB <STATION_CALLSIGN:4>W1AW <EOR>
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Cat.Run(ctx, foo.csv) with lotw-upload preset unexpected output, diff:\n%s", diff)
+	}
+}
+
+func TestPresetLotwDownload(t *testing.T) {
+	adi := adif.NewADIIO()
+	tsv := adif.NewTSVIO()
+	out := &bytes.Buffer{}
+	file1 := `<CALL:3>K1A <BAND:3>20m <QSL_RCVD:1>Y <QSLRDATE:8>20240105 <EOR>
+<CALL:3>K2B <BAND:3>40m <APP_LOTW_QSL_RCVD:1>N <EOR>
+<CALL:3>K3C <BAND:3>15m <LOTW_QSL_RCVD:1>Y <QSL_RCVD:1>N <EOR>
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatTSV,
+		Readers:      readers(adi, tsv),
+		Writers:      writers(adi, tsv),
+		Out:          out,
+		CommandCtx:   &CatContext{},
+		fs:           fakeFilesystem{map[string]string{"lotwreport.adi": file1}}}
+	if err := ctx.Preset.Set("lotw-download"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cat.Run(ctx, []string{"lotwreport.adi"}); err != nil {
+		t.Fatalf("Cat.Run(ctx, lotwreport.adi) got error %v", err)
+	}
+	want := `CALL	BAND	LOTW_QSL_RCVD	LOTW_QSLRDATE
+K1A	20m	Y	20240105
+K2B	40m	N	
+K3C	15m	Y	
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Cat.Run(ctx, lotwreport.adi) with lotw-download preset unexpected output, diff:\n%s", diff)
+	}
+}
+
+func TestPresetEqslUpload(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	file1 := `MODE,CALL,QSO_DATE,TIME_ON,BAND,NAME,RST_SENT,RST_RCVD,QSLMSG,APP_EQSL_QTH_NICKNAME
+FT8,K1A,20240101,1234,20m,Al,-10,-12,TNX FB QSO,Home
+SSB,K2B,20240102,0123,40m,Bea,59,57,,
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatADI,
+		Readers:      readers(adi, csv),
+		Writers:      writers(adi, csv),
+		Out:          out,
+		CommandCtx:   &CatContext{},
+		fs:           fakeFilesystem{map[string]string{"foo.csv": file1}}}
+	if err := ctx.Preset.Set("eqsl-upload"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cat.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Fatalf("Cat.Run(ctx, foo.csv) got error %v", err)
+	}
+	want := `<CALL:3>K1A <QSO_DATE:8>20240101 <TIME_ON:4>1234 <BAND:3>20m <MODE:3>FT8 <RST_SENT:3>-10 <QSLMSG:10>TNX FB QSO <APP_EQSL_QTH_NICKNAME:4>Home <EOR>
+<CALL:3>K2B <QSO_DATE:8>20240102 <TIME_ON:4>0123 <BAND:3>40m <MODE:3>SSB <RST_SENT:2>59 <EOR>
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Cat.Run(ctx, foo.csv) with eqsl-upload preset unexpected output, diff:\n%s", diff)
+	}
+}
+
+func TestPresetEqslDownload(t *testing.T) {
+	adi := adif.NewADIIO()
+	tsv := adif.NewTSVIO()
+	out := &bytes.Buffer{}
+	file1 := `eQSL.cc DownloadInBox
+<ADIF_VER:4>1.00 <PROGRAMID:4>eQSL <EOH>
+<CALL:3>K1A <BAND:3>20m <APP_EQSL_RCVD_STATUS:1>Y <APP_EQSL_QSLRDATE:8>20240105 <EOR>
+<CALL:3>K2B <BAND:3>40m <APP_EQSL_QSL_SENT:1>Y <EOR>
+<CALL:3>K3C <BAND:3>15m <EQSL_QSL_RCVD:1>Y <APP_EQSL_RCVD_STATUS:1>N <EOR>
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatTSV,
+		Readers:      readers(adi, tsv),
+		Writers:      writers(adi, tsv),
+		Out:          out,
+		CommandCtx:   &CatContext{},
+		fs:           fakeFilesystem{map[string]string{"inbox.adi": file1}}}
+	if err := ctx.Preset.Set("eqsl-download"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cat.Run(ctx, []string{"inbox.adi"}); err != nil {
+		t.Fatalf("Cat.Run(ctx, inbox.adi) got error %v", err)
+	}
+	want := `CALL	BAND	EQSL_QSL_RCVD	EQSL_QSLRDATE	EQSL_QSL_SENT
+K1A	20m	Y	20240105	
+K2B	40m			Y
+K3C	15m	Y		
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Cat.Run(ctx, inbox.adi) with eqsl-download preset unexpected output, diff:\n%s", diff)
+	}
+}
+
+func TestPresetFldigi(t *testing.T) {
+	adi := adif.NewADIIO()
+	tsv := adif.NewTSVIO()
+	out := &bytes.Buffer{}
+	file1 := `<CALL:3>K1A <FREQ:6>14.070 <BAND:17>14000000-14350000 <APP_FLDIGI_FREQ:8>14070000 <EOR>
+<CALL:3>K2B <BAND:3>40m <APP_FLDIGI_FREQ:7>7070000 <APP_FLDIGI_XCHG1:3>599 <EOR>
+<CALL:3>K3C <BAND:15>3500000-4000000 <EOR>
+`
+	ctx := &Context{
+		OutputFormat: adif.FormatTSV,
+		Readers:      readers(adi, tsv),
+		Writers:      writers(adi, tsv),
+		Out:          out,
+		CommandCtx:   &CatContext{},
+		fs:           fakeFilesystem{map[string]string{"fldigi.adi": file1}}}
+	if err := ctx.Preset.Set("fldigi"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cat.Run(ctx, []string{"fldigi.adi"}); err != nil {
+		t.Fatalf("Cat.Run(ctx, fldigi.adi) got error %v", err)
+	}
+	want := `CALL	FREQ	BAND	APP_FLDIGI_FREQ	APP_FLDIGI_XCHG1
+K1A	14.070	20m		
+K2B		40m	7070000	599
+K3C		80m		
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Cat.Run(ctx, fldigi.adi) with fldigi preset unexpected output, diff:\n%s", diff)
+	}
+}
+
+func TestPresetUnknown(t *testing.T) {
+	var p Preset
+	if err := p.Set("qrz"); err == nil {
+		t.Errorf("Preset.Set(qrz) got %v, want error", p)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/flwyd/adif-multitool/adif"
+)
+
+var Sample = Command{Name: "sample", Run: runSample, Help: helpSample,
+	Description: "Print a random selection of records from the input"}
+
+type SampleContext struct {
+	// Count is the number of records to select, if positive.
+	Count int
+	// Fraction is the probability of selecting each record, if Count is zero.
+	Fraction float64
+	// Seed initializes the random number generator; if zero, the current time
+	// is used.
+	Seed int64
+}
+
+func helpSample() string {
+	return `Exactly one of --count and --fraction must be set.  --count selects that many
+records (or all records, if there are fewer), while --fraction selects each
+record with the given probability, so --fraction 0.1 selects about 10% of the
+records.  Records from all input files are sampled together, as if they were
+combined with cat.  Selected records are printed in their original order.
+
+Set --seed to select the same records each time the command is run with the
+same input.
+`
+}
+
+func runSample(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*SampleContext)
+	if cctx.Count < 0 {
+		return fmt.Errorf("sample --count must not be negative, got %d", cctx.Count)
+	}
+	if cctx.Fraction < 0 || cctx.Fraction > 1 {
+		return fmt.Errorf("sample --fraction must be between 0 and 1, got %g", cctx.Fraction)
+	}
+	if (cctx.Count == 0) == (cctx.Fraction == 0) {
+		return errors.New("sample requires exactly one of --count or --fraction")
+	}
+	seed := cctx.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	recs := make([]*adif.Record, 0)
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		recs = append(recs, l.Records...)
+	}
+	if cctx.Count > 0 {
+		if cctx.Count < len(recs) {
+			idx := rnd.Perm(len(recs))[:cctx.Count]
+			sort.Ints(idx)
+			for _, i := range idx {
+				acc.Out.AddRecord(recs[i])
+			}
+		} else {
+			acc.Out.Records = append(acc.Out.Records, recs...)
+		}
+	} else {
+		for _, r := range recs {
+			if rnd.Float64() < cctx.Fraction {
+				acc.Out.AddRecord(r)
+			}
+		}
+	}
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSample(t *testing.T) {
+	csv := adif.NewCSVIO()
+	var file1, file2 strings.Builder
+	file1.WriteString("CALL,STX\n")
+	file2.WriteString("CALL,STX\n")
+	for i := 1; i <= 20; i++ {
+		f := &file1
+		if i > 10 {
+			f = &file2
+		}
+		fmt.Fprintf(f, "K%dA,%d\n", i, i)
+	}
+	run := func(cctx SampleContext) ([]string, error) {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          out,
+			CommandCtx:   &cctx,
+			fs:           fakeFilesystem{map[string]string{"foo.csv": file1.String(), "bar.csv": file2.String()}}}
+		if err := Sample.Run(ctx, []string{"foo.csv", "bar.csv"}); err != nil {
+			return nil, err
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if lines[0] != "CALL,STX" {
+			t.Errorf("Sample.Run(ctx) with %+v got header %q", cctx, lines[0])
+		}
+		return lines[1:], nil
+	}
+	tests := []struct {
+		cctx    SampleContext
+		wantLen int
+	}{
+		{cctx: SampleContext{Count: 5, Seed: 42}, wantLen: 5},
+		{cctx: SampleContext{Count: 19, Seed: 1}, wantLen: 19},
+		{cctx: SampleContext{Count: 20, Seed: 1}, wantLen: 20},
+		{cctx: SampleContext{Count: 100, Seed: 1}, wantLen: 20},
+		{cctx: SampleContext{Fraction: 1, Seed: 7}, wantLen: 20},
+		{cctx: SampleContext{Fraction: 0.5, Seed: 7}, wantLen: -1},
+	}
+	for _, tc := range tests {
+		got, err := run(tc.cctx)
+		if err != nil {
+			t.Errorf("Sample.Run(ctx) with %+v got error %v", tc.cctx, err)
+			continue
+		}
+		if tc.wantLen >= 0 && len(got) != tc.wantLen {
+			t.Errorf("Sample.Run(ctx) with %+v got %d records, want %d: %v", tc.cctx, len(got), tc.wantLen, got)
+		}
+		prev := 0
+		for _, l := range got {
+			var n int
+			if _, err := fmt.Sscanf(l[strings.Index(l, ",")+1:], "%d", &n); err != nil {
+				t.Fatalf("Sample.Run(ctx) with %+v got unexpected record %q", tc.cctx, l)
+			}
+			if n <= prev {
+				t.Errorf("Sample.Run(ctx) with %+v records not in original order: %v", tc.cctx, got)
+				break
+			}
+			prev = n
+		}
+		again, err := run(tc.cctx)
+		if err != nil {
+			t.Errorf("Sample.Run(ctx) with %+v second run got error %v", tc.cctx, err)
+		} else if diff := cmp.Diff(got, again); diff != "" {
+			t.Errorf("Sample.Run(ctx) with %+v not reproducible with the same seed, diff:\n%s", tc.cctx, diff)
+		}
+	}
+	for _, cctx := range []SampleContext{{}, {Count: -1}, {Fraction: 1.5}, {Fraction: -0.5}, {Count: 3, Fraction: 0.5}} {
+		if got, err := run(cctx); err == nil {
+			t.Errorf("Sample.Run(ctx) with %+v want error, got %v", cctx, got)
+		}
+	}
+}
@@ -108,9 +108,11 @@ func runSave(ctx *Context, args []string) error {
 			return err
 		}
 		defer out.Close()
-		ctx.Out = out
-		ctx.OutputFormat = format
-		err = write(ctx, l)
+		sctx := *ctx
+		sctx.Out = out
+		sctx.OutputFormat = format
+		sctx.Compress = compressionFor(file, ctx.Compress)
+		err = write(&sctx, l)
 		if err == nil && !cctx.Quiet {
 			fmt.Fprintf(os.Stderr, "Wrote %d records to %s\n", len(l.Records), file)
 		}
@@ -15,8 +15,11 @@
 package cmd
 
 import (
+	"compress/gzip"
 	"encoding/xml"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/flwyd/adif-multitool/adif"
@@ -78,6 +81,14 @@ Comment about Santa on the Air <CALL:3>N0P <QSO_DATE:8>20221224 <BAND:2>2m <EOR>
 			want: `CALL,QSO_DATE,BAND
 W1AW,19870605,40m
 N0P,20221224,2m
+`,
+		},
+		{
+			name:     "infer gzip CSV",
+			filename: "out.csv.gz",
+			want: `CALL,QSO_DATE,BAND
+W1AW,19870605,40m
+N0P,20221224,2m
 `,
 		},
 		{
@@ -105,6 +116,8 @@ N0P,20221224,2m
 				}
 			} else if got, ok := fs.files[tc.filename]; !ok {
 				t.Errorf("runSave didn't write to file %s", tc.filename)
+			} else if got, err = gunzipIfNamed(tc.filename, got); err != nil {
+				t.Errorf("runSave(%q) wrote invalid gzip: %v", tc.filename, err)
 			} else if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("runSave(%q) got diff\n%s", tc.filename, diff)
 			}
@@ -112,6 +125,19 @@ N0P,20221224,2m
 	}
 }
 
+// gunzipIfNamed decompresses s if filename ends in .gz.
+func gunzipIfNamed(filename, s string) (string, error) {
+	if !strings.HasSuffix(filename, ".gz") {
+		return s, nil
+	}
+	z, err := gzip.NewReader(strings.NewReader(s))
+	if err != nil {
+		return "", err
+	}
+	b, err := io.ReadAll(z)
+	return string(b), err
+}
+
 func TestSaveFileTemplate(t *testing.T) {
 	adiio := adif.NewADIIO()
 	adiio.FieldSep = adif.SeparatorSpace
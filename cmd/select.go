@@ -22,7 +22,7 @@ import (
 	"github.com/flwyd/adif-multitool/adif"
 )
 
-var Select = Command{Name: "select", Run: runSelect, Help: helpSelect,
+var Select = Command{Name: "select", Run: runSelect, Help: helpSelect, Batches: true,
 	Description: "Print only specific fields from the input"}
 
 type SelectContext struct {
@@ -43,11 +43,7 @@ func runSelect(ctx *Context, args []string) error {
 		return err
 	}
 	updateFieldOrder(acc.Out, con.Fields)
-	for _, f := range filesOrStdin(args) {
-		l, err := acc.read(f)
-		if err != nil {
-			return err
-		}
+	err = acc.processBatches(filesOrStdin(args), func(l *adif.Logfile, first int) error {
 		for _, r := range l.Records {
 			fields := make([]adif.Field, 0, len(con.Fields))
 			for _, name := range con.Fields {
@@ -59,9 +55,10 @@ func runSelect(ctx *Context, args []string) error {
 				acc.Out.AddRecord(adif.NewRecord(fields...))
 			}
 		}
-	}
-	if err := acc.prepare(); err != nil {
+		return nil
+	})
+	if err != nil {
 		return err
 	}
-	return write(ctx, acc.Out)
+	return acc.write()
 }
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/flwyd/adif-multitool/adif"
@@ -80,3 +81,39 @@ func TestSelectSingleFile(t *testing.T) {
 		}
 	}
 }
+
+func TestSelectBatchSize(t *testing.T) {
+	adi := adif.NewADIIO()
+	bar := "<APP_BAR_SCORE:2>42 <EOH>\n" + adiFile + "Comment from bar\n"
+	files := fakeFilesystem{map[string]string{"foo.adi": adiFile, "bar.adi": bar}}
+	var want string
+	for _, size := range []int{0, 1, 2, 3} {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatADI,
+			Readers:      readers(adi),
+			Writers:      writers(adi),
+			Out:          out,
+			Prepare:      testPrepare("", "3.1.4", "select test", "1.2.3"),
+			BatchSize:    size,
+			fs:           files,
+			CommandCtx:   &SelectContext{Fields: FieldList{"CALL", "BAND"}}}
+		if err := Select.Run(ctx, []string{"foo.adi", "bar.adi"}); err != nil {
+			t.Errorf("Select.Run(ctx, foo.adi, bar.adi) with batch size %d got error %v", size, err)
+			continue
+		}
+		if size == 0 {
+			want = out.String()
+			if n := strings.Count(want, "<EOR>"); n != 4 {
+				t.Fatalf("Select.Run(ctx, foo.adi, bar.adi) got %d records, want 4:\n%s", n, want)
+			}
+			for _, s := range []string{"Comment from bar", "<APP_BAR_SCORE:2>42"} {
+				if !strings.Contains(want, s) {
+					t.Fatalf("Select.Run(ctx, foo.adi, bar.adi) output missing %q:\n%s", s, want)
+				}
+			}
+		} else if diff := cmp.Diff(want, out.String()); diff != "" {
+			t.Errorf("Select.Run(ctx, foo.adi, bar.adi) with batch size %d differs from unbatched output:\n%s", size, diff)
+		}
+	}
+}
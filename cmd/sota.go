@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+// sotaSummitChecker checks Summits on the Air references against a summits
+// list CSV file, as downloaded from https://www.sotadata.org.uk/summitslist.csv
+type sotaSummitChecker struct {
+	summits map[string]bool
+}
+
+// newSOTASummitChecker reads the SummitCode column of a SOTA summits list.
+// The file starts with a title line before the CSV header.
+func newSOTASummitChecker(ctx *Context, filename string) (*sotaSummitChecker, error) {
+	summits, err := readCSVColumn(ctx, filename, "SummitCode", "SOTA summits")
+	if err != nil {
+		return nil, err
+	}
+	return &sotaSummitChecker{summits: summits}, nil
+}
+
+// Validate is a spec.FieldValidator for SOTARef fields.  References which
+// don't have a valid format are skipped, since the spec validator reports them.
+func (c *sotaSummitChecker) Validate(val string, f spec.Field, ctx spec.ValidationContext) spec.Validation {
+	refv := spec.TypeValidators[spec.SOTARefDataType.Name]
+	if refv(val, f, ctx).Validity != spec.Valid {
+		return spec.Validation{Validity: spec.Valid}
+	}
+	if !c.summits[strings.ToUpper(strings.TrimSpace(val))] {
+		return spec.Validation{Validity: spec.InvalidError,
+			Message: fmt.Sprintf("%s unknown summit %s in SOTA database", f.Name, val)}
+	}
+	return spec.Validation{Validity: spec.Valid}
+}
+
+// activatedSummits returns the distinct MY_SOTA_REF values in l, sorted.
+func activatedSummits(l *adif.Logfile) []string {
+	seen := make(map[string]bool)
+	var res []string
+	for _, r := range l.Records {
+		if f, ok := r.Get(spec.MySotaRefField.Name); ok && f.Value != "" {
+			s := strings.ToUpper(strings.TrimSpace(f.Value))
+			if !seen[s] {
+				seen[s] = true
+				res = append(res, s)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// missingActivatorSummit returns a warning message if r has no MY_SOTA_REF but
+// looks like part of a SOTA activation: either SOTA_REF is set, suggesting a
+// summit-to-summit contact, or other records in the file were activated from
+// a summit.
+func missingActivatorSummit(r *adif.Record, activated []string) (string, bool) {
+	if f, ok := r.Get(spec.MySotaRefField.Name); ok && f.Value != "" {
+		return "", false
+	}
+	if f, ok := r.Get(spec.SotaRefField.Name); ok && f.Value != "" {
+		return fmt.Sprintf("%s %s without %s, chaser contact or missing activator summit?", spec.SotaRefField.Name, f.Value, spec.MySotaRefField.Name), true
+	}
+	if len(activated) > 0 {
+		return fmt.Sprintf("missing %s, other records in this file are from %s", spec.MySotaRefField.Name, strings.Join(activated, ", ")), true
+	}
+	return "", false
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+const testSummitsList = `SOTA Summits List (Date=01/05/2025)
+SummitCode,AssociationName,RegionName,SummitName,AltM,AltFt,GridRef1,GridRef2,Longitude,Latitude,Points,BonusPoints,ValidFrom,ValidTo,ActivationCount,ActivationDate,ActivationCall
+W7W/KG-001,USA (Washington),King,"Mount Daniel",2427,7963,-121.1789,47.5656,-121.1789,47.5656,10,3,01/06/2011,31/12/2099,20,01/08/2024,K7ABC
+G/LD-001,England,Lake District,Scafell Pike,978,3209,NY2154,0721,-3.2116,54.4543,10,3,01/01/2002,31/12/2099,1200,30/04/2025,M0XYZ
+`
+
+func TestSOTASummitChecker(t *testing.T) {
+	ctx := &Context{fs: fakeFilesystem{map[string]string{"summitslist.csv": testSummitsList, "bad.csv": "a,b\n1,2\n"}}}
+	c, err := newSOTASummitChecker(ctx, "summitslist.csv")
+	if err != nil {
+		t.Fatalf("newSOTASummitChecker got error %v", err)
+	}
+	tests := []struct {
+		value string
+		want  spec.Validity
+	}{
+		{value: "W7W/KG-001", want: spec.Valid},
+		{value: "g/ld-001", want: spec.Valid},
+		{value: "not a summit", want: spec.Valid}, // format errors come from spec
+		{value: "W7W/KG-999", want: spec.InvalidError},
+		{value: "G/LD-002", want: spec.InvalidError},
+	}
+	for _, tc := range tests {
+		got := c.Validate(tc.value, spec.SotaRefField, spec.ValidationContext{})
+		if got.Validity != tc.want {
+			t.Errorf("Validate(%q) got %v %s, want %v", tc.value, got.Validity, got.Message, tc.want)
+		}
+	}
+	if _, err := newSOTASummitChecker(ctx, "bad.csv"); err == nil {
+		t.Errorf("newSOTASummitChecker(bad.csv) want error")
+	}
+	if _, err := newSOTASummitChecker(ctx, "missing.csv"); err == nil {
+		t.Errorf("newSOTASummitChecker(missing.csv) want error")
+	}
+}
+
+func TestValidateSOTADB(t *testing.T) {
+	for _, tc := range []struct {
+		file    string
+		wantErr bool
+	}{
+		{file: "<CALL:4>W1AW <SOTA_REF:10>W7W/KG-001 <EOR>\n"},
+		{file: "<CALL:4>W1AW <MY_SOTA_REF:8>G/LD-001 <SOTA_REF:8>G/LD-999 <EOR>\n", wantErr: true},
+	} {
+		adi := adif.NewADIIO()
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatADI,
+			Readers:      readers(adi),
+			Writers:      writers(adi),
+			Out:          out,
+			CommandCtx:   &ValidateContext{SOTADBPath: "summitslist.csv"},
+			fs:           fakeFilesystem{map[string]string{"foo.adi": tc.file, "summitslist.csv": testSummitsList}}}
+		err := Validate.Run(ctx, []string{"foo.adi"})
+		if tc.wantErr && err == nil {
+			t.Errorf("Validate.Run(%q) with --sota-db-path want error, got output:\n%s", tc.file, out)
+		} else if !tc.wantErr && err != nil {
+			t.Errorf("Validate.Run(%q) with --sota-db-path got error %v", tc.file, err)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+	"golang.org/x/exp/maps"
+)
+
+var Summary = Command{Name: "summary", Run: runSummary, Help: helpSummary,
+	Description: "Count contacts, multipliers, and claimed score for a contest"}
+
+type SummaryContext struct {
+	// Contest is a CONTEST_ID with scoring rules in contestScoring.  If empty,
+	// the CONTEST_ID field of the first record which has one is used.
+	Contest string
+}
+
+func helpSummary() string {
+	var ids []string
+	for _, id := range contestNames() {
+		ids = append(ids, fmt.Sprintf("  %s: %s", id, contestScoring[id].Description))
+	}
+	return `Outputs one record per band and a TOTAL record with the number of contacts,
+duplicates, QSO points, multipliers, and claimed score for a contest.  The
+contest is set by --contest or by the CONTEST_ID field.  A duplicate is a
+second contact with the same CALL on the same band; duplicates are not scored.
+Logs without a CONTEST_ID are summarized with one point per contact and no
+multipliers.  Scores are approximate, check the contest rules before
+submitting a claimed score.  Contests with scoring rules:
+` + strings.Join(ids, "\n") + "\n"
+}
+
+// contestRules scores contacts for a contest.
+type contestRules struct {
+	Description string
+	// Points returns the QSO points for a non-duplicate contact.
+	Points func(r *adif.Record) int
+	// Mults are multipliers counted once per band, the total score is total QSO
+	// points times the sum of all multipliers on all bands.
+	Mults []contestMultiplier
+}
+
+type contestMultiplier struct {
+	// Name is the output field prefix, e.g. ZONE becomes ZONE_MULTS.
+	Name string
+	// Key returns the multiplier value for a contact, or empty string if the
+	// contact doesn't count for this multiplier.
+	Key func(r *adif.Record) string
+}
+
+var cqWorldWide = contestRules{
+	Description: "CQ World Wide DX Contest: zones and DXCC entities per band",
+	Points:      cqWorldWidePoints,
+	Mults: []contestMultiplier{
+		{Name: "ZONE", Key: contactCQZone},
+		{Name: "COUNTRY", Key: contactDXCC},
+	},
+}
+
+var contestScoring = map[string]contestRules{
+	"CQ-WW-CW":  cqWorldWide,
+	"CQ-WW-SSB": cqWorldWide,
+	"CQ-WW-DX":  cqWorldWide, // not an ADIF CONTEST_ID, but a common name
+}
+
+// defaultContestRules apply to logs without a contest.
+var defaultContestRules = contestRules{Points: func(r *adif.Record) int { return 1 }}
+
+func contestNames() []string {
+	names := maps.Keys(contestScoring)
+	sort.Strings(names)
+	return names
+}
+
+type bandSummary struct {
+	band         string
+	qsos, dupes  int
+	points       int
+	mults        []map[string]bool
+	lowerFreqMhz float64
+}
+
+func runSummary(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*SummaryContext)
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	var records []*adif.Record
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		records = append(records, l.Records...)
+	}
+	contest := strings.ToUpper(strings.TrimSpace(cctx.Contest))
+	if contest == "" {
+		for _, r := range records {
+			if c, ok := r.Get(spec.ContestIdField.Name); ok && c.Value != "" {
+				contest = strings.ToUpper(c.Value)
+				break
+			}
+		}
+	}
+	rules := defaultContestRules
+	if contest != "" {
+		var ok bool
+		if rules, ok = contestScoring[contest]; !ok {
+			return fmt.Errorf("no scoring rules for contest %q, options: %s", contest, strings.Join(contestNames(), ", "))
+		}
+	}
+	bands := make(map[string]*bandSummary)
+	seen := make(map[string]bool)
+	for _, r := range records {
+		band := strings.ToLower(contactBand(r))
+		b, ok := bands[band]
+		if !ok {
+			b = &bandSummary{band: band, mults: make([]map[string]bool, len(rules.Mults))}
+			for i := range b.mults {
+				b.mults[i] = make(map[string]bool)
+			}
+			if e := spec.BandEnumeration.Value(band); len(e) == 1 {
+				b.lowerFreqMhz, _ = strconv.ParseFloat(e[0].(spec.BandEnum).LowerFreqMhz, 64)
+			}
+			bands[band] = b
+		}
+		if k := rules.DupeKey(r); k != "" {
+			if seen[k] {
+				b.dupes++
+				continue
+			}
+			seen[k] = true
+		}
+		b.qsos++
+		b.points += rules.Points(r)
+		for i, m := range rules.Mults {
+			if k := m.Key(r); k != "" {
+				b.mults[i][k] = true
+			}
+		}
+	}
+	sorted := maps.Values(bands)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if (a.lowerFreqMhz == 0) != (b.lowerFreqMhz == 0) {
+			return b.lowerFreqMhz == 0 // unknown bands last
+		}
+		if a.lowerFreqMhz != b.lowerFreqMhz {
+			return a.lowerFreqMhz < b.lowerFreqMhz
+		}
+		return a.band < b.band
+	})
+	order := []string{spec.BandField.Name, "QSOS", "DUPES", "POINTS"}
+	for _, m := range rules.Mults {
+		order = append(order, m.Name+"_MULTS")
+	}
+	order = append(order, "SCORE")
+	updateFieldOrder(acc.Out, order)
+	number := func(name string, n int) adif.Field {
+		return adif.Field{Name: name, Value: strconv.Itoa(n), Type: adif.TypeNumber}
+	}
+	var total bandSummary
+	var totalMults int
+	for _, b := range sorted {
+		r := adif.NewRecord(adif.Field{Name: spec.BandField.Name, Value: b.band},
+			number("QSOS", b.qsos), number("DUPES", b.dupes), number("POINTS", b.points))
+		total.qsos += b.qsos
+		total.dupes += b.dupes
+		total.points += b.points
+		for i, m := range rules.Mults {
+			r.Set(number(m.Name+"_MULTS", len(b.mults[i])))
+			totalMults += len(b.mults[i])
+		}
+		acc.Out.AddRecord(r)
+	}
+	r := adif.NewRecord(adif.Field{Name: spec.BandField.Name, Value: "TOTAL"},
+		number("QSOS", total.qsos), number("DUPES", total.dupes), number("POINTS", total.points))
+	for i, m := range rules.Mults {
+		var n int
+		for _, b := range sorted {
+			n += len(b.mults[i])
+		}
+		r.Set(number(m.Name+"_MULTS", n))
+	}
+	score := total.points
+	if len(rules.Mults) > 0 {
+		score *= totalMults
+	}
+	r.Set(number("SCORE", score))
+	acc.Out.AddRecord(r)
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
+
+// CabrilloScorer returns contest scoring rules for the CLAIMED-SCORE header in
+// Cabrillo output.
+func CabrilloScorer(contest string) (adif.CabrilloScorer, bool) {
+	rules, ok := contestScoring[strings.ToUpper(strings.TrimSpace(contest))]
+	return rules, ok
+}
+
+func (c contestRules) ScoreQSO(r *adif.Record) int { return c.Points(r) }
+
+// DupeKey returns the CALL and band of a contact; a contact with the same
+// callsign on the same band as an earlier contact is a duplicate.
+func (c contestRules) DupeKey(r *adif.Record) string {
+	call, _ := r.Get(spec.CallField.Name)
+	k := strings.ToUpper(strings.TrimSpace(call.Value))
+	if k == "" {
+		return ""
+	}
+	return k + " " + strings.ToLower(contactBand(r))
+}
+
+// Multipliers counts the unique values of each multiplier on each band.
+func (c contestRules) Multipliers(l *adif.Logfile) int {
+	var n int
+	for _, m := range c.Mults {
+		seen := make(map[[2]string]bool)
+		for _, r := range l.Records {
+			if k := m.Key(r); k != "" {
+				seen[[2]string{strings.ToLower(contactBand(r)), k}] = true
+			}
+		}
+		n += len(seen)
+	}
+	return n
+}
+
+// contactBand returns the BAND field or the band containing FREQ.
+func contactBand(r *adif.Record) string {
+	if b, ok := r.Get(spec.BandField.Name); ok && b.Value != "" {
+		return b.Value
+	}
+	if f, err := r.ParseFloat(spec.FreqField.Name); err == nil {
+		if b, ok := bandForFreq(f); ok {
+			return b.Band
+		}
+	}
+	return ""
+}
+
+// contactDXCC returns the DXCC entity code of the contacted station from the
+// DXCC field, COUNTRY field, or CALL prefix.
+func contactDXCC(r *adif.Record) string {
+	return entityCode(r, spec.DxccField, spec.CountryField, spec.CallField)
+}
+
+// stationDXCC returns the DXCC entity code of the logging station from the
+// MY_DXCC field, MY_COUNTRY field, or STATION_CALLSIGN prefix.
+func stationDXCC(r *adif.Record) string {
+	return entityCode(r, spec.MyDxccField, spec.MyCountryField, spec.StationCallsignField)
+}
+
+func entityCode(r *adif.Record, dxcc, country, call spec.Field) string {
+	if d, ok := r.Get(dxcc.Name); ok && d.Value != "" {
+		return strings.TrimSpace(d.Value)
+	}
+	if c, ok := r.Get(country.Name); ok && c.Value != "" {
+		if cs := spec.CountryEnumeration.Value(c.Value); len(cs) == 1 {
+			return cs[0].(spec.CountryEnum).EntityCode
+		}
+	}
+	if c, ok := r.Get(call.Name); ok && c.Value != "" {
+		if cs := spec.DXCCFromCallsign(c.Value); len(cs) == 1 {
+			return cs[0].EntityCode
+		}
+	}
+	return ""
+}
+
+// contactCQZone returns the CQZ field or the only CQ zone for the contacted
+// station's DXCC entity.
+func contactCQZone(r *adif.Record) string {
+	if z, ok := r.Get(spec.CqzField.Name); ok && z.Value != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(z.Value)); err == nil {
+			return strconv.Itoa(n)
+		}
+	}
+	if zs := spec.CQZoneFor(contactDXCC(r)); len(zs) == 1 {
+		return strconv.Itoa(zs[0])
+	}
+	return ""
+}
+
+// cqWorldWidePoints scores 3 points for a contact with another continent and
+// 1 point for a different entity on the same continent (2 points within North
+// America).  Contacts within the station's own DXCC entity are zero points.
+func cqWorldWidePoints(r *adif.Record) int {
+	mine, theirs := stationDXCC(r), contactDXCC(r)
+	if mine == "" || theirs == "" || mine == theirs {
+		return 0
+	}
+	myCont := spec.ContinentFor(mine).Abbreviation
+	theirCont := spec.ContinentFor(theirs).Abbreviation
+	if c, ok := r.Get(spec.ContField.Name); ok && c.Value != "" {
+		theirCont = strings.ToUpper(c.Value)
+	}
+	switch {
+	case myCont == "" || theirCont == "":
+		return 0
+	case myCont != theirCont:
+		return 3
+	case myCont == "NA":
+		return 2
+	default:
+		return 1
+	}
+}
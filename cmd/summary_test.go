@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummary(t *testing.T) {
+	csv := adif.NewCSVIO()
+	log := `STATION_CALLSIGN,CALL,BAND,FREQ,CQZ,CONTEST_ID
+W1AW,JA1ABC,20m,,25,CQ-WW-CW
+W1AW,DL1ABC,20m,,14,CQ-WW-CW
+W1AW,DL1ABC,20m,,14,CQ-WW-CW
+W1AW,VE3ABC,,7.025,4,CQ-WW-CW
+W1AW,K2ABC,40m,,5,CQ-WW-CW
+W1AW,DL1ABC,40m,,14,CQ-WW-CW
+`
+	tests := []struct {
+		name string
+		cctx SummaryContext
+		want string
+	}{
+		{
+			name: "contest id field",
+			cctx: SummaryContext{},
+			want: `BAND,QSOS,DUPES,POINTS,ZONE_MULTS,COUNTRY_MULTS,SCORE
+40m,3,0,5,3,3,
+20m,2,1,6,2,2,
+TOTAL,5,1,11,5,5,110
+`,
+		},
+		{
+			name: "contest flag",
+			cctx: SummaryContext{Contest: "cq-ww-dx"},
+			want: `BAND,QSOS,DUPES,POINTS,ZONE_MULTS,COUNTRY_MULTS,SCORE
+40m,3,0,5,3,3,
+20m,2,1,6,2,2,
+TOTAL,5,1,11,5,5,110
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			ctx := &Context{
+				OutputFormat: adif.FormatCSV,
+				Readers:      readers(csv),
+				Writers:      writers(csv),
+				Out:          out,
+				CommandCtx:   &tc.cctx,
+				fs:           fakeFilesystem{map[string]string{"log.csv": log}}}
+			if err := Summary.Run(ctx, []string{"log.csv"}); err != nil {
+				t.Fatalf("Summary.Run(ctx) got error %v", err)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("Summary.Run(ctx) unexpected output, diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummaryNoContest(t *testing.T) {
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(csv),
+		Writers:      writers(csv),
+		Out:          out,
+		CommandCtx:   &SummaryContext{},
+		fs:           fakeFilesystem{map[string]string{"log.csv": "CALL,BAND\nK1A,2m\nK1B,2m\nK1A,6m\n"}}}
+	if err := Summary.Run(ctx, []string{"log.csv"}); err != nil {
+		t.Fatalf("Summary.Run(ctx) got error %v", err)
+	}
+	want := `BAND,QSOS,DUPES,POINTS,SCORE
+6m,1,0,1,
+2m,2,0,2,
+TOTAL,3,0,3,3
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Summary.Run(ctx) unexpected output, diff:\n%s", diff)
+	}
+	ctx.CommandCtx = &SummaryContext{Contest: "NOT-A-CONTEST"}
+	if err := Summary.Run(ctx, []string{"log.csv"}); err == nil {
+		t.Errorf("Summary.Run(ctx) with unknown contest got no error")
+	}
+}
+
+func TestCabrilloScorer(t *testing.T) {
+	if _, ok := CabrilloScorer("ARRL-FIELD-DAY"); ok {
+		t.Errorf("CabrilloScorer(ARRL-FIELD-DAY) want no scorer")
+	}
+	s, ok := CabrilloScorer("cq-ww-ssb")
+	if !ok {
+		t.Fatalf("CabrilloScorer(cq-ww-ssb) got no scorer")
+	}
+	l := adif.NewLogfile()
+	for _, c := range [][3]string{{"JA1ABC", "20m", "25"}, {"DL1ABC", "20m", "14"}, {"VE3ABC", "40m", "4"}, {"DL1ABC", "40m", "14"}} {
+		l.AddRecord(adif.NewRecord(adif.Field{Name: "STATION_CALLSIGN", Value: "W1AW"},
+			adif.Field{Name: "CALL", Value: c[0]}, adif.Field{Name: "BAND", Value: c[1]}, adif.Field{Name: "CQZ", Value: c[2]}))
+	}
+	var points int
+	for _, r := range l.Records {
+		points += s.ScoreQSO(r)
+	}
+	if points != 11 {
+		t.Errorf("CQ-WW-SSB ScoreQSO total got %d, want 11", points)
+	}
+	if got := s.Multipliers(l); got != 8 {
+		t.Errorf("CQ-WW-SSB Multipliers got %d, want 8", got)
+	}
+	withBand := adif.NewRecord(adif.Field{Name: "CALL", Value: "DL1ABC"}, adif.Field{Name: "BAND", Value: "20M"})
+	withFreq := adif.NewRecord(adif.Field{Name: "CALL", Value: "dl1abc"}, adif.Field{Name: "FREQ", Value: "14.025"})
+	if a, b := s.DupeKey(withBand), s.DupeKey(withFreq); a != b {
+		t.Errorf("DupeKey(%v) = %q, DupeKey(%v) = %q, want equal", withBand, a, withFreq, b)
+	}
+	if k := s.DupeKey(adif.NewRecord(adif.Field{Name: "BAND", Value: "20m"})); k != "" {
+		t.Errorf("DupeKey without CALL got %q, want empty", k)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+var Tail = Command{Name: "tail", Run: runTail, Help: helpTail,
+	Description: "Print the last records from the input"}
+
+type TailContext struct {
+	Count int
+}
+
+func helpTail() string {
+	return `Records from all input files are counted together, as if they were combined
+with cat.  If count is negative, prints all but the first -count records.
+`
+}
+
+func runTail(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*TailContext)
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		acc.Out.Records = append(acc.Out.Records, l.Records...)
+	}
+	recs := acc.Out.Records
+	if cctx.Count >= 0 {
+		recs = recs[maxInt(len(recs)-cctx.Count, 0):]
+	} else if -cctx.Count < len(recs) {
+		recs = recs[-cctx.Count:]
+	} else {
+		recs = nil
+	}
+	acc.Out.Records = recs
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	return write(ctx, acc.Out)
+}
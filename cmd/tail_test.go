@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTail(t *testing.T) {
+	csv := adif.NewCSVIO()
+	file1 := `CALL,BAND
+K1A,20m
+K2B,40m
+`
+	file2 := `CALL,MODE
+K3C,CW
+K4D,SSB
+`
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{count: 0, want: "CALL,BAND,MODE\n"},
+		{count: 1, want: "CALL,BAND,MODE\nK4D,,SSB\n"},
+		{count: 3, want: "CALL,BAND,MODE\nK2B,40m,\nK3C,,CW\nK4D,,SSB\n"},
+		{count: 10, want: "CALL,BAND,MODE\nK1A,20m,\nK2B,40m,\nK3C,,CW\nK4D,,SSB\n"},
+		{count: -1, want: "CALL,BAND,MODE\nK2B,40m,\nK3C,,CW\nK4D,,SSB\n"},
+		{count: -3, want: "CALL,BAND,MODE\nK4D,,SSB\n"},
+		{count: -4, want: "CALL,BAND,MODE\n"},
+		{count: -5, want: "CALL,BAND,MODE\n"},
+	}
+	for _, tc := range tests {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatCSV,
+			Readers:      readers(csv),
+			Writers:      writers(csv),
+			Out:          out,
+			Prepare:      testPrepare("My Comment", "3.1.4", "tail test", "1.2.3"),
+			CommandCtx:   &TailContext{Count: tc.count},
+			fs:           fakeFilesystem{map[string]string{"foo.csv": file1, "bar.csv": file2}}}
+		if err := Tail.Run(ctx, []string{"foo.csv", "bar.csv"}); err != nil {
+			t.Errorf("Tail.Run(ctx) count %d got error %v", tc.count, err)
+		} else if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+			t.Errorf("Tail.Run(ctx) count %d unexpected output, diff:\n%s", tc.count, diff)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"golang.org/x/exp/slices"
+)
+
+var Tee = Command{Name: "tee", Run: runTee, Help: helpTee,
+	Description: "Write records to standard output and to other files, possibly in other formats"}
+
+type TeeContext struct {
+	Outputs           TeeOutputList
+	OverwriteExisting bool
+	CreateDirectory   bool
+}
+
+func helpTee() string {
+	return `Each --outputs value is a file name, optionally preceded by a format or
+preset name and a colon.  If no format is given, it is inferred from the file
+extension.  A preset limits which fields are written to that file, e.g.
+  adifmt tee --outputs adi:all.adi,csv:all.csv --outputs lotw-upload:lotw.adi log.adi
+writes every field to all.adi and all.csv but only LoTW fields to lotw.adi.
+Records are also written to standard output in the --output format.
+`
+}
+
+func runTee(ctx *Context, args []string) error {
+	cctx := ctx.CommandCtx.(*TeeContext)
+	if len(cctx.Outputs) == 0 {
+		return errors.New("tee requires at least one --outputs file")
+	}
+	fs := ctx.fs
+	if fs == nil {
+		fs = osFilesystem{}
+	}
+	seen := make(map[string]bool)
+	for _, o := range cctx.Outputs {
+		if seen[o.File] {
+			return fmt.Errorf("output file %s given more than once", o.File)
+		}
+		seen[o.File] = true
+		if !cctx.OverwriteExisting && fs.Exists(o.File) {
+			return fmt.Errorf("output file %s already exists", o.File)
+		}
+	}
+	acc, err := newAccumulator(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range filesOrStdin(args) {
+		l, err := acc.read(f)
+		if err != nil {
+			return err
+		}
+		updateFieldOrder(acc.Out, l.FieldOrder)
+		for _, r := range l.Records {
+			acc.Out.AddRecord(r)
+		}
+	}
+	if err := acc.prepare(); err != nil {
+		return err
+	}
+	for _, o := range cctx.Outputs {
+		if err := teeFile(ctx, fs, o, cctx.CreateDirectory, copyLogfile(acc.Out)); err != nil {
+			return err
+		}
+	}
+	return write(ctx, acc.Out)
+}
+
+func teeFile(ctx *Context, fs filesystem, o TeeOutput, mkdir bool, l *adif.Logfile) error {
+	if mkdir {
+		if err := fs.MkdirAll(path.Dir(o.File)); err != nil && !errors.Is(err, os.ErrExist) {
+			return err
+		}
+	}
+	out, err := fs.Create(o.File)
+	if err != nil {
+		return err
+	}
+	octx := *ctx
+	octx.Out = out
+	octx.OutputFormat = o.Format
+	octx.Compress = compressionFor(o.File, ctx.Compress)
+	if o.Preset.Name != "" {
+		octx.Preset = o.Preset
+	}
+	if err := write(&octx, l); err != nil {
+		out.Close()
+		return fmt.Errorf("error writing %s: %w", o.File, err)
+	}
+	return out.Close()
+}
+
+// copyLogfile returns a copy of l which can be modified by write without
+// changing l; records are shared.
+func copyLogfile(l *adif.Logfile) *adif.Logfile {
+	res := &adif.Logfile{
+		Records:    slices.Clone(l.Records),
+		Header:     adif.NewRecord(l.Header.Fields()...),
+		Userdef:    slices.Clone(l.Userdef),
+		Comment:    l.Comment,
+		Filename:   l.Filename,
+		FieldOrder: slices.Clone(l.FieldOrder),
+	}
+	res.Header.SetComment(l.Header.GetComment())
+	return res
+}
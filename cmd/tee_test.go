@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTee(t *testing.T) {
+	adi := adif.NewADIIO()
+	csv := adif.NewCSVIO()
+	tsv := adif.NewTSVIO()
+	out := &bytes.Buffer{}
+	file1 := `CALL,QSO_DATE,TIME_ON,BAND,MODE,NAME
+K1A,20240101,1234,20m,FT8,Al
+K2B,20240102,0123,40m,SSB,Bea
+`
+	fs := fakeFilesystem{map[string]string{"foo.csv": file1}}
+	cctx := &TeeContext{}
+	if err := cctx.Outputs.Set("tsv:copy.txt,lotw-upload:lotw.adi,backup.adi.gz"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(adi, csv, tsv),
+		Writers:      writers(adi, csv, tsv),
+		Out:          out,
+		CommandCtx:   cctx,
+		fs:           fs}
+	if err := Tee.Run(ctx, []string{"foo.csv"}); err != nil {
+		t.Fatalf("Tee.Run(ctx, foo.csv) got error %v", err)
+	}
+	if diff := cmp.Diff(file1, out.String()); diff != "" {
+		t.Errorf("Tee.Run(ctx, foo.csv) unexpected stdout, diff:\n%s", diff)
+	}
+	want := map[string]string{
+		"foo.csv": file1,
+		"copy.txt": `CALL	QSO_DATE	TIME_ON	BAND	MODE	NAME
+K1A	20240101	1234	20m	FT8	Al
+K2B	20240102	0123	40m	SSB	Bea
+`,
+		"lotw.adi": `<CALL:3>K1A <QSO_DATE:8>20240101 <TIME_ON:4>1234 <BAND:3>20m <MODE:3>FT8 <EOR>
+<CALL:3>K2B <QSO_DATE:8>20240102 <TIME_ON:4>0123 <BAND:3>40m <MODE:3>SSB <EOR>
+`,
+		"backup.adi.gz": `<CALL:3>K1A <QSO_DATE:8>20240101 <TIME_ON:4>1234 <BAND:3>20m <MODE:3>FT8 <NAME:2>Al <EOR>
+<CALL:3>K2B <QSO_DATE:8>20240102 <TIME_ON:4>0123 <BAND:3>40m <MODE:3>SSB <NAME:3>Bea <EOR>
+`,
+	}
+	if got, err := gunzipIfNamed("backup.adi.gz", fs.files["backup.adi.gz"]); err != nil {
+		t.Errorf("Tee.Run(ctx, foo.csv) wrote invalid gzip: %v", err)
+	} else {
+		fs.files["backup.adi.gz"] = got
+	}
+	if diff := cmp.Diff(want, fs.files); diff != "" {
+		t.Errorf("Tee.Run(ctx, foo.csv) unexpected files, diff:\n%s", diff)
+	}
+}
+
+func TestTeeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []string
+		exists  bool
+	}{
+		{name: "no outputs"},
+		{name: "unknown extension", outputs: []string{"out.txt"}},
+		{name: "empty file name", outputs: []string{"csv:"}},
+		{name: "duplicate file", outputs: []string{"out.adi", "tsv:out.adi"}},
+		{name: "file exists", outputs: []string{"out.adi"}, exists: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			adi := adif.NewADIIO()
+			fs := fakeFilesystem{map[string]string{"foo.adi": "<CALL:3>K1A <EOR>\n"}}
+			if tc.exists {
+				fs.files["out.adi"] = "original"
+			}
+			cctx := &TeeContext{}
+			for _, o := range tc.outputs {
+				if err := cctx.Outputs.Set(o); err != nil {
+					return // invalid flag value is an expected error
+				}
+			}
+			out := &bytes.Buffer{}
+			ctx := &Context{
+				OutputFormat: adif.FormatADI,
+				Readers:      readers(adi),
+				Writers:      writers(adi),
+				Out:          out,
+				CommandCtx:   cctx,
+				fs:           fs}
+			if err := Tee.Run(ctx, []string{"foo.adi"}); err == nil {
+				t.Errorf("Tee.Run(ctx, foo.adi) with outputs %v want error, got output:\n%s", tc.outputs, out)
+			}
+			if tc.exists && fs.files["out.adi"] != "original" {
+				t.Errorf("Tee.Run(ctx, foo.adi) overwrote out.adi: %q", fs.files["out.adi"])
+			}
+		})
+	}
+}
@@ -15,47 +15,311 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/flwyd/adif-multitool/adif"
 	"github.com/flwyd/adif-multitool/adif/spec"
+	"golang.org/x/exp/slices"
 )
 
-var Validate = Command{Name: "validate", Run: runValidate, Help: helpValidate,
+var Validate = Command{Name: "validate", Run: runValidate, Help: helpValidate, Batches: true,
 	Description: "Validate field values; non-zero exit and no stdout if invalid"}
 
 type ValidateContext struct {
 	RequiredFields FieldList
 	Cond           ConditionValue
+	// Severity, if set, only prints problems at that level
+	Severity Severity
+	// MinSeverity, if set, only prints problems at that level or higher
+	MinSeverity Severity
+	// FailOn is the lowest level which causes failure, defaults to error
+	FailOn Severity
+	// Quiet only prints the summary, not each problem.
+	Quiet bool
+	// Verbose also prints each field which has no problems.
+	Verbose bool
+	// POTAAPI checks that POTA_REF and MY_POTA_REF parks exist using the
+	// Parks on the Air API, which requires network access.
+	POTAAPI    bool
+	potaAPIURL string // for testing
+	// SOTADBPath is a SOTA summits list CSV file used to check that SOTA_REF
+	// and MY_SOTA_REF summits exist.
+	SOTADBPath string
+	// WWFFDBPath is a WWFF directory CSV file used to check that WWFF_REF and
+	// MY_WWFF_REF parks exist.
+	WWFFDBPath string
+	// CheckSerials checks that STX values, ordered by date and time, count up
+	// from 1 without gaps or duplicates.
+	CheckSerials bool
+	// WarnLocalTime warns if most TIME_ON values would be in the middle of the
+	// night at the station's longitude, suggesting times are not in UTC.
+	WarnLocalTime bool
+	// AllowCallsignVariation suppresses warnings about different
+	// STATION_CALLSIGN values in the same file, e.g. for multi-op logs.
+	AllowCallsignVariation bool
+	// CheckGeoPlausibility warns if GRIDSQUARE is far from the DXCC entity
+	// and likewise for MY_GRIDSQUARE and MY_DXCC.
+	CheckGeoPlausibility bool
+	// CheckModeBand warns about modes which are unusual on the record's band,
+	// e.g. FM on 40 meters.
+	CheckModeBand bool
+	// CheckSOTA warns about records without MY_SOTA_REF which look like part
+	// of a Summits on the Air activation.
+	CheckSOTA bool
+	// MinFreqPrecision, if positive, warns if FREQ or FREQ_RX has fewer
+	// decimal places, e.g. 3 for kilohertz precision.
+	MinFreqPrecision int
+	// MaxFreqPrecision, if positive, warns if FREQ or FREQ_RX has more
+	// decimal places, e.g. 6 for hertz precision.
+	MaxFreqPrecision int
+	// CheckPrecision warns if DISTANCE claims more precision than a four
+	// character GRIDSQUARE or MY_GRIDSQUARE can support.
+	CheckPrecision bool
+	// CheckAppFields warns about application-defined field names which don't
+	// look like APP_PROGRAMID_FIELDNAME or whose PROGRAMID doesn't match the
+	// file's PROGRAMID header.
+	CheckAppFields bool
+	// CheckPowerLimits warns if TX_PWR is more than the regulatory limit on the
+	// record's band in the station's DXCC entity.
+	CheckPowerLimits bool
+	// DXCC is the logging station's entity code for CheckPowerLimits if a
+	// record does not have MY_DXCC.
+	DXCC string
+	// MinQSODuration, if positive, warns if TIME_OFF is less than this long
+	// after TIME_ON.  FT8 and FT4 contacts have a higher minimum.
+	MinQSODuration time.Duration
+	// MaxQSODuration, if positive, warns if TIME_OFF is more than this long
+	// after TIME_ON.
+	MaxQSODuration time.Duration
+	// CheckDups warns about records with the same DupKey field values.
+	CheckDups bool
+	// DupKey is the list of fields which identify a duplicate contact; if
+	// empty, defaultDupKey is used.
+	DupKey FieldList
+	// DupTimeTolerance, if positive, compares QSO_DATE and TIME_ON as a
+	// timestamp, so contacts this close together are duplicates.
+	DupTimeTolerance time.Duration
+	// UniqueIDFields are fields, like an app-defined record ID, which must
+	// have a different value in each record.
+	UniqueIDFields FieldList
+	// FieldValues, if set, are validated and printed instead of reading files.
+	FieldValues FieldAssignments
+}
+
+func (c *ValidateContext) shouldPrint(s Severity) bool {
+	if c.Quiet {
+		return false
+	}
+	if c.Severity != SeverityUnset {
+		return s == c.Severity
+	}
+	return s >= c.MinSeverity
 }
 
 func helpValidate() string {
-	return "Non-failure warnings are added as comments in ADI and ADX output.\n"
+	return `Non-failure warnings are added as comments in ADI and ADX output.
+Severity levels are warning and error; --severity and --min-severity only
+affect which problems are printed, not the exit status.  Set --fail-on warning
+to treat warnings as failures.  A summary with the number of records, errors,
+and warnings is always printed at the end.  --quiet only prints the summary,
+not each problem.  --verbose also prints each field value without a problem.
+
+--check-serials sorts records by QSO_DATE and TIME_ON and reports duplicate
+STX serial numbers as errors and gaps in the sequence as warnings.
+
+--warn-local-time uses MY_LAT/MY_LON or MY_GRIDSQUARE to estimate the local
+hour of each contact.  If most contacts in the log would have been between
+midnight and 6am, TIME_ON may have been logged in local time instead of UTC.
+
+Different STATION_CALLSIGN values in the same file produce a warning, noting
+whether the difference is just a portable designator like W1AW/4.  Set
+--allow-callsign-variation if the log has contacts from several stations.
+
+--check-geo-plausibility warns if GRIDSQUARE is not near the DXCC entity (or
+COUNTRY if DXCC is not set), and likewise for MY_GRIDSQUARE.  Only large and
+commonly-contacted entities like the United States, Japan, and Australia have
+geographic data; others are not checked.
+
+--check-mode-band warns about modes which are unusual on a band, where a
+mistake is more likely than a rare contact: FM below 10m, DIGITALVOICE below
+80m, and ATV below 70cm.  BAND is inferred from FREQ if not set.
+
+--check-sota warns about records without MY_SOTA_REF which look like part of a
+Summits on the Air activation: SOTA_REF is set (a summit-to-summit contact
+needs both) or other records in the file have MY_SOTA_REF.
+
+--min-freq-precision and --max-freq-precision warn if FREQ or FREQ_RX (in
+megahertz) has too few or too many decimal places.  For example,
+--min-freq-precision 3 warns about 14 or 14.07 (coarser than 1 kHz) and
+--max-freq-precision 6 warns about 14.0740001 (finer than 1 Hz).
+
+--check-precision warns if DISTANCE has more than 3 significant figures but
+GRIDSQUARE or MY_GRIDSQUARE has only 4 characters (and LAT/LON or MY_LAT/MY_LON
+are not set), since a 4-character grid square is about 100 km across.
+
+--check-app-fields warns if an application-defined field name doesn't have the
+form APP_PROGRAMID_FIELDNAME, is longer than 64 characters, or names a program
+other than the file's PROGRAMID header.
+
+TX_PWR above the limit for a QRP or LOW Cabrillo CATEGORY-POWER is a warning.
+The category comes from an APP_CABRILLO_CATEGORY_POWER header or the
+--cabrillo-power option.
+
+With the global --batch-size option, records are validated and written in
+batches.  Validation stops after the first batch with errors, but earlier
+batches have already been written.  --check-dups, --check-serials,
+--warn-local-time, and --check-sota compare all records, so they read the whole
+log at once.
+
+--check-power-limits warns if TX_PWR is more than the legal limit for BAND (or
+the band containing FREQ) in the station's DXCC entity, from MY_DXCC or the
+--dxcc option.  Only United States limits are known, e.g. 100 watts on 60m and
+200 watts on 30m.
+
+--min-qso-duration and --max-qso-duration warn if the time from QSO_DATE and
+TIME_ON to QSO_DATE_OFF and TIME_OFF is outside a range, e.g.
+--min-qso-duration 5s --max-qso-duration 2h.  FT8 and FT4 contacts take at
+least one transmit period, so they are held to a minimum of 15 or 7.5 seconds.
+Times without seconds are treated as accurate to the minute.
+
+--check-dups warns about records with the same CALL, QSO_DATE, TIME_ON, BAND,
+and MODE.  --dup-key sets a different list of fields.  --dup-time-tolerance
+treats contacts a short time apart as duplicates, e.g. --dup-time-tolerance 5m
+warns about two contacts with the same station on the same band and mode at
+12:34 and 12:38.
+
+--check-id-uniqueness reports an error if a field, such as an app-defined record
+ID like APP_MYLOG_ID, has the same value in more than one record.  Blank values
+are not checked.
+
+--validate-field FIELD=value checks a value without reading a log file and
+prints "valid" or the warning or error for each field.  Fields given together
+are validated as one record, e.g. --validate-field CALL=W1AW --validate-field
+DXCC=1 warns that the callsign prefix doesn't match the entity.
+
+--sota-db-path checks SOTA_REF and MY_SOTA_REF against a summits list CSV file
+from https://www.sotadata.org.uk/summitslist.csv without network access.
+WWFF_REF and MY_WWFF_REF are always checked for a plausible national program
+prefix like KFF or ONFF; --wwff-db-path checks that parks exist in a WWFF
+directory CSV file from https://wwff.co/wwff-data/wwff_directory.csv.
+
+--pota-api looks up each park in POTA_REF and MY_POTA_REF at api.pota.app.
+Unknown parks are errors; if the API can't be reached they are warnings.
+`
 }
 
 func runValidate(ctx *Context, args []string) error {
 	cctx := ctx.CommandCtx.(*ValidateContext)
+	if len(cctx.FieldValues.values) > 0 {
+		if len(args) > 0 {
+			return fmt.Errorf("--validate-field does not read input files, got %v", args)
+		}
+		return validateFieldValues(ctx, cctx)
+	}
 	now := time.Now().UTC() // consistent for the whole log
 	cond := cctx.Cond.Get()
-	log := os.Stderr
+	var log io.Writer = validUTF8Writer{os.Stderr}
 	var errors, warnings int
 	appFields := make(map[string]adif.DataType)
+	var pota *potaParkChecker
+	if cctx.POTAAPI {
+		pota = newPOTAParkChecker(cctx.potaAPIURL)
+	}
+	var sota *sotaSummitChecker
+	if cctx.SOTADBPath != "" {
+		var err error
+		if sota, err = newSOTASummitChecker(ctx, cctx.SOTADBPath); err != nil {
+			return err
+		}
+	}
+	var wwff *wwffParkChecker
+	if cctx.WWFFDBPath != "" {
+		var err error
+		if wwff, err = newWWFFParkChecker(ctx, cctx.WWFFDBPath); err != nil {
+			return err
+		}
+	}
+	var serials []serialNumber
+	var localTimes []int
+	checkDups := cctx.CheckDups || len(cctx.DupKey) > 0 || cctx.DupTimeTolerance > 0
+	dupKey := cctx.DupKey
+	if len(dupKey) == 0 {
+		dupKey = defaultDupKey
+	}
+	var dups []dupRecord
+	idsSeen := make(map[string]map[string]string)
+	for _, n := range cctx.UniqueIDFields {
+		idsSeen[strings.ToUpper(n)] = make(map[string]string)
+	}
+	if cctx.Quiet && cctx.Verbose {
+		return fmt.Errorf("--quiet and --verbose are mutually exclusive")
+	}
+	if ctx.BatchSize > 0 && (checkDups || cctx.CheckSerials || cctx.WarnLocalTime || cctx.CheckSOTA) {
+		fmt.Fprintln(os.Stderr, "Warning: --batch-size has no effect with checks that compare all records in a file")
+		bctx := *ctx
+		bctx.BatchSize = 0
+		ctx = &bctx
+	}
+	failure := func() error {
+		if errors > 0 || (cctx.FailOn == SeverityWarning && warnings > 0) {
+			return fmt.Errorf("validate got %d errors and %d warnings", errors, warnings)
+		}
+		return nil
+	}
+	var records int
+	printSummary := func() {
+		fmt.Fprintf(log, "Validated %d records: %d errors, %d warnings\n", records, errors, warnings)
+	}
 	acc, err := newAccumulator(ctx)
 	if err != nil {
 		return err
 	}
-	for _, f := range filesOrStdin(args) {
-		l, err := acc.read(f)
-		if err != nil {
-			return err
-		}
+	// state for each file which carries over between batches
+	var appNamesChecked, stations map[string]bool
+	var firstStation string
+	var firstStationRec int
+	err = acc.processBatches(filesOrStdin(args), func(l *adif.Logfile, first int) error {
+		records += len(l.Records)
 		updateFieldOrder(acc.Out, l.FieldOrder)
-		for i, r := range l.Records {
+		powerCat, powerMax := powerCategoryLimit(ctx, l)
+		var summits []string
+		if cctx.CheckSOTA {
+			summits = activatedSummits(l)
+		}
+		programID, _ := l.Header.Get(spec.ProgramidField.Name)
+		if first == 0 {
+			appNamesChecked = make(map[string]bool)
+			stations = make(map[string]bool)
+			firstStation, firstStationRec = "", 0
+		}
+		for j, r := range l.Records {
+			i := first + j
+			if sc, ok := r.Get(spec.StationCallsignField.Name); ok && sc.Value != "" && !cctx.AllowCallsignVariation {
+				call := strings.ToUpper(sc.Value)
+				if firstStation == "" {
+					firstStation, firstStationRec = call, i+1
+				} else if !stations[call] {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						diff := "differs from"
+						if baseCallsign(call) == baseCallsign(firstStation) {
+							diff = "is a portable variation of"
+						}
+						fmt.Fprintf(log, "WARNING on %s record %d: %s %s %s %s in record %d\n", l, i+1, spec.StationCallsignField.Name, sc.Value, diff, firstStation, firstStationRec)
+					}
+				}
+				stations[call] = true
+			}
 			vctx := spec.ValidationContext{
-				Now: now,
+				Now:    now,
+				Locale: ctx.Locale,
 				FieldValue: func(name string) string {
 					f, _ := r.Get(name)
 					return f.Value
@@ -70,7 +334,9 @@ func runValidate(ctx *Context, args []string) error {
 				}
 				if len(missing) > 0 {
 					errors++
-					fmt.Fprintf(log, "ERROR on %s record %d: missing fields %s\n", l, i+1, strings.Join(missing, ", "))
+					if cctx.shouldPrint(SeverityError) {
+						fmt.Fprintf(log, "ERROR on %s record %d: missing fields %s\n", l, i+1, strings.Join(missing, ", "))
+					}
 				}
 			}
 			for _, f := range r.Fields() {
@@ -80,32 +346,59 @@ func runValidate(ctx *Context, args []string) error {
 						appFields[name] = f.Type
 					} else if f.Type != adif.TypeUnspecified && f.Type != adt {
 						warnings++
-						fmt.Fprintf(log, "WARNING on %s record %d: inconsistent types for %s\n", l, i+1, f.Name)
+						if cctx.shouldPrint(SeverityWarning) {
+							fmt.Fprintf(log, "WARNING on %s record %d: inconsistent types for %s\n", l, i+1, f.Name)
+						}
+					}
+					if cctx.CheckAppFields && !appNamesChecked[name] {
+						appNamesChecked[name] = true
+						for _, msg := range appFieldNameProblems(name, programID.Value) {
+							warnings++
+							if cctx.shouldPrint(SeverityWarning) {
+								fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+							}
+						}
 					}
 				}
 				if f.Value == "" {
 					continue
 				}
+				problems := errors + warnings
 				validateSpec := func(fv spec.FieldValidator, fs spec.Field) {
 					if fv != nil {
 						switch v := fv(f.Value, fs, vctx); v.Validity {
 						case spec.InvalidError:
 							errors++
-							fmt.Fprintf(log, "ERROR on %s record %d: %s\n", l, i+1, v)
+							if cctx.shouldPrint(SeverityError) {
+								fmt.Fprintf(log, "ERROR on %s record %d: %s\n", l, i+1, v)
+							}
 						case spec.InvalidWarning:
 							warnings++
-							fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, v)
+							if cctx.shouldPrint(SeverityWarning) {
+								fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, v)
+							}
 							msgs = append(msgs, fmt.Sprintf("%s: %s", f.Name, v.Message))
 						}
 					}
 				}
 				if fs, ok := spec.FieldNamed(f.Name); ok {
 					validateSpec(spec.TypeValidators[fs.Type.Name], fs)
+					if pota != nil && fs.Type == spec.POTARefListDataType {
+						validateSpec(pota.Validate, fs)
+					}
+					if sota != nil && fs.Type == spec.SOTARefDataType {
+						validateSpec(sota.Validate, fs)
+					}
+					if wwff != nil && fs.Type == spec.WWFFRefDataType {
+						validateSpec(wwff.Validate, fs)
+					}
 				} else if u, ok := acc.Out.GetUserdef(f.Name); ok {
 					if len(u.EnumValues) > 0 || u.Min != 0.0 || u.Max != 0.0 {
 						if err := u.Validate(f); err != nil {
 							errors++
-							fmt.Fprintf(log, "ERROR on %s record %d: %s\n", l, i+1, err)
+							if cctx.shouldPrint(SeverityError) {
+								fmt.Fprintf(log, "ERROR on %s record %d: %s\n", l, i+1, err)
+							}
 						}
 					} else { // spec enum validator can't handle userdef enums
 						dt := spec.DataTypes[u.Type.Indicator()]
@@ -113,25 +406,689 @@ func runValidate(ctx *Context, args []string) error {
 						validateSpec(spec.TypeValidators[dt.Name], fs)
 					}
 				} else if f.IsAppDefined() {
-					fs := spec.Field{Name: f.Name, Type: spec.DataTypes[appFields[name].Indicator()]}
+					// prefer the type declared on this field, e.g. <APP_X_SCORE:3:N>
+					t := f.Type
+					if t == adif.TypeUnspecified {
+						t = appFields[name]
+					}
+					fs := spec.Field{Name: f.Name, Type: spec.DataTypes[t.Indicator()]}
+					if t == adif.TypeEnumeration {
+						// app-defined enumerations don't declare their values
+						fs.Type = spec.StringDataType
+					}
 					validateSpec(spec.TypeValidators[fs.Type.Name], fs)
 				}
 				if len(msgs) > 0 {
 					r.SetComment("adif-multitool: validate warnings: " + strings.Join(msgs, "; "))
 				}
+				if cctx.Verbose && errors+warnings == problems {
+					fmt.Fprintf(log, "OK on %s record %d: %s %q\n", l, i+1, f.Name, f.Value)
+				}
+			}
+			if cctx.CheckGeoPlausibility {
+				for _, msg := range implausibleGridsquares(r) {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if powerMax > 0 {
+				if p, err := r.ParseFloat(spec.TxPwrField.Name); err == nil && p > float64(powerMax) {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s %s is more than %d watts for CATEGORY-POWER %s\n", l, i+1, spec.TxPwrField.Name, strconv.FormatFloat(p, 'f', -1, 64), powerMax, powerCat)
+					}
+				}
+			}
+			if cctx.MinQSODuration > 0 || cctx.MaxQSODuration > 0 {
+				if msg, ok := unusualQSODuration(r, cctx.MinQSODuration, cctx.MaxQSODuration); ok {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if cctx.CheckPowerLimits {
+				if msg, ok := overPowerLimit(r, cctx.DXCC); ok {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if cctx.CheckSOTA {
+				if msg, ok := missingActivatorSummit(r, summits); ok {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if cctx.MinFreqPrecision > 0 || cctx.MaxFreqPrecision > 0 {
+				for _, msg := range freqPrecisionProblems(r, cctx.MinFreqPrecision, cctx.MaxFreqPrecision) {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if cctx.CheckPrecision {
+				if msg, ok := impreciseDistance(r); ok {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if cctx.CheckModeBand {
+				if msg, ok := unusualModeBand(r); ok {
+					warnings++
+					if cctx.shouldPrint(SeverityWarning) {
+						fmt.Fprintf(log, "WARNING on %s record %d: %s\n", l, i+1, msg)
+					}
+				}
+			}
+			if cctx.CheckSerials {
+				if stx, err := r.ParseInt(spec.StxField.Name); err == nil {
+					date, _ := r.Get(spec.QsoDateField.Name)
+					timeOn, _ := r.Get(spec.TimeOnField.Name)
+					serials = append(serials, serialNumber{
+						where: fmt.Sprintf("%s record %d", l, i+1), when: date.Value + timeWithSeconds(timeOn.Value), stx: stx})
+				}
+			}
+			if cctx.WarnLocalTime {
+				if m, ok := solarMinutes(r); ok {
+					localTimes = append(localTimes, m)
+				}
+			}
+			for _, n := range cctx.UniqueIDFields {
+				n = strings.ToUpper(n)
+				seen := idsSeen[n]
+				f, ok := r.Get(n)
+				id := strings.TrimSpace(f.Value)
+				if !ok || id == "" {
+					continue
+				}
+				where := fmt.Sprintf("%s record %d", l, i+1)
+				if prev, ok := seen[id]; ok {
+					errors++
+					if cctx.shouldPrint(SeverityError) {
+						fmt.Fprintf(log, "ERROR on %s: duplicate %s %q, also used by %s\n", where, n, id, prev)
+					}
+				} else {
+					seen[id] = where
+				}
+			}
+			if checkDups {
+				dups = append(dups, newDupRecord(r, fmt.Sprintf("%s record %d", l, i+1), dupKey, cctx.DupTimeTolerance > 0))
 			}
 			acc.Out.AddRecord(r)
 		}
+		if ctx.BatchSize > 0 {
+			// don't write the batch if it has problems
+			if err := failure(); err != nil {
+				printSummary()
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	if errors > 0 {
-		return fmt.Errorf("validate got %d errors and %d warnings", errors, warnings)
+	if checkDups {
+		w := checkDupRecords(dups, cctx.DupTimeTolerance, func(where, msg string) {
+			if cctx.shouldPrint(SeverityWarning) {
+				fmt.Fprintf(log, "WARNING on %s: %s\n", where, msg)
+			}
+		})
+		warnings += w
+	}
+	if cctx.WarnLocalTime {
+		night := 0
+		for _, m := range localTimes {
+			if m < localNightEnd {
+				night++
+			}
+		}
+		if len(localTimes) >= localTimeMinRecords && night*2 > len(localTimes) {
+			warnings++
+			if cctx.shouldPrint(SeverityWarning) {
+				fmt.Fprintf(log, "WARNING: %d of %d contacts would be between midnight and 6am local time; %s may not be UTC\n", night, len(localTimes), spec.TimeOnField.Name)
+			}
+		}
+	}
+	if cctx.CheckSerials {
+		e, w := checkSerials(serials, func(s Severity, where, msg string) {
+			if cctx.shouldPrint(s) {
+				fmt.Fprintf(log, "%s on %s: %s\n", strings.ToUpper(s.String()), where, msg)
+			}
+		})
+		errors += e
+		warnings += w
 	}
-	if err := acc.prepare(); err != nil {
+	printSummary()
+	if err := failure(); err != nil {
 		return err
 	}
-	err = write(ctx, acc.Out)
-	if warnings > 0 {
-		fmt.Fprintf(log, "validate got %d warnings\n", warnings)
+	return acc.write()
+}
+
+// validateFieldValues prints the validation result of each --validate-field
+// value, treating all of them as fields in a single record.
+func validateFieldValues(ctx *Context, cctx *ValidateContext) error {
+	vals := make(map[string]string)
+	for _, f := range cctx.FieldValues.values {
+		vals[f.Name] = f.Value
+	}
+	vctx := spec.ValidationContext{
+		Now:        time.Now().UTC(),
+		FieldValue: func(name string) string { return vals[strings.ToUpper(name)] },
+		Locale:     ctx.Locale,
+	}
+	var errors, warnings int
+	for _, f := range cctx.FieldValues.values {
+		var v spec.Validation
+		if fs, ok := spec.FieldNamed(f.Name); ok {
+			if fv := spec.TypeValidators[fs.Type.Name]; fv != nil && f.Value != "" {
+				v = fv(f.Value, fs, vctx)
+			}
+		} else if i := slices.IndexFunc(ctx.UserdefFields, func(u adif.UserdefField) bool { return strings.EqualFold(u.Name, f.Name) }); i >= 0 {
+			if err := ctx.UserdefFields[i].Validate(f); err != nil {
+				v = spec.Validation{Validity: spec.InvalidError, Message: err.Error()}
+			}
+		} else if !f.IsAppDefined() {
+			v = spec.Validation{Validity: spec.InvalidError, Message: fmt.Sprintf("%s is not an ADIF field", f.Name)}
+		}
+		res := "valid"
+		switch v.Validity {
+		case spec.InvalidError:
+			errors++
+			res = "error: " + v.Message
+		case spec.InvalidWarning:
+			warnings++
+			res = "warning: " + v.Message
+		}
+		fmt.Fprintf(ctx.Out, "%s=%s: %s\n", f.Name, f.Value, res)
+	}
+	if errors > 0 || (cctx.FailOn == SeverityWarning && warnings > 0) {
+		return fmt.Errorf("validate got %d errors and %d warnings", errors, warnings)
+	}
+	return nil
+}
+
+type serialNumber struct {
+	where, when string
+	stx         int
+}
+
+// timeWithSeconds returns an HHMMSS time so HHMM and HHMMSS values sort together.
+func timeWithSeconds(t string) string {
+	if len(t) == 4 {
+		return t + "00"
+	}
+	return t
+}
+
+// checkSerials sorts serials by time and reports duplicates as errors and gaps
+// (including a first serial number other than 1) as warnings.
+func checkSerials(serials []serialNumber, report func(s Severity, where, msg string)) (errors, warnings int) {
+	sort.SliceStable(serials, func(i, j int) bool { return serials[i].when < serials[j].when })
+	var prev int
+	seen := make(map[int]string)
+	for _, s := range serials {
+		if w, ok := seen[s.stx]; ok {
+			errors++
+			report(SeverityError, s.where, fmt.Sprintf("duplicate %s serial number %d, also used by %s", spec.StxField.Name, s.stx, w))
+			continue
+		}
+		seen[s.stx] = s.where
+		if s.stx != prev+1 {
+			warnings++
+			if s.stx <= prev {
+				report(SeverityWarning, s.where, fmt.Sprintf("%s serial number %d is out of order, previous was %d", spec.StxField.Name, s.stx, prev))
+			} else if prev == 0 {
+				report(SeverityWarning, s.where, fmt.Sprintf("%s serial numbers start at %d, not 1", spec.StxField.Name, s.stx))
+			} else {
+				report(SeverityWarning, s.where, fmt.Sprintf("gap in %s serial numbers from %d to %d", spec.StxField.Name, prev, s.stx))
+			}
+		}
+		prev = s.stx
+	}
+	return
+}
+
+// geoMargin is the distance in degrees a grid square can be outside a DXCC
+// entity's bounding box before it is implausible.
+const geoMargin = 1.0
+
+// implausibleGridsquares returns a message if GRIDSQUARE or MY_GRIDSQUARE
+// doesn't overlap the approximate bounds of the record's DXCC entity.
+func implausibleGridsquares(r *adif.Record) []string {
+	var res []string
+	for _, fs := range [][3]spec.Field{
+		{spec.GridsquareField, spec.DxccField, spec.CountryField},
+		{spec.MyGridsquareField, spec.MyDxccField, spec.MyCountryField},
+	} {
+		gs, ok := r.Get(fs[0].Name)
+		if !ok || gs.Value == "" {
+			continue
+		}
+		ent, _ := r.Get(fs[1].Name)
+		if ent.Value == "" {
+			ent, _ = r.Get(fs[2].Name)
+		}
+		if ent.Value == "" {
+			continue
+		}
+		box, ok := spec.DXCCBounds(ent.Value)
+		if !ok {
+			continue
+		}
+		minLat, maxLat, minLon, maxLon, err := spec.GridsquareBoundingBox(gs.Value)
+		if err != nil {
+			continue // invalid format is reported by the field validator
+		}
+		box.MinLat -= geoMargin
+		box.MaxLat += geoMargin
+		box.MinLon -= geoMargin
+		box.MaxLon += geoMargin
+		if !box.Intersects(spec.LatLonBox{MinLat: minLat, MaxLat: maxLat, MinLon: minLon, MaxLon: maxLon}) {
+			res = append(res, fmt.Sprintf("%s %s is not near %s %s", fs[0].Name, gs.Value, ent.Name, ent.Value))
+		}
+	}
+	return res
+}
+
+// validUTF8Writer replaces invalid UTF-8 sequences with U+FFFD so that
+// messages quoting non-ASCII field values are safe to print.  Each Write call
+// is assumed to be a complete message, so multi-byte characters aren't split.
+type validUTF8Writer struct{ w io.Writer }
+
+func (v validUTF8Writer) Write(p []byte) (int, error) {
+	if _, err := v.w.Write(bytes.ToValidUTF8(p, []byte("\uFFFD"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// powerCategoryLimit returns the Cabrillo CATEGORY-POWER of a log and its
+// maximum power in watts, or 0 if the category is not QRP or LOW.  The
+// category comes from an APP_CABRILLO_CATEGORY_POWER header, e.g. from a
+// Cabrillo file, or the --cabrillo-power option.  Limits are set by
+// --cabrillo-max-power-qrp and --cabrillo-max-power-low.
+func powerCategoryLimit(ctx *Context, l *adif.Logfile) (string, int) {
+	cab, ok := ctx.Writers[adif.FormatCabrillo].(*adif.CabrilloIO)
+	if !ok {
+		cab = adif.NewCabrilloIO()
+	}
+	var cat string
+	if h, ok := l.Header.Get("APP_CABRILLO_CATEGORY_POWER"); ok && h.Value != "" {
+		cat = h.Value
+	} else {
+		cat = cab.Categories["POWER"]
+	}
+	cat = strings.ToUpper(strings.TrimSpace(cat))
+	switch cat {
+	case "QRP":
+		return cat, cab.QRPPowerMax
+	case "LOW":
+		return cat, cab.LowPowerMax
+	default:
+		return cat, 0
+	}
+}
+
+// overPowerLimit returns a message if TX_PWR is more than the regulatory limit
+// for the record's band in the MY_DXCC entity, or dxcc if MY_DXCC is not set.
+func overPowerLimit(r *adif.Record, dxcc string) (string, bool) {
+	p, err := r.ParseFloat(spec.TxPwrField.Name)
+	if err != nil {
+		return "", false
+	}
+	if d, _ := r.Get(spec.MyDxccField.Name); d.Value != "" {
+		dxcc = d.Value
+	}
+	var band string
+	if b, _ := r.Get(spec.BandField.Name); b.Value != "" {
+		band = b.Value
+	} else if freq, err := r.ParseFloat(spec.FreqField.Name); err == nil {
+		if bb, ok := bandForFreq(freq); ok {
+			band = bb.Band
+		}
+	}
+	if band == "" {
+		return "", false
+	}
+	limit, ok := spec.PowerLimitFor(dxcc, band)
+	if !ok || p <= limit {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s is more than the %s watt limit on %s in DXCC entity %s", spec.TxPwrField.Name, strconv.FormatFloat(p, 'f', -1, 64), strconv.FormatFloat(limit, 'f', -1, 64), band, dxcc), true
+}
+
+// lowestModeBands is the lowest band where each mode is commonly used.
+// Operation below these bands is legal in many places but much more likely to
+// be a logging mistake.  Digital voice modes like D-STAR and FreeDV do see
+// HF use, so DIGITALVOICE is only unusual on the lowest bands.
+var lowestModeBands = map[string]string{
+	"ATV":          "70cm",
+	"DIGITALVOICE": "80m",
+	"FM":           "10m",
+}
+
+// unusualModeBand returns a message if MODE is not commonly used on BAND, or
+// on the band containing FREQ if BAND is not set.
+func unusualModeBand(r *adif.Record) (string, bool) {
+	mode, _ := r.Get(spec.ModeField.Name)
+	lowest, ok := lowestModeBands[strings.ToUpper(mode.Value)]
+	if !ok {
+		return "", false
+	}
+	var band spec.BandEnum
+	if b, _ := r.Get(spec.BandField.Name); b.Value != "" {
+		bs := spec.BandEnumeration.Value(b.Value)
+		if len(bs) != 1 {
+			return "", false // unknown band is reported by the field validator
+		}
+		band = bs[0].(spec.BandEnum)
+	} else if freq, err := r.ParseFloat(spec.FreqField.Name); err == nil {
+		if band, ok = bandForFreq(freq); !ok {
+			return "", false
+		}
+	} else {
+		return "", false
+	}
+	low := spec.BandEnumeration.Value(lowest)[0].(spec.BandEnum)
+	bf, err := strconv.ParseFloat(band.LowerFreqMhz, 64)
+	if err != nil {
+		return "", false
+	}
+	lf, err := strconv.ParseFloat(low.LowerFreqMhz, 64)
+	if err != nil || bf >= lf {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s is unusual on %s band, expected %s or higher", spec.ModeField.Name, mode.Value, band.Band, low.Band), true
+}
+
+// freqPrecisionProblems returns a message for each of FREQ and FREQ_RX with
+// fewer than minPlaces or more than maxPlaces decimal places.  Zero limits are
+// not checked.  Blank and non-numeric values are left to the field validator.
+func freqPrecisionProblems(r *adif.Record, minPlaces, maxPlaces int) []string {
+	var res []string
+	for _, name := range []string{spec.FreqField.Name, spec.FreqRxField.Name} {
+		f, _ := r.Get(name)
+		if f.Value == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(f.Value, 64); err != nil {
+			continue
+		}
+		parts := strings.Split(f.Value, ".")
+		places := 0
+		if len(parts) == 2 {
+			places = len(parts[1])
+		}
+		if minPlaces > 0 && places < minPlaces {
+			res = append(res, fmt.Sprintf("%s %s has %d decimal places, fewer than %d", name, f.Value, places, minPlaces))
+		}
+		if maxPlaces > 0 && places > maxPlaces {
+			res = append(res, fmt.Sprintf("%s %s has %d decimal places, more than %d", name, f.Value, places, maxPlaces))
+		}
+	}
+	return res
+}
+
+// appFieldMaxLen is the longest application-defined field name which isn't
+// considered excessive.  ADIF doesn't set a limit, but other programs may.
+const appFieldMaxLen = 64
+
+// appFieldNameProblems returns warnings if name, an upper-case field starting
+// with APP_, doesn't follow the APP_PROGRAMID_FIELDNAME convention or if the
+// PROGRAMID part doesn't match programID (if not empty).  Spaces and
+// punctuation are ignored in programID, so APP_N1MM_EXCHANGE1 matches
+// "N1MM Logger+".
+func appFieldNameProblems(name, programID string) []string {
+	var res []string
+	parts := strings.SplitN(name, "_", 3)
+	if len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+		return append(res, fmt.Sprintf("%s should be named APP_PROGRAMID_FIELDNAME", name))
+	}
+	if len(name) > appFieldMaxLen {
+		res = append(res, fmt.Sprintf("%s is longer than %d characters", name, appFieldMaxLen))
+	}
+	if programID != "" {
+		pid := strings.ToUpper(programID)
+		alnum := strings.Map(func(r rune) rune {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, pid)
+		if !strings.HasPrefix(name, "APP_"+pid+"_") && parts[1] != alnum {
+			res = append(res, fmt.Sprintf("%s program %s does not match %s %s", name, parts[1], spec.ProgramidField.Name, programID))
+		}
+	}
+	return res
+}
+
+// impreciseDistance returns a message if DISTANCE has more than 3 significant
+// figures but either station's location is only known to a 4-character grid
+// square, which can be 100 km or more from the actual location.
+func impreciseDistance(r *adif.Record) (string, bool) {
+	d, _ := r.Get(spec.DistanceField.Name)
+	if _, err := strconv.ParseFloat(d.Value, 64); err != nil {
+		return "", false
+	}
+	if n := significantFigures(d.Value); n > 3 {
+		for _, g := range []struct{ grid, lat, lon spec.Field }{
+			{spec.GridsquareField, spec.LatField, spec.LonField},
+			{spec.MyGridsquareField, spec.MyLatField, spec.MyLonField},
+		} {
+			grid, _ := r.Get(g.grid.Name)
+			if grid.Value == "" || len(grid.Value) > 4 {
+				continue
+			}
+			if lat, _ := r.Get(g.lat.Name); lat.Value != "" {
+				if lon, _ := r.Get(g.lon.Name); lon.Value != "" {
+					continue
+				}
+			}
+			return fmt.Sprintf("%s %s has %d significant figures but %s %s is only precise to about 100 km", spec.DistanceField.Name, d.Value, n, g.grid.Name, grid.Value), true
+		}
+	}
+	return "", false
+}
+
+// significantFigures counts the significant digits in a decimal number.
+// Leading zeros are not significant, nor are trailing zeros in a number
+// without a decimal point, so 1200 has two significant figures and 1200.0 has
+// five.
+func significantFigures(num string) int {
+	num = strings.TrimLeft(num, "+-")
+	whole, frac, hasPoint := strings.Cut(num, ".")
+	digits := strings.TrimLeft(whole+frac, "0")
+	if !hasPoint {
+		digits = strings.TrimRight(digits, "0")
+	}
+	return len(digits)
+}
+
+// baseCallsign returns the longest part of a callsign separated by slashes,
+// e.g. W1AW for W1AW/4, VE3/W1AW, or W1AW/P.
+func baseCallsign(call string) string {
+	var res string
+	for _, p := range strings.Split(call, "/") {
+		if len(p) > len(res) {
+			res = p
+		}
+	}
+	return res
+}
+
+var defaultDupKey = FieldList{spec.CallField.Name, spec.QsoDateField.Name, spec.TimeOnField.Name, spec.BandField.Name, spec.ModeField.Name}
+
+type dupRecord struct {
+	where, key string
+	when       time.Time // zero if not comparing times
+}
+
+// newDupRecord creates a dupRecord with the values of key fields.  If useTime
+// is true and the record has a valid date and time, QSO_DATE and TIME_ON are
+// compared as a timestamp rather than as part of the key.
+func newDupRecord(r *adif.Record, where string, key FieldList, useTime bool) dupRecord {
+	res := dupRecord{where: where}
+	if useTime {
+		d, derr := r.ParseDate(spec.QsoDateField.Name)
+		t, terr := r.ParseTime(spec.TimeOnField.Name)
+		if derr == nil && terr == nil {
+			res.when = d.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second)
+		}
+	}
+	vals := make([]string, 0, len(key))
+	for _, k := range key {
+		if !res.when.IsZero() && (strings.EqualFold(k, spec.QsoDateField.Name) || strings.EqualFold(k, spec.TimeOnField.Name)) {
+			continue
+		}
+		f, _ := r.Get(k)
+		vals = append(vals, strings.ToUpper(f.Value))
+	}
+	res.key = strings.Join(vals, "\x00")
+	if !res.when.IsZero() {
+		res.key = "time\x00" + res.key // don't match records without a time
+	}
+	return res
+}
+
+// checkDupRecords reports records with the same key and, if tolerance is
+// positive, a time within tolerance of an earlier record with that key.
+// Returns the number of warnings.
+func checkDupRecords(recs []dupRecord, tolerance time.Duration, report func(where, msg string)) (warnings int) {
+	byKey := make(map[string][]dupRecord)
+	var keys []string
+	for _, r := range recs {
+		if byKey[r.key] == nil {
+			keys = append(keys, r.key)
+		}
+		byKey[r.key] = append(byKey[r.key], r)
+	}
+	for _, k := range keys {
+		group := byKey[k]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool { return group[i].when.Before(group[j].when) })
+		for i := 1; i < len(group); i++ {
+			prev, cur := group[i-1], group[i]
+			if cur.when.IsZero() {
+				warnings++
+				report(cur.where, fmt.Sprintf("possible duplicate of %s", group[0].where))
+			} else if d := cur.when.Sub(prev.when); d <= tolerance {
+				warnings++
+				report(cur.where, fmt.Sprintf("possible duplicate of %s, %s apart", prev.where, d))
+			}
+		}
+	}
+	return
+}
+
+// modeMinQSODurations are the shortest possible contacts for modes with fixed
+// transmit periods.
+var modeMinQSODurations = map[string]time.Duration{
+	"FT8": 15 * time.Second,
+	"FT4": 7500 * time.Millisecond,
+}
+
+// qsoDuration returns the time between QSO_DATE + TIME_ON and QSO_DATE_OFF +
+// TIME_OFF.  If QSO_DATE_OFF is not set and TIME_OFF is earlier than TIME_ON,
+// the contact is assumed to end the next day.  slack is the amount the true
+// duration could be longer or shorter because a time doesn't have seconds.
+func qsoDuration(r *adif.Record) (dur, slack time.Duration, ok bool) {
+	date, err := r.ParseDate(spec.QsoDateField.Name)
+	if err != nil {
+		return 0, 0, false
+	}
+	on, err := r.ParseTime(spec.TimeOnField.Name)
+	if err != nil {
+		return 0, 0, false
+	}
+	off, err := r.ParseTime(spec.TimeOffField.Name)
+	if err != nil {
+		return 0, 0, false
+	}
+	dateOff, err := r.ParseDate(spec.QsoDateOffField.Name)
+	hasDateOff := err == nil
+	if !hasDateOff {
+		dateOff = date
+	}
+	sinceMidnight := func(t time.Time) time.Duration {
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	}
+	dur = dateOff.Add(sinceMidnight(off)).Sub(date.Add(sinceMidnight(on)))
+	if dur < 0 && !hasDateOff {
+		dur += 24 * time.Hour
+	}
+	timeOn, _ := r.Get(spec.TimeOnField.Name)
+	timeOff, _ := r.Get(spec.TimeOffField.Name)
+	if len(timeOn.Value) == 4 || len(timeOff.Value) == 4 {
+		slack = time.Minute - time.Second
+	}
+	return dur, slack, true
+}
+
+// unusualQSODuration returns a message if a contact's duration is less than
+// minDur (or the minimum for its SUBMODE or MODE) or more than maxDur.
+func unusualQSODuration(r *adif.Record, minDur, maxDur time.Duration) (string, bool) {
+	dur, slack, ok := qsoDuration(r)
+	if !ok {
+		return "", false
+	}
+	if dur < 0 {
+		return fmt.Sprintf("%s and %s are before %s and %s", spec.QsoDateOffField.Name, spec.TimeOffField.Name, spec.QsoDateField.Name, spec.TimeOnField.Name), true
+	}
+	mode, _ := r.Get(spec.SubmodeField.Name)
+	if mode.Value == "" {
+		mode, _ = r.Get(spec.ModeField.Name)
+	}
+	var forMode string
+	if m, ok := modeMinQSODurations[strings.ToUpper(mode.Value)]; ok && minDur > 0 && m > minDur {
+		minDur = m
+		forMode = " for " + strings.ToUpper(mode.Value)
+	}
+	if minDur > 0 && dur+slack < minDur {
+		return fmt.Sprintf("contact duration %s is less than %s%s", dur, minDur, forMode), true
+	}
+	if maxDur > 0 && dur-slack > maxDur {
+		return fmt.Sprintf("contact duration %s is more than %s", dur, maxDur), true
+	}
+	return "", false
+}
+
+const (
+	localTimeMinRecords = 10
+	localNightEnd       = 6 * 60 // minutes after midnight
+)
+
+// solarMinutes returns the approximate local time of TIME_ON in minutes after
+// midnight, based on the station's longitude rather than time zone rules.
+func solarMinutes(r *adif.Record) (int, bool) {
+	t, err := r.ParseTime(spec.TimeOnField.Name)
+	if err != nil {
+		return 0, false
+	}
+	var lon float64
+	if _, l, err := parseLatLon(r, spec.MyLatField.Name); err == nil {
+		lon = l
+	} else if gs, ok := r.Get(spec.MyGridsquareField.Name); ok && gs.Value != "" {
+		_, l, err := parseMaidenhead(gs.Value)
+		if err != nil {
+			return 0, false
+		}
+		lon = l
+	} else {
+		return 0, false
 	}
-	return err
+	m := t.Hour()*60 + t.Minute() + int(lon*4) // 15° per hour
+	return (m%1440 + 1440) % 1440, true
 }
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/flwyd/adif-multitool/adif"
@@ -55,7 +56,7 @@ func TestValidateNoErrors(t *testing.T) {
 	file1 := `My Comment
 <ADIF_VER:5>3.1.4 <PROGRAMID:13>validate test <PROGRAMVERSION:5>1.2.3 <EOH>
 <QSO_DATE:8>19901031 <TIME_ON:4>1234 <BAND:3>40M <FREQ:5>7.123 <MODE:2>CW <CALLSIGN:4>W1AW <NAME:17>Hiram Percy Maxim <AGE:2>31 <ARRL_SECT:2>CT <CONT:2>NA <GRIDSQUARE:6>FN31pr <SILENT_KEY:1>y <DXCC:3>291 <COUNTRY:24>UNITED STATES OF AMERICA <EOR>
-<QSO_DATE:8:D>20221224 <TIME_ON:6:T>095846 <BAND:6:E>1.25cm <FREQ:5>24240 <MODE:3>PSK <SUBMODE:7>QPSK500 <CALLSIGN:3:S>N0P <NAME:11:S>Santa Claus <EMAIL:23>santa AT north DOT pole <QSO_RANDOM:1>N <IOTA_REF:6>EU-019 <K_INDEX:1>9 <GRIDSQUARE:4>LR49 <CONT:2>EU <DXCC:2>54 <COUNTRY:15>EUROPEAN RUSSIA <EOR>
+<QSO_DATE:8:D>20221224 <TIME_ON:6:T>095846 <BAND:6:E>1.25cm <FREQ:5>24240 <MODE:3>PSK <SUBMODE:7>QPSK500 <CALLSIGN:3:S>N0P <NAME:11:S>Santa Claus <EMAIL:23>santa AT north DOT pole <QSO_RANDOM:1>N <IOTA_REF:6>EU-019 <K_INDEX:1>9 <GRIDSQUARE:4>LR49 <CONT:2>EU <DXCC:2>54 <COUNTRY:15>EUROPEAN RUSSIA <APP_LOG_SCORE:3:N>100 <APP_LOG_SLEIGH:8:E>REINDEER <EOR>
 `
 	ctx := &Context{
 		OutputFormat: adif.FormatADI,
@@ -76,6 +77,33 @@ func TestValidateNoErrors(t *testing.T) {
 	}
 }
 
+func TestValidateBatchSize(t *testing.T) {
+	adi := adif.NewADIIO()
+	file := `<CALL:4>W1AW <BAND:3>40m <EOR>
+<CALL:3>N0P <BAND:3>20m <EOR>
+<BAND:3>80m <EOR>
+<CALL:4>K1AR <BAND:3>15m <EOR>
+`
+	for _, tc := range []struct{ size, records int }{{0, 0}, {1, 2}, {2, 2}, {3, 0}} {
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatADI,
+			Readers:      readers(adi),
+			Writers:      writers(adi),
+			Out:          out,
+			Prepare:      testPrepare("My Comment", "3.1.4", "validate test", "1.2.3"),
+			BatchSize:    tc.size,
+			CommandCtx:   &ValidateContext{RequiredFields: FieldList{"CALL"}, Quiet: true},
+			fs:           fakeFilesystem{map[string]string{"foo.adi": file}}}
+		if err := Validate.Run(ctx, []string{"foo.adi"}); err == nil {
+			t.Errorf("Validate.Run(ctx, foo.adi) with batch size %d expected an error", tc.size)
+		}
+		if got := strings.Count(out.String(), "<EOR>"); got != tc.records {
+			t.Errorf("Validate.Run(ctx, foo.adi) with batch size %d wrote %d records, want %d:\n%s", tc.size, got, tc.records, out.String())
+		}
+	}
+}
+
 func TestValidateErrors(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -121,6 +149,14 @@ func TestValidateErrors(t *testing.T) {
 			name:   "app-defined field type error",
 			record: []adif.Field{{Name: "APP_MONOLOG_BOOLEAN", Value: "uh-huh", Type: adif.TypeBoolean}},
 		},
+		{
+			name:   "app-defined number",
+			record: []adif.Field{{Name: "APP_LOG_SCORE", Value: "1OO", Type: adif.TypeNumber}},
+		},
+		{
+			name:   "app-defined date",
+			record: []adif.Field{{Name: "APP_LOG_CHECKED", Value: "2024-01-02", Type: adif.TypeDate}},
+		},
 		{
 			name:    "userdef invalid number",
 			record:  []adif.Field{{Name: "LUGGAGE_CODE", Value: "IZEA"}},
@@ -169,3 +205,21 @@ func TestValidateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestValidUTF8Writer(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{in: "NAME_INTL \"Peña\"\n", want: "NAME_INTL \"Peña\"\n"},
+		{in: "QTH_INTL \"Z\xfcrich\"\n", want: "QTH_INTL \"Z�rich\"\n"},
+		{in: "日本語 \xe6\x97\n", want: "日本語 �\n"},
+	}
+	for _, tc := range tests {
+		out := &bytes.Buffer{}
+		w := validUTF8Writer{out}
+		if n, err := w.Write([]byte(tc.in)); err != nil || n != len(tc.in) {
+			t.Errorf("Write(%q) got (%d, %v), want (%d, nil)", tc.in, n, err, len(tc.in))
+		}
+		if got := out.String(); got != tc.want {
+			t.Errorf("Write(%q) wrote %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
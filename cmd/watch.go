@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flwyd/adif-multitool/adif"
+)
+
+var Watch = Command{Name: "watch", Run: runWatch, Help: helpWatch,
+	Description: "Run a command on records as they are appended to an ADI file"}
+
+type WatchContext struct {
+	// Command is run on each batch of new records, configured by CommandCtx.
+	Command     Command
+	CommandName string
+	CommandCtx  any
+	// Interval is the time between checks for new data in the file.
+	Interval time.Duration
+	// FromStart runs Command on records already in the file before watching
+	// for new ones.
+	FromStart bool
+}
+
+func helpWatch() string {
+	return `Watch takes a single ADI file name.  Each time new complete records are
+appended to the file, e.g. by a logging program, they are processed by the
+--command as if they were a separate logfile with the watched file's header.
+Options for the watched command can also be given, e.g.
+  watch --command validate --required-fields call,band,mode log.adi
+Records already in the file are skipped unless --from-start is set.  If the file
+shrinks (e.g. it was rewritten) it is read again from the beginning.
+Watch runs until interrupted, e.g. with Ctrl-C.
+`
+}
+
+func runWatch(ctx *Context, args []string) error {
+	cctx, ok := ctx.CommandCtx.(*WatchContext)
+	if !ok {
+		return errors.New("invalid command context type")
+	}
+	if cctx.Command.Run == nil || cctx.Command.Name == "watch" {
+		return fmt.Errorf("--command %q is not a command which processes records", cctx.CommandName)
+	}
+	if len(args) != 1 || args[0] == "-" {
+		return errors.New("watch needs exactly one file name")
+	}
+	if ctx.InputFormat.IsValid() && ctx.InputFormat != adif.FormatADI {
+		return fmt.Errorf("watch only supports ADI files, not %s", ctx.InputFormat)
+	}
+	if f, err := adif.GuessFormatFromName(args[0]); err == nil && f != adif.FormatADI {
+		return fmt.Errorf("watch only supports ADI files, not %s", f)
+	}
+	interval := cctx.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	w := &fileWatcher{ctx: ctx, cctx: cctx, name: args[0], skip: !cctx.FromStart}
+	for {
+		if err := w.poll(); err != nil {
+			return err
+		}
+		time.Sleep(interval)
+	}
+}
+
+// fileWatcher tracks the position of the last complete record read from an
+// ADI file.  Polling the file size keeps this portable without depending on
+// platform-specific file notification APIs.
+type fileWatcher struct {
+	ctx        *Context
+	cctx       *WatchContext
+	name       string
+	header     string // header text through <EOH>, prepended to each batch
+	headerDone bool
+	skip       bool // true if existing records should be skipped
+	offset     int64
+}
+
+// poll reads any new data in the file and runs the watched command if there
+// are complete records.  Errors from the command are printed to standard
+// error and do not stop the watch; errors reading the file are returned.
+func (w *fileWatcher) poll() error {
+	fs := w.ctx.fs
+	if fs == nil {
+		fs = osFilesystem{}
+	}
+	f, err := fs.Open(w.name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s, ok := f.(io.Seeker)
+	if !ok {
+		return fmt.Errorf("cannot watch %s, it is not a regular file", w.name)
+	}
+	size, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if size < w.offset {
+		fmt.Fprintf(os.Stderr, "%s got shorter, reading from the beginning\n", w.name)
+		w.offset, w.header, w.headerDone, w.skip = 0, "", false, false
+	}
+	if size == w.offset {
+		return nil
+	}
+	if _, err := s.Seek(w.offset, io.SeekStart); err != nil {
+		return err
+	}
+	b, err := io.ReadAll(io.LimitReader(f, size-w.offset))
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", w.name, err)
+	}
+	buf := string(b)
+	pos := 0
+	if !w.headerDone {
+		// ADI files have a header unless the first character is <
+		if buf[0] != '<' {
+			i := indexFold(buf, "<eoh>")
+			if i < 0 {
+				return nil // wait for the rest of the header
+			}
+			pos = i + len("<eoh>")
+			w.header = buf[:pos]
+		}
+		w.headerDone = true
+	}
+	end := lastIndexFold(buf[pos:], "<eor>")
+	if end < 0 {
+		w.offset += int64(pos)
+		return nil
+	}
+	end += pos + len("<eor>")
+	recs := buf[pos:end]
+	w.offset += int64(end)
+	if w.skip {
+		w.skip = false
+		return nil
+	}
+	if w.header == "" {
+		recs = strings.TrimLeft(recs, " \t\r\n")
+	}
+	sub := *w.ctx
+	sub.InputFormat = adif.FormatADI
+	sub.CommandCtx = w.cctx.CommandCtx
+	sub.fs = overlayFilesystem{base: w.ctx.fs, files: map[string]string{w.name: w.header + recs}}
+	if err := w.cctx.Command.Run(&sub, []string{w.name}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running %s on new records in %s: %v\n", w.cctx.Command.Name, w.name, err)
+	}
+	return nil
+}
+
+// overlayFilesystem reads some files from memory and delegates everything
+// else to base, or the OS filesystem if base is nil.
+type overlayFilesystem struct {
+	base  filesystem
+	files map[string]string
+}
+
+func (o overlayFilesystem) fs() filesystem {
+	if o.base == nil {
+		return osFilesystem{}
+	}
+	return o.base
+}
+
+func (o overlayFilesystem) Exists(name string) bool {
+	_, ok := o.files[name]
+	return ok || o.fs().Exists(name)
+}
+
+func (o overlayFilesystem) Open(name string) (NamedReader, error) {
+	if s, ok := o.files[name]; ok {
+		return &stringReader{Reader: strings.NewReader(s), Filename: name}, nil
+	}
+	return o.fs().Open(name)
+}
+
+func (o overlayFilesystem) Create(name string) (io.WriteCloser, error) {
+	return o.fs().Create(name)
+}
+
+func (o overlayFilesystem) Append(name string) (io.WriteCloser, error) {
+	return o.fs().Append(name)
+}
+
+func (o overlayFilesystem) MkdirAll(dir string) error { return o.fs().MkdirAll(dir) }
+
+func (o overlayFilesystem) Replace(name string, write func(io.Writer) error) error {
+	return o.fs().Replace(name, write)
+}
+
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func lastIndexFold(s, substr string) int {
+	for i := len(s) - len(substr); i >= 0; i-- {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatchPoll(t *testing.T) {
+	name := "log.adi"
+	fs := fakeFilesystem{map[string]string{}}
+	appendFile := func(s string) { fs.files[name] += s }
+	appendFile("Log header\n<ADIF_VER:5>3.1.4 <USERDEF1:4:N>SCORE <EOH>\n<CALL:4>K1AB <SCORE:1>1 <EOR>\n")
+	csv := adif.NewCSVIO()
+	out := &bytes.Buffer{}
+	ctx := &Context{
+		OutputFormat: adif.FormatCSV,
+		Readers:      readers(adif.NewADIIO(), csv),
+		Writers:      writers(csv),
+		Out:          out,
+		fs:           fs,
+	}
+	w := &fileWatcher{ctx: ctx, name: name, skip: true,
+		cctx: &WatchContext{Command: Cat, CommandName: "cat", CommandCtx: &CatContext{}}}
+	poll := func(want string) {
+		t.Helper()
+		out.Reset()
+		if err := w.poll(); err != nil {
+			t.Fatalf("poll() got error %v", err)
+		}
+		if diff := cmp.Diff(want, out.String()); diff != "" {
+			t.Errorf("poll() unexpected output, diff:\n%s", diff)
+		}
+	}
+	poll("")
+	poll("")
+	appendFile("<CALL:4>K2CD <SCORE:1>2 <EOR>\n<CALL:4>K3EF <SCORE:1>3 <eor>\n<CALL:4>K4GH ")
+	poll("CALL,SCORE\nK2CD,2\nK3EF,3\n")
+	poll("")
+	appendFile("<SCORE:1>4 <EOR>\n")
+	poll("CALL,SCORE\nK4GH,4\n")
+	fs.files[name] = "<CALL:4>K5IJ <EOR>\n"
+	poll("CALL\nK5IJ\n")
+}
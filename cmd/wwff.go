@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+// wwffParkChecker checks World Wide Flora and Fauna references against a
+// directory CSV file, as downloaded from
+// https://wwff.co/wwff-data/wwff_directory.csv
+type wwffParkChecker struct {
+	parks map[string]bool
+}
+
+// newWWFFParkChecker reads the reference column of a WWFF directory.
+func newWWFFParkChecker(ctx *Context, filename string) (*wwffParkChecker, error) {
+	parks, err := readCSVColumn(ctx, filename, "reference", "WWFF directory")
+	if err != nil {
+		return nil, err
+	}
+	return &wwffParkChecker{parks: parks}, nil
+}
+
+// Validate is a spec.FieldValidator for WWFFRef fields.  References which
+// don't have a valid format are skipped, since the spec validator reports them.
+// An unknown WWFF program is only a warning from the spec validator, but any
+// reference not in the directory is an error.
+func (c *wwffParkChecker) Validate(val string, f spec.Field, ctx spec.ValidationContext) spec.Validation {
+	refv := spec.TypeValidators[spec.WWFFRefDataType.Name]
+	if val == "" || refv(val, f, ctx).Validity == spec.InvalidError {
+		return spec.Validation{Validity: spec.Valid}
+	}
+	if !c.parks[strings.ToUpper(strings.TrimSpace(val))] {
+		return spec.Validation{Validity: spec.InvalidError,
+			Message: fmt.Sprintf("%s unknown park %s in WWFF directory", f.Name, val)}
+	}
+	return spec.Validation{Validity: spec.Valid}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/flwyd/adif-multitool/adif"
+	"github.com/flwyd/adif-multitool/adif/spec"
+)
+
+const testWWFFDirectory = `reference,status,name,program,dxcc,state,county,continent,iota,iaruLocator,latitude,longitude
+KFF-0001,active,Acadia National Park,KFF,K,ME,,NA,,FN54,44.35,-68.21
+ONFF-0042,active,Brackenbos,ONFF,ON,,,EU,,JO20,50.87,4.38
+`
+
+func TestWWFFParkChecker(t *testing.T) {
+	ctx := &Context{fs: fakeFilesystem{map[string]string{"wwff_directory.csv": testWWFFDirectory, "bad.csv": "a,b\n1,2\n"}}}
+	c, err := newWWFFParkChecker(ctx, "wwff_directory.csv")
+	if err != nil {
+		t.Fatalf("newWWFFParkChecker got error %v", err)
+	}
+	tests := []struct {
+		value string
+		want  spec.Validity
+	}{
+		{value: "KFF-0001", want: spec.Valid},
+		{value: "onff-0042", want: spec.Valid},
+		{value: "", want: spec.Valid},
+		{value: "not a park", want: spec.Valid}, // format errors come from spec
+		{value: "KFF-9999", want: spec.InvalidError},
+		{value: "QFF-0001", want: spec.InvalidError},
+	}
+	for _, tc := range tests {
+		got := c.Validate(tc.value, spec.WwffRefField, spec.ValidationContext{FieldValue: func(string) string { return "" }})
+		if got.Validity != tc.want {
+			t.Errorf("Validate(%q) got %v %s, want %v", tc.value, got.Validity, got.Message, tc.want)
+		}
+	}
+	if _, err := newWWFFParkChecker(ctx, "bad.csv"); err == nil {
+		t.Errorf("newWWFFParkChecker(bad.csv) want error")
+	}
+}
+
+func TestValidateWWFFDB(t *testing.T) {
+	for _, tc := range []struct {
+		file    string
+		wantErr bool
+	}{
+		{file: "<CALL:4>W1AW <WWFF_REF:8>KFF-0001 <EOR>\n"},
+		{file: "<CALL:4>W1AW <MY_WWFF_REF:9>ONFF-0042 <WWFF_REF:8>KFF-0002 <EOR>\n", wantErr: true},
+	} {
+		adi := adif.NewADIIO()
+		out := &bytes.Buffer{}
+		ctx := &Context{
+			OutputFormat: adif.FormatADI,
+			Readers:      readers(adi),
+			Writers:      writers(adi),
+			Out:          out,
+			CommandCtx:   &ValidateContext{WWFFDBPath: "wwff_directory.csv"},
+			fs:           fakeFilesystem{map[string]string{"foo.adi": tc.file, "wwff_directory.csv": testWWFFDirectory}}}
+		err := Validate.Run(ctx, []string{"foo.adi"})
+		if tc.wantErr && err == nil {
+			t.Errorf("Validate.Run(%q) with --wwff-db-path want error, got output:\n%s", tc.file, out)
+		} else if !tc.wantErr && err != nil {
+			t.Errorf("Validate.Run(%q) with --wwff-db-path got error %v", tc.file, err)
+		}
+	}
+}